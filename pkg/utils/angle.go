@@ -0,0 +1,113 @@
+package utils
+
+import "math"
+
+// DegreesToRadians converts an angle from degrees to radians.
+//
+// Examples:
+//
+//	DegreesToRadians(180) == math.Pi
+//	DegreesToRadians(90) == math.Pi / 2
+func DegreesToRadians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+// RadiansToDegrees converts an angle from radians to degrees.
+//
+// Examples:
+//
+//	RadiansToDegrees(math.Pi) == 180
+//	RadiansToDegrees(math.Pi / 4) == 45
+func RadiansToDegrees(rad float64) float64 {
+	return rad * 180 / math.Pi
+}
+
+// NormalizeAngle wraps an angle in degrees into the range [0, 360).
+// NaN and infinite inputs return NaN.
+//
+// Examples:
+//
+//	NormalizeAngle(370) == 10
+//	NormalizeAngle(-90) == 270
+//	NormalizeAngle(360) == 0
+func NormalizeAngle(deg float64) float64 {
+	a := math.Mod(deg, 360)
+	if a < 0 {
+		a += 360
+	}
+	// Tiny negative inputs can round up to exactly 360 after the addition above.
+	if a >= 360 {
+		a = 0
+	}
+	return a
+}
+
+// NormalizeAngleSigned wraps an angle in degrees into the range (-180, 180].
+// It is convenient for headings and longitudes.
+//
+// Examples:
+//
+//	NormalizeAngleSigned(270) == -90
+//	NormalizeAngleSigned(-180) == 180
+//	NormalizeAngleSigned(45) == 45
+func NormalizeAngleSigned(deg float64) float64 {
+	a := NormalizeAngle(deg)
+	if a > 180 {
+		a -= 360
+	}
+	return a
+}
+
+// AngleDifference returns the shortest signed rotation in degrees from angle a to angle b,
+// in the range (-180, 180].
+//
+// Examples:
+//
+//	AngleDifference(350, 10) == 20
+//	AngleDifference(10, 350) == -20
+//	AngleDifference(0, 180) == 180
+func AngleDifference(a, b float64) float64 {
+	return NormalizeAngleSigned(b - a)
+}
+
+// SinDeg returns the sine of an angle given in degrees.
+// Multiples of 90 degrees return exact results (e.g. SinDeg(180) == 0), unlike math.Sin(math.Pi).
+//
+// Examples:
+//
+//	SinDeg(30) == 0.49999999999999994
+//	SinDeg(90) == 1
+//	SinDeg(180) == 0
+func SinDeg(deg float64) float64 {
+	a := NormalizeAngle(deg)
+	switch a {
+	case 0, 180:
+		return 0
+	case 90:
+		return 1
+	case 270:
+		return -1
+	}
+	return math.Sin(DegreesToRadians(a))
+}
+
+// CosDeg returns the cosine of an angle given in degrees.
+// Multiples of 90 degrees return exact results (e.g. CosDeg(90) == 0), unlike math.Cos(math.Pi / 2).
+//
+// Examples:
+//
+//	CosDeg(60) == 0.5000000000000001
+//	CosDeg(90) == 0
+//	CosDeg(180) == -1
+func CosDeg(deg float64) float64 {
+	a := NormalizeAngle(deg)
+	switch a {
+	case 0:
+		return 1
+	case 90, 270:
+		return 0
+	case 180:
+		return -1
+	}
+	return math.Cos(DegreesToRadians(a))
+}
@@ -0,0 +1,117 @@
+package utils
+
+import "sync/atomic"
+
+// AtomicValue holds a value of type T that can be loaded and stored atomically, a typed
+// wrapper for sync/atomic.Value. Unlike atomic.Value it accepts any value, including nil
+// interfaces and pointers. The zero value holds the zero value of T and is ready to use.
+// An AtomicValue must not be copied after first use.
+type AtomicValue[T any] struct {
+	v atomic.Value // of atomicBox[T]
+}
+
+// atomicBox wraps values stored in an AtomicValue, so that atomic.Value always sees the
+// same concrete type and never a nil interface.
+type atomicBox[T any] struct {
+	v T
+}
+
+// NewAtomicValue returns an AtomicValue holding v.
+//
+// Examples:
+//
+//	cfg := NewAtomicValue(loadConfig())
+//	go func() { for range reload { cfg.Store(loadConfig()) } }()
+//	timeout := cfg.Load().Timeout
+func NewAtomicValue[T any](v T) *AtomicValue[T] {
+	a := &AtomicValue[T]{}
+	a.Store(v)
+	return a
+}
+
+// Load returns the value held by a.
+func (a *AtomicValue[T]) Load() T {
+	box, _ := a.v.Load().(atomicBox[T])
+	return box.v
+}
+
+// Store sets the value held by a to v.
+func (a *AtomicValue[T]) Store(v T) {
+	a.v.Store(atomicBox[T]{v})
+}
+
+// Swap sets the value held by a to v and returns the previous value.
+func (a *AtomicValue[T]) Swap(v T) T {
+	old, _ := a.v.Swap(atomicBox[T]{v}).(atomicBox[T])
+	return old.v
+}
+
+// CompareAndSwap sets the value held by a to new if it is equal to old, as by ==, and reports
+// whether it did. It panics if T is not comparable at run time, as for slices, maps and
+// functions, or interfaces holding them.
+//
+// Examples:
+//
+//	state := NewAtomicValue("idle")
+//	state.CompareAndSwap("idle", "running") == true
+//	state.CompareAndSwap("idle", "running") == false
+func (a *AtomicValue[T]) CompareAndSwap(old, new T) bool {
+	for {
+		if a.v.CompareAndSwap(atomicBox[T]{old}, atomicBox[T]{new}) {
+			return true
+		}
+		// A never-stored AtomicValue holds the zero value, but atomic.Value sees nil.
+		if a.v.Load() != nil || any(atomicBox[T]{old}) != any(atomicBox[T]{}) {
+			return false
+		}
+		if a.v.CompareAndSwap(nil, atomicBox[T]{new}) {
+			return true
+		}
+	}
+}
+
+// AtomicCounter is an int64 counter that is safe for concurrent use, for metrics such as
+// requests served. The zero value is a counter at 0, ready to use. An AtomicCounter must
+// not be copied after first use.
+type AtomicCounter struct {
+	n atomic.Int64
+}
+
+// Add adds delta to c and returns the new count.
+func (c *AtomicCounter) Add(delta int64) int64 {
+	return c.n.Add(delta)
+}
+
+// Inc adds 1 to c and returns the new count.
+func (c *AtomicCounter) Inc() int64 {
+	return c.n.Add(1)
+}
+
+// Dec subtracts 1 from c and returns the new count.
+func (c *AtomicCounter) Dec() int64 {
+	return c.n.Add(-1)
+}
+
+// Load returns the current count.
+func (c *AtomicCounter) Load() int64 {
+	return c.n.Load()
+}
+
+// Store sets the count to n.
+func (c *AtomicCounter) Store(n int64) {
+	c.n.Store(n)
+}
+
+// SnapshotAndReset returns the current count and resets it to 0 in one atomic step, so
+// that no increment is lost between reading and resetting, as when reporting counts per
+// interval.
+//
+// Examples:
+//
+//	var requests AtomicCounter
+//	for range time.Tick(time.Minute) {
+//		metrics.Record("requests_per_minute", requests.SnapshotAndReset())
+//	}
+func (c *AtomicCounter) SnapshotAndReset() int64 {
+	return c.n.Swap(0)
+}
@@ -0,0 +1,64 @@
+package utils
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// Jitter fractions for BackoffDuration.
+const (
+	// NoJitter returns the exponential delay unchanged.
+	NoJitter = 0.0
+	// EqualJitter keeps half of the delay and randomizes the other half.
+	EqualJitter = 0.5
+	// FullJitter randomizes the whole delay, which spreads out retries from many clients the most.
+	FullJitter = 1.0
+)
+
+// BackoffDuration returns how long to wait before retry number attempt, counting from 0, with
+// exponential backoff: base doubled attempt times, capped at max. If max is zero or negative the
+// delay is only capped at the largest time.Duration.
+// jitter is the fraction of the delay, from 0 to 1, that is randomized: the result is uniformly
+// distributed in (delay*(1-jitter), delay], so FullJitter (1) gives a delay in (0, delay]
+// and EqualJitter (0.5) one in (delay/2, delay]. Values outside [0, 1] are clamped, and NaN
+// means no jitter. A base of zero or less returns 0.
+//
+// Examples:
+//
+//	BackoffDuration(0, 100*time.Millisecond, 10*time.Second, NoJitter) == 100*time.Millisecond
+//	BackoffDuration(3, 100*time.Millisecond, 10*time.Second, NoJitter) == 800*time.Millisecond
+//	BackoffDuration(10, 100*time.Millisecond, 10*time.Second, NoJitter) == 10*time.Second
+//	BackoffDuration(3, 100*time.Millisecond, 10*time.Second, FullJitter) returns a value in (0, 800ms]
+//	BackoffDuration(3, 100*time.Millisecond, 10*time.Second, EqualJitter) returns a value in (400ms, 800ms]
+func BackoffDuration(attempt int, base, max time.Duration, jitter float64) time.Duration {
+	if base <= 0 {
+		return 0
+	}
+	if max <= 0 {
+		max = math.MaxInt64
+	}
+
+	delay := min(base, max)
+	for i := 0; i < attempt && delay < max; i++ {
+		if delay > max/2 {
+			delay = max
+			break
+		}
+		delay *= 2
+	}
+
+	var spread time.Duration
+	switch {
+	case math.IsNaN(jitter) || jitter <= 0:
+		return delay
+	case jitter >= 1:
+		spread = delay
+	default:
+		spread = time.Duration(jitter * float64(delay))
+	}
+	if spread <= 0 {
+		return delay
+	}
+	return delay - time.Duration(rand.Int64N(int64(spread)))
+}
@@ -0,0 +1,126 @@
+package utils
+
+import (
+	"errors"
+	"math"
+)
+
+// baseDigits is the digit alphabet used by ToBase and FromBase.
+// The first 36 digits match strconv, so bases up to 36 are interchangeable with strconv.FormatInt.
+const baseDigits = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// validateBase returns an error if base is outside the supported range [2, 62].
+func validateBase(base int) error {
+	if base < 2 || base > len(baseDigits) {
+		return errors.New("base must be between 2 and 62")
+	}
+	return nil
+}
+
+// ToBase converts an integer to its string representation in the given base (2 to 62).
+// Digits above 9 use lowercase letters first and then uppercase letters, so base 62 is
+// suitable for short URL identifiers. Negative numbers are prefixed with '-'.
+// It returns an error if the base is out of range.
+//
+// Examples:
+//
+//	ToBase(255, 16) == ("ff", nil)
+//	ToBase(-5, 2) == ("-101", nil)
+//	ToBase(61, 62) == ("Z", nil)
+//	ToBase(3844, 62) == ("100", nil)
+//	ToBase(10, 63) returns an error
+func ToBase(n int64, base int) (string, error) {
+	if err := validateBase(base); err != nil {
+		return "", err
+	}
+	if n == 0 {
+		return "0", nil
+	}
+	// Work with the magnitude as a uint64 so that math.MinInt64 is handled correctly.
+	u := uint64(n)
+	if n < 0 {
+		u = -u
+	}
+	var buf [65]byte
+	i := len(buf)
+	for u > 0 {
+		i--
+		buf[i] = baseDigits[u%uint64(base)]
+		u /= uint64(base)
+	}
+	if n < 0 {
+		i--
+		buf[i] = '-'
+	}
+	return string(buf[i:]), nil
+}
+
+// FromBase parses a string in the given base (2 to 62) produced by ToBase or a compatible encoder.
+// For bases up to 36, letters are accepted in either case; above 36 the case is significant.
+// An optional leading '+' or '-' sign is accepted.
+// It returns an error if the base is out of range, the string contains invalid digits,
+// or the value does not fit in an int64 (ErrOverflow).
+//
+// Examples:
+//
+//	FromBase("ff", 16) == (255, nil)
+//	FromBase("FF", 16) == (255, nil)
+//	FromBase("-101", 2) == (-5, nil)
+//	FromBase("Z", 62) == (61, nil)
+//	FromBase("12", 2) returns an error
+//	FromBase("aZl8N0y58M8", 62) returns (0, ErrOverflow)
+func FromBase(s string, base int) (int64, error) {
+	if err := validateBase(base); err != nil {
+		return 0, err
+	}
+	negative := false
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		negative = s[0] == '-'
+		s = s[1:]
+	}
+	if s == "" {
+		return 0, errors.New("string contains no digits")
+	}
+
+	limit := uint64(math.MaxInt64)
+	if negative {
+		limit++
+	}
+	var u uint64
+	for i := 0; i < len(s); i++ {
+		d := baseDigitValue(s[i], base)
+		if d < 0 {
+			return 0, errors.New("invalid digit for base")
+		}
+		if u > (limit-uint64(d))/uint64(base) {
+			return 0, ErrOverflow
+		}
+		u = u*uint64(base) + uint64(d)
+	}
+	if negative {
+		return int64(-u), nil
+	}
+	return int64(u), nil
+}
+
+// baseDigitValue returns the value of digit c in the given base, or -1 if c is not a valid digit.
+func baseDigitValue(c byte, base int) int {
+	var d int
+	switch {
+	case c >= '0' && c <= '9':
+		d = int(c - '0')
+	case c >= 'a' && c <= 'z':
+		d = int(c-'a') + 10
+	case c >= 'A' && c <= 'Z':
+		d = int(c-'A') + 36
+		if base <= 36 {
+			d -= 26
+		}
+	default:
+		return -1
+	}
+	if d >= base {
+		return -1
+	}
+	return d
+}
@@ -0,0 +1,129 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrBatcherClosed is returned by Batcher.Add after the Batcher has been closed.
+var ErrBatcherClosed = errors.New("batcher is closed")
+
+// Batcher accumulates items and passes them to a flush function in batches, as soon as a
+// batch reaches its maximum size or its oldest item has waited the maximum latency, which
+// suits bulk inserts and log shipping. Flushes run one at a time on the Batcher's own
+// goroutine, and Add blocks while one is running, so a slow flush slows producers down
+// rather than letting items pile up. It is safe for concurrent use.
+type Batcher[T any] struct {
+	maxSize    int
+	maxLatency time.Duration
+	flush      func(batch []T)
+
+	in        chan T
+	quit      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewBatcher returns a running Batcher that calls flush with batches of up to maxSize items,
+// flushing a partial batch once its first item is maxLatency old. The batch slice is owned by
+// flush, which may keep it. A maxSize below 1 is treated as 1, and a maxLatency of zero or
+// less only flushes full batches and on Close. Close must be called to flush the last batch
+// and stop the Batcher's goroutine.
+//
+// Examples:
+//
+//	b := NewBatcher(500, time.Second, func(rows []Row) {
+//		if err := db.BulkInsert(rows); err != nil {
+//			log.Printf("bulk insert of %d rows failed: %v", len(rows), err)
+//		}
+//	})
+//	defer b.Close(context.Background())
+func NewBatcher[T any](maxSize int, maxLatency time.Duration, flush func(batch []T)) *Batcher[T] {
+	b := &Batcher[T]{
+		maxSize:    max(maxSize, 1),
+		maxLatency: maxLatency,
+		flush:      flush,
+		in:         make(chan T),
+		quit:       make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+	go b.run()
+	return b
+}
+
+// Add adds item to the current batch, blocking while a flush is running. It returns
+// ErrBatcherClosed if b has been closed, or ctx's error if ctx is done before the item is
+// accepted; in both cases the item is not added.
+func (b *Batcher[T]) Add(ctx context.Context, item T) error {
+	select {
+	case <-b.quit:
+		return ErrBatcherClosed
+	default:
+	}
+	select {
+	case b.in <- item:
+		return nil
+	case <-b.quit:
+		return ErrBatcherClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close stops b from accepting items, flushes the items already added, and waits for the
+// flush to finish or for ctx to be done, in which case it returns ctx's error and the flush
+// carries on in the background. Calling Close again waits in the same way.
+func (b *Batcher[T]) Close(ctx context.Context) error {
+	b.closeOnce.Do(func() { close(b.quit) })
+	select {
+	case <-b.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run collects items into batches and flushes them until b is closed.
+func (b *Batcher[T]) run() {
+	defer close(b.done)
+	var (
+		batch   []T
+		timer   *time.Timer
+		timeout <-chan time.Time // nil, blocking forever, while there is no partial batch
+	)
+	flush := func() {
+		if timer != nil {
+			timer.Stop()
+			timeout = nil
+		}
+		if len(batch) > 0 {
+			b.flush(batch)
+			batch = nil
+		}
+	}
+
+	for {
+		select {
+		case item := <-b.in:
+			if batch == nil {
+				batch = make([]T, 0, b.maxSize)
+				if b.maxLatency > 0 {
+					timer = time.NewTimer(b.maxLatency)
+					timeout = timer.C
+				}
+			}
+			batch = append(batch, item)
+			if len(batch) >= b.maxSize {
+				flush()
+			}
+		case <-timeout:
+			timeout = nil
+			flush()
+		case <-b.quit:
+			flush()
+			return
+		}
+	}
+}
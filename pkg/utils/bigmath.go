@@ -0,0 +1,88 @@
+package utils
+
+import (
+	"math/big"
+	"sync"
+)
+
+// bigMemoLimit bounds how many Factorial and Fibonacci results are kept in memory.
+// Larger inputs are still supported; they are computed from the last memoized value.
+const bigMemoLimit = 1000
+
+var (
+	factorialMu   sync.Mutex
+	factorialMemo = []*big.Int{big.NewInt(1)}
+
+	fibonacciMu   sync.Mutex
+	fibonacciMemo = []*big.Int{big.NewInt(0), big.NewInt(1)}
+)
+
+// Factorial returns n! as an arbitrary-precision integer.
+// Results up to an internal limit are memoized, so repeated calls are cheap.
+// The returned value is a fresh copy that the caller may modify.
+// It returns nil if n is negative.
+//
+// Examples:
+//
+//	Factorial(0).String() == "1"
+//	Factorial(5).String() == "120"
+//	Factorial(25).String() == "15511210043330985984000000"
+//	Factorial(-1) == nil
+func Factorial(n int) *big.Int {
+	if n < 0 {
+		return nil
+	}
+	factorialMu.Lock()
+	defer factorialMu.Unlock()
+
+	for i := len(factorialMemo); i <= n && i <= bigMemoLimit; i++ {
+		next := new(big.Int).Mul(factorialMemo[i-1], big.NewInt(int64(i)))
+		factorialMemo = append(factorialMemo, next)
+	}
+	if n < len(factorialMemo) {
+		return new(big.Int).Set(factorialMemo[n])
+	}
+
+	result := new(big.Int).Set(factorialMemo[len(factorialMemo)-1])
+	for i := len(factorialMemo); i <= n; i++ {
+		result.Mul(result, big.NewInt(int64(i)))
+	}
+	return result
+}
+
+// Fibonacci returns the n-th Fibonacci number as an arbitrary-precision integer,
+// where Fibonacci(0) == 0 and Fibonacci(1) == 1.
+// Results up to an internal limit are memoized, so repeated calls are cheap.
+// The returned value is a fresh copy that the caller may modify.
+// It returns nil if n is negative.
+//
+// Examples:
+//
+//	Fibonacci(0).String() == "0"
+//	Fibonacci(10).String() == "55"
+//	Fibonacci(100).String() == "354224848179261915075"
+//	Fibonacci(-1) == nil
+func Fibonacci(n int) *big.Int {
+	if n < 0 {
+		return nil
+	}
+	fibonacciMu.Lock()
+	defer fibonacciMu.Unlock()
+
+	for i := len(fibonacciMemo); i <= n && i <= bigMemoLimit; i++ {
+		next := new(big.Int).Add(fibonacciMemo[i-1], fibonacciMemo[i-2])
+		fibonacciMemo = append(fibonacciMemo, next)
+	}
+	if n < len(fibonacciMemo) {
+		return new(big.Int).Set(fibonacciMemo[n])
+	}
+
+	last := len(fibonacciMemo) - 1
+	a := new(big.Int).Set(fibonacciMemo[last-1])
+	b := new(big.Int).Set(fibonacciMemo[last])
+	for i := last; i < n; i++ {
+		a.Add(a, b)
+		a, b = b, a
+	}
+	return b
+}
@@ -0,0 +1,215 @@
+package utils
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash/fnv"
+	"math"
+	"math/bits"
+)
+
+// bloomMagic starts the binary encoding of a BloomFilter, followed by a version byte.
+const bloomMagic = "BLM"
+
+// BloomFilter is a probabilistic set: MayContain never misses an item that was added, but may
+// report items that were not, at a rate set when the filter is created. It takes a few bits
+// per item however large the items are. Items cannot be removed. The hashes are stable across
+// processes, so encoded filters can be stored and shared. It is not safe for concurrent writes.
+// The zero value holds no bits: Add ignores items and MayContain reports false, until it is
+// replaced with UnmarshalBinary. Use NewBloomFilter to get a usable filter.
+type BloomFilter struct {
+	bits []uint64
+	m    uint64 // number of bits
+	k    uint32 // number of hash functions
+}
+
+// NewBloomFilter returns an empty filter sized to hold expectedItems with a false-positive
+// rate of at most falsePositiveRate; adding more items than expected raises the rate.
+// An expectedItems below 1 is treated as 1, and a falsePositiveRate outside (0, 1) as 0.01.
+//
+// Examples:
+//
+//	seen := NewBloomFilter(1_000_000, 0.001) // about 1.8 MB
+//	if !seen.MayContainString(url) {
+//		seen.AddString(url)
+//		crawl(url)
+//	}
+func NewBloomFilter(expectedItems int, falsePositiveRate float64) *BloomFilter {
+	n := float64(max(expectedItems, 1))
+	p := falsePositiveRate
+	if !(p > 0 && p < 1) {
+		p = 0.01
+	}
+	m := math.Ceil(-n * math.Log(p) / (math.Ln2 * math.Ln2))
+	k := math.Round(m / n * math.Ln2)
+	return newBloomFilter(uint64(min(max(m, 1), math.MaxInt64)), uint32(min(max(k, 1), math.MaxUint32)))
+}
+
+// newBloomFilter returns an empty filter of m bits and k hash functions.
+func newBloomFilter(m uint64, k uint32) *BloomFilter {
+	return &BloomFilter{bits: make([]uint64, bloomWords(m)), m: m, k: k}
+}
+
+// bloomWords returns the number of 64-bit words that hold m bits, without overflowing for any m.
+func bloomWords(m uint64) uint64 {
+	words := m / 64
+	if m%64 != 0 {
+		words++
+	}
+	return words
+}
+
+// Add adds data to f. It does nothing if f is the zero BloomFilter.
+func (f *BloomFilter) Add(data []byte) {
+	if f.m == 0 {
+		return
+	}
+	h1, h2 := bloomHash(data)
+	for i := uint64(0); i < uint64(f.k); i++ {
+		bit := (h1 + i*h2) % f.m
+		f.bits[bit/64] |= 1 << (bit % 64)
+	}
+}
+
+// AddString adds s to f.
+func (f *BloomFilter) AddString(s string) {
+	f.Add([]byte(s))
+}
+
+// MayContain reports whether data may have been added to f. False means it certainly was
+// not; true means it probably was. It reports false if f is the zero BloomFilter.
+func (f *BloomFilter) MayContain(data []byte) bool {
+	if f.m == 0 {
+		return false
+	}
+	h1, h2 := bloomHash(data)
+	for i := uint64(0); i < uint64(f.k); i++ {
+		bit := (h1 + i*h2) % f.m
+		if f.bits[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// MayContainString reports whether s may have been added to f.
+func (f *BloomFilter) MayContainString(s string) bool {
+	return f.MayContain([]byte(s))
+}
+
+// Merge adds the items of other to f, so that f reports the items added to either.
+// It returns an error if the filters were not created with the same size and hash count.
+//
+// Examples:
+//
+//	if err := seen.Merge(workerSeen); err != nil {
+//		return err
+//	}
+func (f *BloomFilter) Merge(other *BloomFilter) error {
+	if f.m != other.m || f.k != other.k {
+		return errors.New("bloom filters differ in size or hash count")
+	}
+	for i, w := range other.bits {
+		f.bits[i] |= w
+	}
+	return nil
+}
+
+// Union returns a new filter holding the items of both f and other.
+// It returns an error if the filters were not created with the same size and hash count.
+func (f *BloomFilter) Union(other *BloomFilter) (*BloomFilter, error) {
+	u := f.Clone()
+	if err := u.Merge(other); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// Clone returns a copy of f, which can be changed without affecting f.
+func (f *BloomFilter) Clone() *BloomFilter {
+	return &BloomFilter{bits: append([]uint64(nil), f.bits...), m: f.m, k: f.k}
+}
+
+// BitSize returns the number of bits in f.
+func (f *BloomFilter) BitSize() uint64 {
+	return f.m
+}
+
+// HashCount returns the number of hash functions f uses per item.
+func (f *BloomFilter) HashCount() int {
+	return int(f.k)
+}
+
+// EstimatedCount returns an estimate of the number of distinct items added to f, from the
+// share of bits set.
+func (f *BloomFilter) EstimatedCount() int {
+	if f.m == 0 {
+		return 0
+	}
+	set := 0
+	for _, w := range f.bits {
+		set += bits.OnesCount64(w)
+	}
+	if uint64(set) >= f.m {
+		return math.MaxInt
+	}
+	m, k := float64(f.m), float64(f.k)
+	return int(math.Round(-m / k * math.Log1p(-float64(set)/m)))
+}
+
+// MarshalBinary encodes f as the magic string "BLM", a version byte, the hash count as a
+// 32-bit and the bit size as a 64-bit unsigned integer, then the bits as 64-bit words, all
+// little-endian.
+func (f *BloomFilter) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, 0, len(bloomMagic)+1+4+8+8*len(f.bits))
+	buf = append(buf, bloomMagic...)
+	buf = append(buf, 1)
+	buf = binary.LittleEndian.AppendUint32(buf, f.k)
+	buf = binary.LittleEndian.AppendUint64(buf, f.m)
+	for _, w := range f.bits {
+		buf = binary.LittleEndian.AppendUint64(buf, w)
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary decodes f from the encoding of MarshalBinary, replacing its contents.
+// It returns an error if data is not such an encoding.
+//
+// Examples:
+//
+//	var seen BloomFilter
+//	if err := seen.UnmarshalBinary(data); err != nil {
+//		return err
+//	}
+func (f *BloomFilter) UnmarshalBinary(data []byte) error {
+	const header = len(bloomMagic) + 1 + 4 + 8
+	if len(data) < header || string(data[:len(bloomMagic)]) != bloomMagic {
+		return errors.New("invalid bloom filter encoding")
+	}
+	if data[len(bloomMagic)] != 1 {
+		return errors.New("unsupported bloom filter encoding version")
+	}
+	k := binary.LittleEndian.Uint32(data[len(bloomMagic)+1:])
+	m := binary.LittleEndian.Uint64(data[len(bloomMagic)+5:])
+	words := data[header:]
+	if k == 0 || m == 0 || len(words)%8 != 0 || uint64(len(words)/8) != bloomWords(m) {
+		return errors.New("invalid bloom filter encoding")
+	}
+	g := newBloomFilter(m, k)
+	for i := range g.bits {
+		g.bits[i] = binary.LittleEndian.Uint64(words[8*i:])
+	}
+	*f = *g
+	return nil
+}
+
+// bloomHash returns the two hashes of data from which a BloomFilter derives its k bit
+// positions, by double hashing. h2 is made odd so that it is never zero, which would put all
+// k positions on the same bit.
+func bloomHash(data []byte) (h1, h2 uint64) {
+	h := fnv.New128a()
+	h.Write(data)
+	var sum [16]byte
+	h.Sum(sum[:0])
+	return binary.BigEndian.Uint64(sum[:8]), binary.BigEndian.Uint64(sum[8:]) | 1
+}
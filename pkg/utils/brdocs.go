@@ -0,0 +1,167 @@
+package utils
+
+import (
+	"errors"
+	"strings"
+)
+
+// brDocDigits strips the punctuation used to format CPF and CNPJ numbers
+// and reports whether exactly n ASCII digits remain.
+func brDocDigits(s string, n int) (string, bool) {
+	digits := strings.NewReplacer(".", "", "-", "", "/", "", " ", "").Replace(s)
+	if len(digits) != n {
+		return "", false
+	}
+	for i := 0; i < n; i++ {
+		if digits[i] < '0' || digits[i] > '9' {
+			return "", false
+		}
+	}
+	return digits, true
+}
+
+// brDocCheckDigit computes a mod-11 check digit over digits using the given weights.
+func brDocCheckDigit(digits string, weights []int) byte {
+	sum := 0
+	for i, w := range weights {
+		sum += int(digits[i]-'0') * w
+	}
+	r := sum % 11
+	if r < 2 {
+		return '0'
+	}
+	return byte('0' + 11 - r)
+}
+
+// allSameDigit reports whether every digit in s is the same, as in "11111111111".
+// Such numbers pass the checksums but are never issued.
+func allSameDigit(s string) bool {
+	return strings.Count(s, s[:1]) == len(s)
+}
+
+// cpfDigits returns the 11 digits of cpf if it is a valid CPF.
+func cpfDigits(cpf string) (string, bool) {
+	digits, ok := brDocDigits(cpf, 11)
+	if !ok || allSameDigit(digits) {
+		return "", false
+	}
+	first := brDocCheckDigit(digits, []int{10, 9, 8, 7, 6, 5, 4, 3, 2})
+	second := brDocCheckDigit(digits, []int{11, 10, 9, 8, 7, 6, 5, 4, 3, 2})
+	return digits, digits[9] == first && digits[10] == second
+}
+
+// cnpjDigits returns the 14 digits of cnpj if it is a valid CNPJ.
+func cnpjDigits(cnpj string) (string, bool) {
+	digits, ok := brDocDigits(cnpj, 14)
+	if !ok || allSameDigit(digits) {
+		return "", false
+	}
+	first := brDocCheckDigit(digits, []int{5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2})
+	second := brDocCheckDigit(digits, []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2})
+	return digits, digits[12] == first && digits[13] == second
+}
+
+// IsValidCPF reports whether s is a valid Brazilian CPF (Cadastro de Pessoas Físicas) number:
+// 11 digits, optionally formatted as "000.000.000-00", whose two check digits are correct.
+// Numbers made of a single repeated digit are rejected.
+//
+// Examples:
+//
+//	IsValidCPF("529.982.247-25") == true
+//	IsValidCPF("52998224725") == true
+//	IsValidCPF("529.982.247-26") == false
+//	IsValidCPF("111.111.111-11") == false
+func IsValidCPF(s string) bool {
+	_, ok := cpfDigits(s)
+	return ok
+}
+
+// IsValidCNPJ reports whether s is a valid Brazilian CNPJ (Cadastro Nacional da Pessoa Jurídica) number:
+// 14 digits, optionally formatted as "00.000.000/0000-00", whose two check digits are correct.
+// Numbers made of a single repeated digit are rejected.
+//
+// Examples:
+//
+//	IsValidCNPJ("11.222.333/0001-81") == true
+//	IsValidCNPJ("11222333000181") == true
+//	IsValidCNPJ("11.222.333/0001-82") == false
+//	IsValidCNPJ("00.000.000/0000-00") == false
+func IsValidCNPJ(s string) bool {
+	_, ok := cnpjDigits(s)
+	return ok
+}
+
+// FormatCPF returns a valid CPF in the standard "000.000.000-00" form.
+// It returns an error if s is not a valid CPF according to IsValidCPF.
+//
+// Examples:
+//
+//	FormatCPF("52998224725") == ("529.982.247-25", nil)
+//	FormatCPF("529 982 247 25") == ("529.982.247-25", nil)
+//	FormatCPF("12345678900") returns an error
+func FormatCPF(s string) (string, error) {
+	digits, ok := cpfDigits(s)
+	if !ok {
+		return "", errors.New("invalid CPF")
+	}
+	return formatCPFDigits(digits), nil
+}
+
+// FormatCNPJ returns a valid CNPJ in the standard "00.000.000/0000-00" form.
+// It returns an error if s is not a valid CNPJ according to IsValidCNPJ.
+//
+// Examples:
+//
+//	FormatCNPJ("11222333000181") == ("11.222.333/0001-81", nil)
+//	FormatCNPJ("11222333000182") returns an error
+func FormatCNPJ(s string) (string, error) {
+	digits, ok := cnpjDigits(s)
+	if !ok {
+		return "", errors.New("invalid CNPJ")
+	}
+	return formatCNPJDigits(digits), nil
+}
+
+// MaskCPF formats a valid CPF like FormatCPF, with all but the last five digits
+// replaced by a masking character using Mask, as is common on receipts and screens.
+// It returns an error if s is not a valid CPF.
+//
+// Examples:
+//
+//	MaskCPF("52998224725") == ("***.***.247-25", nil)
+//	MaskCPF("529.982.247-25", '#') == ("###.###.247-25", nil)
+func MaskCPF(s string, maskChar ...rune) (string, error) {
+	digits, ok := cpfDigits(s)
+	if !ok {
+		return "", errors.New("invalid CPF")
+	}
+	return formatCPFDigits(Mask(digits, 5, maskChar...)), nil
+}
+
+// MaskCNPJ formats a valid CNPJ like FormatCNPJ, with all but the branch number and
+// check digits (the last six digits) replaced by a masking character using Mask.
+// It returns an error if s is not a valid CNPJ.
+//
+// Examples:
+//
+//	MaskCNPJ("11222333000181") == ("**.***.***/0001-81", nil)
+func MaskCNPJ(s string, maskChar ...rune) (string, error) {
+	digits, ok := cnpjDigits(s)
+	if !ok {
+		return "", errors.New("invalid CNPJ")
+	}
+	return formatCNPJDigits(Mask(digits, 6, maskChar...)), nil
+}
+
+// formatCPFDigits punctuates an 11-character CPF string. It works on runes
+// so that digits replaced by a multi-byte masking character are kept intact.
+func formatCPFDigits(digits string) string {
+	r := []rune(digits)
+	return string(r[0:3]) + "." + string(r[3:6]) + "." + string(r[6:9]) + "-" + string(r[9:11])
+}
+
+// formatCNPJDigits punctuates a 14-character CNPJ string, working on runes like formatCPFDigits.
+func formatCNPJDigits(digits string) string {
+	r := []rune(digits)
+	return string(r[0:2]) + "." + string(r[2:5]) + "." + string(r[5:8]) + "/" + string(r[8:12]) + "-" + string(r[12:14])
+}
@@ -0,0 +1,121 @@
+package utils
+
+import "time"
+
+// HolidayFunc reports whether t falls on a holiday. It is called with times at noon of the
+// day in question, in the location of the times passed to the business-day functions.
+// A HolidayFunc that reports every weekday as a holiday makes AddBusinessDays loop forever.
+type HolidayFunc func(t time.Time) bool
+
+// Holidays returns a HolidayFunc matching the calendar dates of the given times,
+// compared by year, month and day in each time's own location.
+//
+// Examples:
+//
+//	newYear := Holidays(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+//	newYear(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)) == true
+//	newYear(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)) == false
+func Holidays(dates ...time.Time) HolidayFunc {
+	set := make(map[[3]int]bool, len(dates))
+	for _, d := range dates {
+		set[dateKey(d)] = true
+	}
+	return func(t time.Time) bool {
+		return set[dateKey(t)]
+	}
+}
+
+// dateKey returns the calendar date of t as a comparable value.
+func dateKey(t time.Time) [3]int {
+	y, m, d := t.Date()
+	return [3]int{y, int(m), d}
+}
+
+// noonOf returns noon on the calendar date of t, in t's location. Stepping a day at a time
+// from noon stays on the right date across daylight saving changes.
+func noonOf(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 12, 0, 0, 0, t.Location())
+}
+
+// IsBusinessDay reports whether t falls on a weekday (Monday to Friday) that none of
+// holidays reports as a holiday.
+//
+// Examples:
+//
+//	IsBusinessDay(time.Date(2024, 5, 3, 0, 0, 0, 0, time.UTC)) == true // Friday
+//	IsBusinessDay(time.Date(2024, 5, 4, 0, 0, 0, 0, time.UTC)) == false // Saturday
+//	IsBusinessDay(christmas, Holidays(christmas)) == false
+func IsBusinessDay(t time.Time, holidays ...HolidayFunc) bool {
+	if wd := t.Weekday(); wd == time.Saturday || wd == time.Sunday {
+		return false
+	}
+	noon := noonOf(t)
+	for _, isHoliday := range holidays {
+		if isHoliday(noon) {
+			return false
+		}
+	}
+	return true
+}
+
+// AddBusinessDays returns t moved forward n business days, or backward if n is negative,
+// skipping weekends and any day that one of holidays reports, as SLA deadlines are computed.
+// The time of day and location of t are kept. If n is 0, t is returned unchanged even if it
+// is not a business day.
+//
+// Examples:
+//
+//	friday := time.Date(2024, 5, 3, 9, 0, 0, 0, time.UTC)
+//	AddBusinessDays(friday, 1) == time.Date(2024, 5, 6, 9, 0, 0, 0, time.UTC) // Monday
+//	AddBusinessDays(friday, -5) == time.Date(2024, 4, 26, 9, 0, 0, 0, time.UTC)
+//	AddBusinessDays(friday, 1, Holidays(time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC))) is Tuesday, May 7
+func AddBusinessDays(t time.Time, n int, holidays ...HolidayFunc) time.Time {
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+	days := 0
+	for day := noonOf(t); n > 0; {
+		day = day.AddDate(0, 0, step)
+		days += step
+		if IsBusinessDay(day, holidays...) {
+			n--
+		}
+	}
+	return t.AddDate(0, 0, days)
+}
+
+// BusinessDaysBetween returns the number of business days after a's date up to and including
+// b's date, skipping weekends and holidays, so that AddBusinessDays(a, n) is n business days
+// after a. If b is before a, it returns minus the number of business days from b's date up to
+// but excluding a's date, so that BusinessDaysBetween(a, AddBusinessDays(a, n)) == n for
+// negative n as well. Times of day are ignored and b is read in a's location.
+//
+// Examples:
+//
+//	monday := time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC)
+//	BusinessDaysBetween(monday, monday.AddDate(0, 0, 7)) == 5
+//	BusinessDaysBetween(monday, monday.AddDate(0, 0, 5)) == 4 // the following Saturday
+//	BusinessDaysBetween(monday.AddDate(0, 0, 7), monday) == -5
+//	BusinessDaysBetween(monday.AddDate(0, 0, -2), monday.AddDate(0, 0, -3)) == -1 // Saturday to Friday
+//	BusinessDaysBetween(monday, monday) == 0
+func BusinessDaysBetween(a, b time.Time, holidays ...HolidayFunc) int {
+	from, to := noonOf(a), noonOf(b.In(a.Location()))
+	if to.Before(from) {
+		count := 0
+		for day := to; day.Before(from); day = day.AddDate(0, 0, 1) {
+			if IsBusinessDay(day, holidays...) {
+				count++
+			}
+		}
+		return -count
+	}
+	count := 0
+	for day := from.AddDate(0, 0, 1); !day.After(to); day = day.AddDate(0, 0, 1) {
+		if IsBusinessDay(day, holidays...) {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,110 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// byteUnitMultipliers maps the lower-cased unit suffixes accepted by ParseBytes to their size in bytes.
+var byteUnitMultipliers = map[string]float64{
+	"": 1, "b": 1,
+	"kb": 1e3, "mb": 1e6, "gb": 1e9, "tb": 1e12, "pb": 1e15, "eb": 1e18,
+	"kib": 1 << 10, "mib": 1 << 20, "gib": 1 << 30, "tib": 1 << 40, "pib": 1 << 50, "eib": 1 << 60,
+	"ki": 1 << 10, "mi": 1 << 20, "gi": 1 << 30, "ti": 1 << 40, "pi": 1 << 50, "ei": 1 << 60,
+	"k": 1 << 10, "m": 1 << 20, "g": 1 << 30, "t": 1 << 40, "p": 1 << 50, "e": 1 << 60,
+}
+
+// formatBytes formats n scaled by powers of base with the given unit names,
+// keeping at most one decimal place.
+func formatBytes(n int64, base float64, units []string) string {
+	if n > -int64(base) && n < int64(base) {
+		return strconv.FormatInt(n, 10) + " B"
+	}
+	value := math.Abs(float64(n))
+	i := 0
+	for value >= base && i < len(units)-1 {
+		value /= base
+		i++
+	}
+	// Rounding may carry into the next unit, as in 1023.96 KiB.
+	if math.Round(value*10)/10 >= base && i < len(units)-1 {
+		value /= base
+		i++
+	}
+	s := strconv.FormatFloat(value, 'f', 1, 64)
+	s = strings.TrimSuffix(s, ".0")
+	if n < 0 {
+		s = "-" + s
+	}
+	return s + " " + units[i]
+}
+
+// FormatBytes formats a size in bytes with binary (IEC) units, which are powers of 1024,
+// keeping at most one decimal place: "512 B", "1.5 KiB", "2 GiB".
+// Use FormatBytesSI for decimal units.
+//
+// Examples:
+//
+//	FormatBytes(512) == "512 B"
+//	FormatBytes(1536) == "1.5 KiB"
+//	FormatBytes(1610612736) == "1.5 GiB"
+//	FormatBytes(-2048) == "-2 KiB"
+func FormatBytes(n int64) string {
+	return formatBytes(n, 1024, []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"})
+}
+
+// FormatBytesSI formats a size in bytes with decimal (SI) units, which are powers of 1000,
+// keeping at most one decimal place: "512 B", "1.5 kB", "2 GB", as disk vendors and network
+// rates use.
+//
+// Examples:
+//
+//	FormatBytesSI(999) == "999 B"
+//	FormatBytesSI(1500) == "1.5 kB"
+//	FormatBytesSI(2000000000) == "2 GB"
+func FormatBytesSI(n int64) string {
+	return formatBytes(n, 1000, []string{"B", "kB", "MB", "GB", "TB", "PB", "EB"})
+}
+
+// ParseBytes parses a size such as "512MB", "1.5 GiB" or "64k" into a number of bytes.
+// Units are case-insensitive and may be separated from the number by spaces. SI units (kB, MB,
+// GB, TB, PB, EB) are powers of 1000, IEC units (KiB, MiB, ... or Ki, Mi, ... as Kubernetes writes
+// them) are powers of 1024, and the single letters K, M, G, T, P and E are also powers of 1024,
+// as in nginx and JVM settings. A number without a unit, or with "B", is a count of bytes.
+// Fractional results are rounded to the nearest byte.
+// It returns ErrOverflow if the size does not fit in an int64, or an error if s is malformed or negative.
+//
+// Examples:
+//
+//	ParseBytes("512MB") == (512000000, nil)
+//	ParseBytes("1.5 GiB") == (1610612736, nil)
+//	ParseBytes("64k") == (65536, nil)
+//	ParseBytes("100") == (100, nil)
+//	ParseBytes("12 parsecs") returns an error
+func ParseBytes(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	i := 0
+	for i < len(s) && (s[i] >= '0' && s[i] <= '9' || s[i] == '.') {
+		i++
+	}
+	if i == 0 {
+		return 0, fmt.Errorf("invalid byte size %q: must start with a non-negative number", s)
+	}
+	value, err := strconv.ParseFloat(s[:i], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid byte size %q", s)
+	}
+	unit := strings.ToLower(strings.TrimSpace(s[i:]))
+	multiplier, ok := byteUnitMultipliers[unit]
+	if !ok {
+		return 0, fmt.Errorf("invalid byte size %q: unknown unit %q", s, strings.TrimSpace(s[i:]))
+	}
+	size := math.Round(value * multiplier)
+	// float64(math.MaxInt64) rounds up to 2^63, so the upper bound must be exclusive.
+	if size >= math.MaxInt64 {
+		return 0, ErrOverflow
+	}
+	return int64(size), nil
+}
@@ -0,0 +1,128 @@
+package utils
+
+import (
+	"context"
+	"sync"
+)
+
+// FanOut distributes the values received from in over n channels, so that n consumers
+// can process them in parallel. Each value goes to exactly one output, and each output takes
+// its next value from in only once its consumer has received the previous one, so a slow
+// consumer holds at most one value and does not hold back the others. Every output is closed
+// once in is closed and drained. If n is less than 1, one output is returned. Wrap in with
+// OrDone to stop early when a context is done.
+//
+// Examples:
+//
+//	for _, ch := range FanOut(rows, 4) {
+//		go func() {
+//			for row := range ch {
+//				insert(row)
+//			}
+//		}()
+//	}
+func FanOut[T any](in <-chan T, n int) []<-chan T {
+	n = max(n, 1)
+	outs := make([]<-chan T, n)
+	for i := range outs {
+		out := make(chan T)
+		outs[i] = out
+		go func() {
+			defer close(out)
+			for v := range in {
+				out <- v
+			}
+		}()
+	}
+	return outs
+}
+
+// FanIn merges the values received from chans into one channel, in the order they arrive.
+// The result is closed once every input is closed and drained; with no inputs it is
+// closed at once.
+//
+// Examples:
+//
+//	for result := range FanIn(workerResults...) {
+//		write(result)
+//	}
+func FanIn[T any](chans ...<-chan T) <-chan T {
+	out := make(chan T)
+	var wg sync.WaitGroup
+	wg.Add(len(chans))
+	for _, ch := range chans {
+		go func() {
+			defer wg.Done()
+			for v := range ch {
+				out <- v
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
+
+// Tee copies every value received from in to both returned channels, which are closed once
+// in is closed and drained. Each value is sent to both outputs before the next is received,
+// so the slower consumer sets the pace of both.
+//
+// Examples:
+//
+//	toDB, toAudit := Tee(events)
+//	go store(toDB)
+//	go audit(toAudit)
+func Tee[T any](in <-chan T) (<-chan T, <-chan T) {
+	out1, out2 := make(chan T), make(chan T)
+	go func() {
+		defer close(out1)
+		defer close(out2)
+		for v := range in {
+			// Send to whichever output is ready first, then to the other.
+			a, b := out1, out2
+			for a != nil || b != nil {
+				select {
+				case a <- v:
+					a = nil
+				case b <- v:
+					b = nil
+				}
+			}
+		}
+	}()
+	return out1, out2
+}
+
+// OrDone returns a channel that forwards the values received from ch until ch is closed or
+// ctx is done, and is then closed, so that a range over it also stops on cancellation.
+// A value received from ch when ctx is done may be dropped.
+//
+// Examples:
+//
+//	for v := range OrDone(ctx, updates) {
+//		apply(v)
+//	}
+func OrDone[T any](ctx context.Context, ch <-chan T) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case v, ok := <-ch:
+				if !ok {
+					return
+				}
+				select {
+				case out <- v:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
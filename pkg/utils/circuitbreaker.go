@@ -0,0 +1,308 @@
+package utils
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned by CircuitBreaker.Execute and Execute, without calling the
+// function, while the breaker is open or its half-open trial calls are all in progress.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// BreakerState is the state of a CircuitBreaker.
+type BreakerState int
+
+const (
+	// BreakerClosed lets calls through and counts their failures.
+	BreakerClosed BreakerState = iota
+	// BreakerOpen rejects calls with ErrCircuitOpen until the open timeout has passed.
+	BreakerOpen
+	// BreakerHalfOpen lets a limited number of trial calls through; if they all succeed the
+	// breaker closes, and if one fails it opens again.
+	BreakerHalfOpen
+)
+
+// String returns "closed", "open" or "half-open".
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// breakerOptions holds the settings applied by BreakerOption values.
+type breakerOptions struct {
+	consecutiveFailures int
+	failureRate         float64
+	minRequests         int
+	window              time.Duration
+	openTimeout         time.Duration
+	halfOpenMax         int
+	onStateChange       func(from, to BreakerState)
+	isFailure           func(error) bool
+}
+
+// BreakerOption configures a CircuitBreaker.
+type BreakerOption func(*breakerOptions)
+
+// BreakerFailureThreshold makes the breaker open after n consecutive failures.
+// The default is 5; 0 disables this threshold.
+func BreakerFailureThreshold(n int) BreakerOption {
+	return func(o *breakerOptions) {
+		o.consecutiveFailures = n
+	}
+}
+
+// BreakerFailureRate makes the breaker open when at least rate (from 0 to 1) of the calls in
+// the current window failed, once the window has at least minRequests calls. It is disabled
+// by default.
+func BreakerFailureRate(rate float64, minRequests int) BreakerOption {
+	return func(o *breakerOptions) {
+		o.failureRate = rate
+		o.minRequests = minRequests
+	}
+}
+
+// BreakerWindow sets the length of the fixed windows over which the failure rate is counted
+// while the breaker is closed. The default is one minute.
+func BreakerWindow(d time.Duration) BreakerOption {
+	return func(o *breakerOptions) {
+		o.window = d
+	}
+}
+
+// BreakerOpenTimeout sets how long the breaker stays open before letting trial calls
+// through in the half-open state. The default is 30 seconds.
+func BreakerOpenTimeout(d time.Duration) BreakerOption {
+	return func(o *breakerOptions) {
+		o.openTimeout = d
+	}
+}
+
+// BreakerHalfOpenMax sets how many trial calls the half-open breaker lets through, all of
+// which must succeed for it to close. The default is 1.
+func BreakerHalfOpenMax(n int) BreakerOption {
+	return func(o *breakerOptions) {
+		o.halfOpenMax = n
+	}
+}
+
+// BreakerOnStateChange sets a function called whenever the breaker changes state, for
+// logging and metrics. It is called without the breaker's lock held.
+func BreakerOnStateChange(fn func(from, to BreakerState)) BreakerOption {
+	return func(o *breakerOptions) {
+		o.onStateChange = fn
+	}
+}
+
+// BreakerFailureIf sets which errors count as failures. By default every non-nil error does;
+// excluding, say, validation errors or context.Canceled keeps them from opening the breaker.
+func BreakerFailureIf(isFailure func(err error) bool) BreakerOption {
+	return func(o *breakerOptions) {
+		o.isFailure = isFailure
+	}
+}
+
+// CircuitBreaker stops calling a failing dependency for a while, so that it can recover and
+// callers fail fast instead of piling up. It starts closed, opens when the failure thresholds
+// are reached, and after the open timeout goes half-open to let trial calls decide whether to
+// close again. It is safe for concurrent use.
+type CircuitBreaker struct {
+	mu          sync.Mutex
+	opts        breakerOptions
+	state       BreakerState
+	generation  uint64 // incremented on each state change, to ignore results from earlier states
+	openedAt    time.Time
+	windowStart time.Time
+	requests    int // calls in the current window, or trial calls started when half-open
+	failures    int // failed calls in the current window
+	consecutive int // consecutive failures
+	successes   int // successful trial calls when half-open
+}
+
+// NewCircuitBreaker returns a closed CircuitBreaker configured by opts.
+//
+// Examples:
+//
+//	cb := NewCircuitBreaker(
+//		BreakerFailureThreshold(5),
+//		BreakerFailureRate(0.5, 20),
+//		BreakerOpenTimeout(10*time.Second),
+//		BreakerOnStateChange(func(from, to BreakerState) { log.Printf("payments breaker %s -> %s", from, to) }),
+//	)
+func NewCircuitBreaker(opts ...BreakerOption) *CircuitBreaker {
+	o := breakerOptions{
+		consecutiveFailures: 5,
+		window:              time.Minute,
+		openTimeout:         30 * time.Second,
+		halfOpenMax:         1,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	o.halfOpenMax = max(o.halfOpenMax, 1)
+	return &CircuitBreaker{opts: o, windowStart: time.Now()}
+}
+
+// State returns the breaker's current state. An open breaker whose timeout has passed reports
+// BreakerHalfOpen.
+func (cb *CircuitBreaker) State() BreakerState {
+	cb.mu.Lock()
+	from := cb.state
+	to := cb.refresh(time.Now())
+	cb.mu.Unlock()
+	cb.notify(from, to)
+	return to
+}
+
+// Reset closes the breaker and clears its counts.
+func (cb *CircuitBreaker) Reset() {
+	cb.mu.Lock()
+	from := cb.state
+	cb.setState(BreakerClosed, time.Now())
+	cb.mu.Unlock()
+	cb.notify(from, BreakerClosed)
+}
+
+// Execute calls fn if the breaker allows it and records the outcome. It returns
+// ErrCircuitOpen without calling fn if the breaker is open, and otherwise fn's error.
+// If fn panics, the call counts as a failure and the panic is propagated.
+//
+// Examples:
+//
+//	err := cb.Execute(func() error { return payments.Charge(ctx, order) })
+//	if errors.Is(err, ErrCircuitOpen) {
+//		// the payment service is down; queue the charge for later
+//	}
+func (cb *CircuitBreaker) Execute(fn func() error) error {
+	_, err := Execute(cb, func() (struct{}, error) {
+		return struct{}{}, fn()
+	})
+	return err
+}
+
+// Execute is CircuitBreaker.Execute for functions that also return a value. It returns the
+// zero value of T with ErrCircuitOpen when the breaker is open.
+//
+// Examples:
+//
+//	quote, err := Execute(cb, func() (Quote, error) { return pricing.Get(ctx, sku) })
+func Execute[T any](cb *CircuitBreaker, fn func() (T, error)) (T, error) {
+	generation, err := cb.before()
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	completed := false
+	defer func() {
+		if !completed {
+			cb.after(generation, true)
+		}
+	}()
+	v, err := fn()
+	completed = true
+	cb.after(generation, err != nil && (cb.opts.isFailure == nil || cb.opts.isFailure(err)))
+	return v, err
+}
+
+// before checks whether a call may proceed and counts it, returning the generation to report
+// its result against.
+func (cb *CircuitBreaker) before() (uint64, error) {
+	cb.mu.Lock()
+	from := cb.state
+	to := cb.refresh(time.Now())
+	var err error
+	switch {
+	case to == BreakerOpen:
+		err = ErrCircuitOpen
+	case to == BreakerHalfOpen && cb.requests >= cb.opts.halfOpenMax:
+		err = ErrCircuitOpen
+	default:
+		cb.requests++
+	}
+	generation := cb.generation
+	cb.mu.Unlock()
+	cb.notify(from, to)
+	return generation, err
+}
+
+// after records the result of a call started in generation.
+func (cb *CircuitBreaker) after(generation uint64, failed bool) {
+	cb.mu.Lock()
+	now := time.Now()
+	from := cb.state
+	if generation == cb.generation {
+		switch cb.state {
+		case BreakerClosed:
+			cb.recordClosed(failed, now)
+		case BreakerHalfOpen:
+			if failed {
+				cb.setState(BreakerOpen, now)
+			} else if cb.successes++; cb.successes >= cb.opts.halfOpenMax {
+				cb.setState(BreakerClosed, now)
+			}
+		}
+	}
+	to := cb.state
+	cb.mu.Unlock()
+	cb.notify(from, to)
+}
+
+// recordClosed counts the result of a call made while closed, opening the breaker if a
+// threshold is reached. cb.mu must be held.
+func (cb *CircuitBreaker) recordClosed(failed bool, now time.Time) {
+	if !failed {
+		cb.consecutive = 0
+		return
+	}
+	cb.failures++
+	cb.consecutive++
+	o := cb.opts
+	if (o.consecutiveFailures > 0 && cb.consecutive >= o.consecutiveFailures) ||
+		(o.failureRate > 0 && cb.requests >= o.minRequests &&
+			float64(cb.failures) >= o.failureRate*float64(cb.requests)) {
+		cb.setState(BreakerOpen, now)
+	}
+}
+
+// refresh applies the state changes due by now, the end of the open timeout and of the
+// failure-rate window, and returns the resulting state. cb.mu must be held.
+func (cb *CircuitBreaker) refresh(now time.Time) BreakerState {
+	switch cb.state {
+	case BreakerOpen:
+		if now.Sub(cb.openedAt) >= cb.opts.openTimeout {
+			cb.setState(BreakerHalfOpen, now)
+		}
+	case BreakerClosed:
+		if cb.opts.window > 0 && now.Sub(cb.windowStart) >= cb.opts.window {
+			cb.windowStart = now
+			cb.requests, cb.failures = 0, 0
+		}
+	}
+	return cb.state
+}
+
+// setState moves the breaker to state and clears its counts. cb.mu must be held.
+func (cb *CircuitBreaker) setState(state BreakerState, now time.Time) {
+	cb.state = state
+	cb.generation++
+	cb.requests, cb.failures, cb.consecutive, cb.successes = 0, 0, 0, 0
+	cb.windowStart = now
+	if state == BreakerOpen {
+		cb.openedAt = now
+	}
+}
+
+// notify calls the state-change callback if the state changed from from to to.
+func (cb *CircuitBreaker) notify(from, to BreakerState) {
+	if from != to && cb.opts.onStateChange != nil {
+		cb.opts.onStateChange(from, to)
+	}
+}
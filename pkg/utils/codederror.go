@@ -0,0 +1,117 @@
+package utils
+
+import "errors"
+
+// CodedError is an error carrying a machine-readable code, such as "not_found", and optional
+// key-value metadata, so transport layers can map internal errors to HTTP or gRPC statuses in
+// one place and log their context. Retrieve it from an error chain with errors.As, or read
+// just the code with CodeOf.
+type CodedError struct {
+	// Code classifies the error, for example "not_found" or "conflict".
+	Code string
+	// Message describes the error; it is followed by the wrapped error's message, if any.
+	Message string
+	// Meta holds extra details, such as the ID of a missing record. It may be nil.
+	Meta map[string]any
+	// Err is the wrapped error, or nil.
+	Err error
+}
+
+// NewCodedError returns a CodedError with code and message, wrapping no error.
+//
+// Examples:
+//
+//	var ErrQuotaExceeded = NewCodedError("resource_exhausted", "quota exceeded")
+func NewCodedError(code, message string) *CodedError {
+	return &CodedError{Code: code, Message: message}
+}
+
+// WrapWithCode returns a CodedError with code and message wrapping err. Check err first: the
+// result is never nil, even if err is.
+//
+// Examples:
+//
+//	if err := db.QueryRow(q, id).Scan(&u); errors.Is(err, sql.ErrNoRows) {
+//		return WrapWithCode(err, "not_found", "user not found").With("user_id", id)
+//	}
+func WrapWithCode(err error, code, message string) *CodedError {
+	return &CodedError{Code: code, Message: message, Err: err}
+}
+
+// With sets the metadata key to value and returns e, for chaining. It changes e, so do not
+// call it on a shared error such as a package-level variable.
+func (e *CodedError) With(key string, value any) *CodedError {
+	if e.Meta == nil {
+		e.Meta = make(map[string]any)
+	}
+	e.Meta[key] = value
+	return e
+}
+
+// Error returns the message, followed by ": " and the wrapped error's message if there is
+// one. The code is left out, since it is meant for programs.
+func (e *CodedError) Error() string {
+	switch {
+	case e.Err == nil:
+		return e.Message
+	case e.Message == "":
+		return e.Err.Error()
+	}
+	return e.Message + ": " + e.Err.Error()
+}
+
+// Unwrap returns the wrapped error.
+func (e *CodedError) Unwrap() error {
+	return e.Err
+}
+
+// CodeOf returns the code of the first CodedError in err's chain, or "" if there is none.
+//
+// Examples:
+//
+//	switch CodeOf(err) {
+//	case "not_found":
+//		w.WriteHeader(http.StatusNotFound)
+//	case "":
+//		w.WriteHeader(http.StatusInternalServerError)
+//	}
+func CodeOf(err error) string {
+	var coded *CodedError
+	if errors.As(err, &coded) {
+		return coded.Code
+	}
+	return ""
+}
+
+// MetaOf returns the metadata of all the CodedErrors in err's chain, merged, with the
+// outermost errors' values taking precedence. It returns nil if there is none.
+func MetaOf(err error) map[string]any {
+	var meta map[string]any
+	var visit func(error)
+	visit = func(err error) {
+		if coded, ok := err.(*CodedError); ok && coded != nil {
+			for k, v := range coded.Meta {
+				if _, ok := meta[k]; !ok {
+					if meta == nil {
+						meta = make(map[string]any)
+					}
+					meta[k] = v
+				}
+			}
+		}
+		switch u := err.(type) {
+		case interface{ Unwrap() error }:
+			if next := u.Unwrap(); next != nil {
+				visit(next)
+			}
+		case interface{ Unwrap() []error }:
+			for _, next := range u.Unwrap() {
+				visit(next)
+			}
+		}
+	}
+	if err != nil {
+		visit(err)
+	}
+	return meta
+}
@@ -0,0 +1,303 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// splitList splits a comma-separated list such as "a, b,c" into trimmed items.
+// An empty or all-whitespace s yields an empty list.
+func splitList(s string) []string {
+	if strings.TrimSpace(s) == "" {
+		return []string{}
+	}
+	items := strings.Split(s, ",")
+	for i, item := range items {
+		items[i] = strings.TrimSpace(item)
+	}
+	return items
+}
+
+// coerceSlice converts each element of the slice or array v, or of the comma-separated
+// list v holds if it is a string, with convert. The name is used in error messages.
+func coerceSlice[T any](v any, name string, convert func(any) (T, error)) ([]T, error) {
+	rv, err := convertValue(v)
+	if err != nil {
+		return nil, err
+	}
+	if rv.Kind() == reflect.String {
+		items := splitList(rv.String())
+		out := make([]T, len(items))
+		for i, item := range items {
+			if out[i], err = convert(item); err != nil {
+				return nil, fmt.Errorf("item %d: %w", i, err)
+			}
+		}
+		return out, nil
+	}
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, fmt.Errorf("cannot convert %T to %s", v, name)
+	}
+	out := make([]T, rv.Len())
+	for i := range out {
+		if out[i], err = convert(rv.Index(i).Interface()); err != nil {
+			return nil, fmt.Errorf("item %d: %w", i, err)
+		}
+	}
+	return out, nil
+}
+
+// ToStringSlice converts v to a []string. Slices and arrays of any element type have each
+// element converted with ToString, and a string is split on commas with surrounding
+// whitespace trimmed from each item, as comma-separated lists are written in env vars and flags.
+// Pointers are dereferenced.
+// It returns an error if v or one of its elements cannot be converted.
+//
+// Examples:
+//
+//	ToStringSlice([]any{"a", 1, true}) == ([]string{"a", "1", "true"}, nil)
+//	ToStringSlice("a, b,c") == ([]string{"a", "b", "c"}, nil)
+//	ToStringSlice("") == ([]string{}, nil)
+//	ToStringSlice(42) returns an error
+func ToStringSlice(v any) ([]string, error) {
+	return coerceSlice(v, "[]string", ToString)
+}
+
+// ToStringSliceOr converts v to a []string as ToStringSlice does, returning def if the conversion fails.
+//
+// Examples:
+//
+//	ToStringSliceOr("x,y", nil) == []string{"x", "y"}
+//	ToStringSliceOr(nil, []string{"*"}) == []string{"*"}
+func ToStringSliceOr(v any, def []string) []string {
+	s, err := ToStringSlice(v)
+	if err != nil {
+		return def
+	}
+	return s
+}
+
+// ToIntSlice converts v to an []int. Slices and arrays of any element type have each element
+// converted with ToInt, and a string is treated as a comma-separated list as in ToStringSlice.
+// It returns an error if v or one of its elements cannot be converted.
+//
+// Examples:
+//
+//	ToIntSlice([]any{1, "2", 3.0}) == ([]int{1, 2, 3}, nil)
+//	ToIntSlice("80, 443") == ([]int{80, 443}, nil)
+//	ToIntSlice([]string{"1", "x"}) returns an error
+func ToIntSlice(v any) ([]int, error) {
+	return coerceSlice(v, "[]int", ToInt)
+}
+
+// ToIntSliceOr converts v to an []int as ToIntSlice does, returning def if the conversion fails.
+//
+// Examples:
+//
+//	ToIntSliceOr("1,2", nil) == []int{1, 2}
+//	ToIntSliceOr("1,two", []int{}) == []int{}
+func ToIntSliceOr(v any, def []int) []int {
+	s, err := ToIntSlice(v)
+	if err != nil {
+		return def
+	}
+	return s
+}
+
+// ToStringMap converts v to a map[string]any. Maps of any key type have their keys converted
+// with ToString, as YAML decoders produce map[any]any, and a string or []byte holding a JSON
+// object is decoded. Values are kept as they are. Pointers are dereferenced.
+// It returns an error if v cannot be converted or a key has no string form.
+//
+// Examples:
+//
+//	ToStringMap(map[any]any{"a": 1, 2: "b"}) == (map[string]any{"a": 1, "2": "b"}, nil)
+//	ToStringMap(`{"port": 8080}`) == (map[string]any{"port": float64(8080)}, nil)
+//	ToStringMap([]int{1}) returns an error
+func ToStringMap(v any) (map[string]any, error) {
+	rv, err := convertValue(v)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case rv.Kind() == reflect.String:
+		return decodeJSONObject([]byte(rv.String()))
+	case rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8:
+		return decodeJSONObject(rv.Bytes())
+	case rv.Kind() != reflect.Map:
+		return nil, fmt.Errorf("cannot convert %T to map[string]any", v)
+	}
+	out := make(map[string]any, rv.Len())
+	iter := rv.MapRange()
+	for iter.Next() {
+		key, err := ToString(iter.Key().Interface())
+		if err != nil {
+			return nil, fmt.Errorf("map key: %w", err)
+		}
+		out[key] = iter.Value().Interface()
+	}
+	return out, nil
+}
+
+// decodeJSONObject decodes data as a JSON object.
+func decodeJSONObject(data []byte) (map[string]any, error) {
+	var m map[string]any
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("cannot convert to map[string]any: %w", err)
+	}
+	if m == nil {
+		return nil, errors.New("cannot convert JSON null to map[string]any")
+	}
+	return m, nil
+}
+
+// ToStringMapOr converts v to a map[string]any as ToStringMap does, returning def if the conversion fails.
+//
+// Examples:
+//
+//	ToStringMapOr(`{"a": 1}`, nil) == map[string]any{"a": float64(1)}
+//	ToStringMapOr("not json", nil) == nil
+func ToStringMapOr(v any, def map[string]any) map[string]any {
+	m, err := ToStringMap(v)
+	if err != nil {
+		return def
+	}
+	return m
+}
+
+// ToDuration converts v to a time.Duration. Durations are returned as-is, numbers are taken
+// as nanoseconds like time.Duration(n) (fractions are rounded), and strings are parsed with
+// time.ParseDuration, with a bare number such as "1500" read as nanoseconds.
+// Pointers are dereferenced.
+// It returns ErrOverflow if a number is out of range, or an error if v cannot be converted.
+//
+// Examples:
+//
+//	ToDuration("1m30s") == (90*time.Second, nil)
+//	ToDuration(int64(time.Second)) == (time.Second, nil)
+//	ToDuration(" 250ms ") == (250*time.Millisecond, nil)
+//	ToDuration("soon") returns an error
+func ToDuration(v any) (time.Duration, error) {
+	if d, ok := v.(time.Duration); ok {
+		return d, nil
+	}
+	rv, err := convertValue(v)
+	if err != nil {
+		return 0, err
+	}
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return time.Duration(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if rv.Uint() > math.MaxInt64 {
+			return 0, ErrOverflow
+		}
+		return time.Duration(rv.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return floatToDuration(rv.Float())
+	case reflect.String:
+		return parseDurationString(rv.String())
+	}
+	return 0, fmt.Errorf("cannot convert %T to time.Duration", v)
+}
+
+// floatToDuration rounds f nanoseconds to a time.Duration.
+func floatToDuration(f float64) (time.Duration, error) {
+	f = math.Round(f)
+	// float64(math.MaxInt64) rounds up to 2^63, so the upper bound must be exclusive.
+	if math.IsNaN(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+		return 0, ErrOverflow
+	}
+	return time.Duration(f), nil
+}
+
+// parseDurationString parses s with time.ParseDuration, reading a bare number as nanoseconds.
+func parseDurationString(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return floatToDuration(f)
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("cannot convert %q to time.Duration", s)
+	}
+	return d, nil
+}
+
+// ToDurationOr converts v to a time.Duration as ToDuration does, returning def if the conversion fails.
+//
+// Examples:
+//
+//	ToDurationOr("5s", time.Second) == 5*time.Second
+//	ToDurationOr("", time.Second) == time.Second
+func ToDurationOr(v any, def time.Duration) time.Duration {
+	d, err := ToDuration(v)
+	if err != nil {
+		return def
+	}
+	return d
+}
+
+// ToTime converts v to a time.Time. Times are returned as-is, integers are taken as Unix
+// seconds, and strings are parsed with ParseDate and DefaultDateLayouts, falling back to
+// Unix seconds for an integer string such as "1700000000". Times from numbers are in UTC.
+// Pointers are dereferenced.
+// It returns an error if v cannot be converted.
+//
+// Examples:
+//
+//	ToTime("2024-05-01") == (time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), nil)
+//	ToTime("2024-05-01T12:00:00Z") == (time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC), nil)
+//	ToTime(int64(0)) == (time.Unix(0, 0).UTC(), nil)
+//	ToTime("next week") returns an error
+func ToTime(v any) (time.Time, error) {
+	if t, ok := v.(time.Time); ok {
+		return t, nil
+	}
+	rv, err := convertValue(v)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if t, ok := rv.Interface().(time.Time); ok {
+		return t, nil
+	}
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return time.Unix(rv.Int(), 0).UTC(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if rv.Uint() > math.MaxInt64 {
+			return time.Time{}, ErrOverflow
+		}
+		return time.Unix(int64(rv.Uint()), 0).UTC(), nil
+	case reflect.String:
+		s := strings.TrimSpace(rv.String())
+		if t, err := ParseDate(s); err == nil {
+			return t, nil
+		}
+		if sec, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return time.Unix(sec, 0).UTC(), nil
+		}
+		return time.Time{}, fmt.Errorf("cannot convert %q to time.Time", s)
+	}
+	return time.Time{}, fmt.Errorf("cannot convert %T to time.Time", v)
+}
+
+// ToTimeOr converts v to a time.Time as ToTime does, returning def if the conversion fails.
+//
+// Examples:
+//
+//	ToTimeOr("2024-05-01", time.Time{}) == time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+//	ToTimeOr("", fallback) == fallback
+func ToTimeOr(v any, def time.Time) time.Time {
+	t, err := ToTime(v)
+	if err != nil {
+		return def
+	}
+	return t
+}
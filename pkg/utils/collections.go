@@ -0,0 +1,219 @@
+package utils
+
+// Stack is a last-in, first-out collection, optionally bounded. The zero value is an empty,
+// unbounded stack ready to use. It is not safe for concurrent use.
+type Stack[T any] struct {
+	items    []T
+	capacity int // 0 for unbounded
+}
+
+// NewStack returns an empty stack. If capacity is given and positive, the stack holds at most
+// that many items and Push reports false when it is full.
+//
+// Examples:
+//
+//	s := NewStack[int]()
+//	s.Push(1)
+//	s.Push(2)
+//	s.Pop() == (2, true)
+//	undo := NewStack[Edit](100) // keeps at most 100 edits
+func NewStack[T any](capacity ...int) *Stack[T] {
+	s := &Stack[T]{}
+	if len(capacity) > 0 && capacity[0] > 0 {
+		s.capacity = capacity[0]
+	}
+	return s
+}
+
+// Push adds item to the top of s, and reports false, without adding it, if s is full.
+func (s *Stack[T]) Push(item T) bool {
+	if s.capacity > 0 && len(s.items) >= s.capacity {
+		return false
+	}
+	s.items = append(s.items, item)
+	return true
+}
+
+// Pop removes and returns the item at the top of s, or returns false if s is empty.
+func (s *Stack[T]) Pop() (T, bool) {
+	var zero T
+	if len(s.items) == 0 {
+		return zero, false
+	}
+	last := len(s.items) - 1
+	item := s.items[last]
+	s.items[last] = zero // let the item be garbage collected
+	s.items = s.items[:last]
+	return item, true
+}
+
+// Peek returns the item at the top of s without removing it, or returns false if s is empty.
+func (s *Stack[T]) Peek() (T, bool) {
+	if len(s.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return s.items[len(s.items)-1], true
+}
+
+// Len returns the number of items in s.
+func (s *Stack[T]) Len() int {
+	return len(s.items)
+}
+
+// Deque is a double-ended queue, which adds and removes items at both ends in constant time,
+// optionally bounded. It is backed by a ring buffer that grows as needed. The zero value is
+// an empty, unbounded deque ready to use. It is not safe for concurrent use.
+type Deque[T any] struct {
+	buf      []T
+	head     int // index of the front item in buf
+	n        int // number of items
+	capacity int // 0 for unbounded
+}
+
+// NewDeque returns an empty deque. If capacity is given and positive, the deque holds at most
+// that many items and the push methods report false when it is full.
+//
+// Examples:
+//
+//	d := NewDeque[string]()
+//	d.PushBack("b")
+//	d.PushFront("a")
+//	d.PopBack() == ("b", true)
+func NewDeque[T any](capacity ...int) *Deque[T] {
+	d := &Deque[T]{}
+	if len(capacity) > 0 && capacity[0] > 0 {
+		d.capacity = capacity[0]
+	}
+	return d
+}
+
+// PushBack adds item at the back of d, and reports false, without adding it, if d is full.
+func (d *Deque[T]) PushBack(item T) bool {
+	if !d.reserve() {
+		return false
+	}
+	d.buf[(d.head+d.n)%len(d.buf)] = item
+	d.n++
+	return true
+}
+
+// PushFront adds item at the front of d, and reports false, without adding it, if d is full.
+func (d *Deque[T]) PushFront(item T) bool {
+	if !d.reserve() {
+		return false
+	}
+	d.head = (d.head - 1 + len(d.buf)) % len(d.buf)
+	d.buf[d.head] = item
+	d.n++
+	return true
+}
+
+// PopFront removes and returns the item at the front of d, or returns false if d is empty.
+func (d *Deque[T]) PopFront() (T, bool) {
+	var zero T
+	if d.n == 0 {
+		return zero, false
+	}
+	item := d.buf[d.head]
+	d.buf[d.head] = zero
+	d.head = (d.head + 1) % len(d.buf)
+	d.n--
+	return item, true
+}
+
+// PopBack removes and returns the item at the back of d, or returns false if d is empty.
+func (d *Deque[T]) PopBack() (T, bool) {
+	var zero T
+	if d.n == 0 {
+		return zero, false
+	}
+	i := (d.head + d.n - 1) % len(d.buf)
+	item := d.buf[i]
+	d.buf[i] = zero
+	d.n--
+	return item, true
+}
+
+// PeekFront returns the item at the front of d without removing it, or returns false if d is empty.
+func (d *Deque[T]) PeekFront() (T, bool) {
+	if d.n == 0 {
+		var zero T
+		return zero, false
+	}
+	return d.buf[d.head], true
+}
+
+// PeekBack returns the item at the back of d without removing it, or returns false if d is empty.
+func (d *Deque[T]) PeekBack() (T, bool) {
+	if d.n == 0 {
+		var zero T
+		return zero, false
+	}
+	return d.buf[(d.head+d.n-1)%len(d.buf)], true
+}
+
+// Len returns the number of items in d.
+func (d *Deque[T]) Len() int {
+	return d.n
+}
+
+// reserve makes room for one more item, growing the buffer if needed, and reports false if d
+// is full.
+func (d *Deque[T]) reserve() bool {
+	if d.capacity > 0 && d.n >= d.capacity {
+		return false
+	}
+	if d.n < len(d.buf) {
+		return true
+	}
+	size := max(2*len(d.buf), 8)
+	if d.capacity > 0 {
+		size = min(size, d.capacity)
+	}
+	buf := make([]T, size)
+	// Copy the items in order, unwrapping the ring.
+	n := copy(buf, d.buf[d.head:])
+	copy(buf[n:], d.buf[:d.head])
+	d.buf, d.head = buf, 0
+	return true
+}
+
+// Queue is a first-in, first-out collection, optionally bounded. The zero value is an empty,
+// unbounded queue ready to use. It is not safe for concurrent use.
+type Queue[T any] struct {
+	d Deque[T]
+}
+
+// NewQueue returns an empty queue. If capacity is given and positive, the queue holds at most
+// that many items and Push reports false when it is full.
+//
+// Examples:
+//
+//	q := NewQueue[int]()
+//	q.Push(1)
+//	q.Push(2)
+//	q.Pop() == (1, true)
+func NewQueue[T any](capacity ...int) *Queue[T] {
+	return &Queue[T]{d: *NewDeque[T](capacity...)}
+}
+
+// Push adds item at the back of q, and reports false, without adding it, if q is full.
+func (q *Queue[T]) Push(item T) bool {
+	return q.d.PushBack(item)
+}
+
+// Pop removes and returns the item at the front of q, or returns false if q is empty.
+func (q *Queue[T]) Pop() (T, bool) {
+	return q.d.PopFront()
+}
+
+// Peek returns the item at the front of q without removing it, or returns false if q is empty.
+func (q *Queue[T]) Peek() (T, bool) {
+	return q.d.PeekFront()
+}
+
+// Len returns the number of items in q.
+func (q *Queue[T]) Len() int {
+	return q.d.Len()
+}
@@ -0,0 +1,318 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// convertValue dereferences pointers in v and returns the underlying value,
+// or an error if v is nil or a nil pointer.
+func convertValue(v any) (reflect.Value, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return reflect.Value{}, fmt.Errorf("cannot convert nil %T", v)
+		}
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() {
+		return reflect.Value{}, errors.New("cannot convert nil")
+	}
+	return rv, nil
+}
+
+// ToInt converts v to an int. It accepts any integer type, floating-point values
+// without a fractional part, booleans (true is 1), and strings or []byte holding a
+// base-10 integer or an integral decimal number such as "42", " -7 " or "1e3".
+// Named types such as json.Number and time.Duration are converted by their underlying
+// kind, and pointers are dereferenced.
+// It returns ErrOverflow if the value does not fit in an int, or an error if v cannot be converted.
+//
+// Examples:
+//
+//	ToInt("42") == (42, nil)
+//	ToInt(json.Number("17")) == (17, nil)
+//	ToInt(float64(3)) == (3, nil)
+//	ToInt(3.5) returns an error
+//	ToInt("abc") returns an error
+//	ToInt(nil) returns an error
+func ToInt(v any) (int, error) {
+	rv, err := convertValue(v)
+	if err != nil {
+		return 0, err
+	}
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return SafeConvert[int](rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return SafeConvert[int](rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		return floatToInt(rv.Float())
+	case reflect.Bool:
+		if rv.Bool() {
+			return 1, nil
+		}
+		return 0, nil
+	case reflect.String:
+		return parseIntString(rv.String())
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return parseIntString(string(rv.Bytes()))
+		}
+	}
+	return 0, fmt.Errorf("cannot convert %T to int", v)
+}
+
+// ToIntOr converts v to an int as ToInt does, returning def if the conversion fails.
+//
+// Examples:
+//
+//	ToIntOr("8080", 80) == 8080
+//	ToIntOr("", 80) == 80
+//	ToIntOr(nil, 80) == 80
+func ToIntOr(v any, def int) int {
+	n, err := ToInt(v)
+	if err != nil {
+		return def
+	}
+	return n
+}
+
+// floatToInt converts f to an int if it is integral and in range.
+func floatToInt(f float64) (int, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) || f != math.Trunc(f) {
+		return 0, fmt.Errorf("cannot convert %v to int without losing precision", f)
+	}
+	// float64(math.MaxInt) rounds up to 2^63, so the upper bound must be exclusive.
+	if f < math.MinInt || f >= math.MaxInt {
+		return 0, ErrOverflow
+	}
+	return int(f), nil
+}
+
+// parseIntString parses s as a base-10 integer, falling back to an integral decimal number.
+func parseIntString(s string) (int, error) {
+	s = strings.TrimSpace(s)
+	n, err := strconv.ParseInt(s, 10, 0)
+	if err == nil {
+		return int(n), nil
+	}
+	if errors.Is(err, strconv.ErrRange) {
+		return 0, ErrOverflow
+	}
+	f, ferr := strconv.ParseFloat(s, 64)
+	if ferr != nil {
+		return 0, fmt.Errorf("cannot convert %q to int", s)
+	}
+	return floatToInt(f)
+}
+
+// ToFloat converts v to a float64. It accepts any integer or floating-point type,
+// booleans (true is 1), and strings or []byte holding a number as accepted by
+// strconv.ParseFloat, such as "3.14", " 1e-3 " or "-0". Named types such as json.Number
+// are converted by their underlying kind, and pointers are dereferenced.
+// It returns an error if v cannot be converted.
+//
+// Examples:
+//
+//	ToFloat("3.14") == (3.14, nil)
+//	ToFloat(json.Number("2.5")) == (2.5, nil)
+//	ToFloat(7) == (7, nil)
+//	ToFloat("seven") returns an error
+func ToFloat(v any) (float64, error) {
+	rv, err := convertValue(v)
+	if err != nil {
+		return 0, err
+	}
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(rv.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), nil
+	case reflect.Bool:
+		if rv.Bool() {
+			return 1, nil
+		}
+		return 0, nil
+	case reflect.String:
+		return parseFloatString(rv.String())
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return parseFloatString(string(rv.Bytes()))
+		}
+	}
+	return 0, fmt.Errorf("cannot convert %T to float64", v)
+}
+
+// ToFloatOr converts v to a float64 as ToFloat does, returning def if the conversion fails.
+//
+// Examples:
+//
+//	ToFloatOr("0.75", 0.5) == 0.75
+//	ToFloatOr([]int{1}, 0.5) == 0.5
+func ToFloatOr(v any, def float64) float64 {
+	f, err := ToFloat(v)
+	if err != nil {
+		return def
+	}
+	return f
+}
+
+// parseFloatString parses s as a float64 after trimming whitespace.
+func parseFloatString(s string) (float64, error) {
+	f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return 0, fmt.Errorf("cannot convert %q to float64", s)
+	}
+	return f, nil
+}
+
+// ToBool converts v to a bool. It accepts booleans; numbers, which are true unless zero;
+// and strings or []byte holding, in any case, one of "1", "t", "true", "y", "yes" or "on"
+// for true and "0", "f", "false", "n", "no" or "off" for false, as found in env files
+// and query strings. Pointers are dereferenced.
+// It returns an error if v cannot be converted.
+//
+// Examples:
+//
+//	ToBool("yes") == (true, nil)
+//	ToBool(" OFF ") == (false, nil)
+//	ToBool(1) == (true, nil)
+//	ToBool(0.0) == (false, nil)
+//	ToBool("maybe") returns an error
+func ToBool(v any) (bool, error) {
+	rv, err := convertValue(v)
+	if err != nil {
+		return false, err
+	}
+	switch rv.Kind() {
+	case reflect.Bool:
+		return rv.Bool(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int() != 0, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return rv.Uint() != 0, nil
+	case reflect.Float32, reflect.Float64:
+		return rv.Float() != 0, nil
+	case reflect.String:
+		return parseBoolString(rv.String())
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return parseBoolString(string(rv.Bytes()))
+		}
+	}
+	return false, fmt.Errorf("cannot convert %T to bool", v)
+}
+
+// ToBoolOr converts v to a bool as ToBool does, returning def if the conversion fails.
+//
+// Examples:
+//
+//	ToBoolOr("on", false) == true
+//	ToBoolOr("", true) == true
+func ToBoolOr(v any, def bool) bool {
+	b, err := ToBool(v)
+	if err != nil {
+		return def
+	}
+	return b
+}
+
+// parseBoolString parses the boolean spellings accepted by ToBool.
+func parseBoolString(s string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "1", "t", "true", "y", "yes", "on":
+		return true, nil
+	case "0", "f", "false", "n", "no", "off":
+		return false, nil
+	}
+	return false, fmt.Errorf("cannot convert %q to bool", s)
+}
+
+// ToString converts v to a string. Values implementing fmt.Stringer or error use their
+// String or Error method, strings and []byte are returned as-is, integers and booleans are
+// formatted with strconv, and floats use the shortest representation that round-trips,
+// switching to an exponent outside [1e-6, 1e21) as encoding/json does ("0.1", "1000000", "1e+21").
+// Pointers are dereferenced.
+// It returns an error if v is nil or of another kind, such as a struct or map.
+//
+// Examples:
+//
+//	ToString(42) == ("42", nil)
+//	ToString(0.1) == ("0.1", nil)
+//	ToString(true) == ("true", nil)
+//	ToString(time.Second) == ("1s", nil)
+//	ToString(map[string]int{}) returns an error
+func ToString(v any) (string, error) {
+	rv, err := convertValue(v)
+	if err != nil {
+		return "", err
+	}
+	// Check for a String or Error method both on v itself (for pointer receivers)
+	// and on the dereferenced value.
+	if s, ok := stringMethod(v); ok {
+		return s, nil
+	}
+	if s, ok := stringMethod(rv.Interface()); ok {
+		return s, nil
+	}
+	switch rv.Kind() {
+	case reflect.String:
+		return rv.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	case reflect.Float32:
+		return formatFloatShortest(rv.Float(), 32), nil
+	case reflect.Float64:
+		return formatFloatShortest(rv.Float(), 64), nil
+	case reflect.Bool:
+		return strconv.FormatBool(rv.Bool()), nil
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return string(rv.Bytes()), nil
+		}
+	}
+	return "", fmt.Errorf("cannot convert %T to string", v)
+}
+
+// stringMethod returns the result of v's String or Error method, if it has one.
+func stringMethod(v any) (string, bool) {
+	switch x := v.(type) {
+	case fmt.Stringer:
+		return x.String(), true
+	case error:
+		return x.Error(), true
+	}
+	return "", false
+}
+
+// formatFloatShortest formats f with the fewest digits that round-trip at the given bit size,
+// using plain notation for magnitudes in [1e-6, 1e21) and an exponent otherwise.
+func formatFloatShortest(f float64, bitSize int) string {
+	if abs := math.Abs(f); abs != 0 && (abs < 1e-6 || abs >= 1e21) {
+		return strconv.FormatFloat(f, 'g', -1, bitSize)
+	}
+	return strconv.FormatFloat(f, 'f', -1, bitSize)
+}
+
+// ToStringOr converts v to a string as ToString does, returning def if the conversion fails.
+//
+// Examples:
+//
+//	ToStringOr(8080, "") == "8080"
+//	ToStringOr(nil, "n/a") == "n/a"
+func ToStringOr(v any, def string) string {
+	s, err := ToString(v)
+	if err != nil {
+		return def
+	}
+	return s
+}
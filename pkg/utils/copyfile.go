@@ -0,0 +1,356 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// OverwritePolicy decides what CopyFile and CopyDir do when a destination file exists.
+type OverwritePolicy int
+
+const (
+	// OverwriteNever fails with an error wrapping fs.ErrExist. It is the default.
+	OverwriteNever OverwritePolicy = iota
+	// OverwriteAlways replaces the existing file.
+	OverwriteAlways
+	// OverwriteIfNewer replaces the existing file only if the source was modified after it,
+	// and otherwise skips the copy. Use it with CopyPreserveTimes for incremental backups.
+	OverwriteIfNewer
+	// OverwriteSkip keeps the existing file and skips the copy.
+	OverwriteSkip
+)
+
+// SymlinkPolicy decides how CopyDir copies the symbolic links it finds.
+type SymlinkPolicy int
+
+const (
+	// SymlinkPreserve recreates each link, pointing at the same target. It is the default.
+	SymlinkPreserve SymlinkPolicy = iota
+	// SymlinkFollow copies what each link points at, as a file or directory of its own.
+	// A link to a directory already being copied is an error, to stop loops.
+	SymlinkFollow
+	// SymlinkSkip leaves the links out.
+	SymlinkSkip
+)
+
+// copyOptions holds the settings applied by CopyOption values.
+type copyOptions struct {
+	overwrite     OverwritePolicy
+	symlinks      SymlinkPolicy
+	mode          fs.FileMode
+	hasMode       bool
+	preserveTimes bool
+	onProgress    func(src, dst string, bytes int64)
+}
+
+// CopyOption configures CopyFile and CopyDir.
+type CopyOption func(*copyOptions)
+
+// CopyOverwrite sets what to do when a destination file exists; see OverwritePolicy.
+func CopyOverwrite(policy OverwritePolicy) CopyOption {
+	return func(o *copyOptions) {
+		o.overwrite = policy
+	}
+}
+
+// CopySymlinks sets how CopyDir copies symbolic links; see SymlinkPolicy. CopyFile always
+// copies what its source points at.
+func CopySymlinks(policy SymlinkPolicy) CopyOption {
+	return func(o *copyOptions) {
+		o.symlinks = policy
+	}
+}
+
+// CopyMode gives the copied files the permission bits mode, instead of those of the source.
+func CopyMode(mode fs.FileMode) CopyOption {
+	return func(o *copyOptions) {
+		o.mode, o.hasMode = mode.Perm(), true
+	}
+}
+
+// CopyPreserveTimes gives the copied files and directories the modification time of their
+// source, instead of the time of the copy.
+func CopyPreserveTimes() CopyOption {
+	return func(o *copyOptions) {
+		o.preserveTimes = true
+	}
+}
+
+// CopyOnProgress sets a function called after each file is copied, with its source and
+// destination paths and its size in bytes, for progress reporting. Skipped files are not
+// reported.
+func CopyOnProgress(fn func(src, dst string, bytes int64)) CopyOption {
+	return func(o *copyOptions) {
+		o.onProgress = fn
+	}
+}
+
+// CopyFile copies the contents of the file at src to dst, following src if it is a symbolic
+// link. By default dst gets src's permission bits, and an existing dst is an error; see
+// CopyMode and CopyOverwrite. The contents are written to a temporary file next to dst,
+// which is renamed to dst once complete, so a failed copy never leaves dst half-written.
+//
+// Examples:
+//
+//	err := CopyFile("config.yaml", "config.yaml.bak", CopyOverwrite(OverwriteAlways))
+func CopyFile(src, dst string, opts ...CopyOption) error {
+	o := applyCopyOptions(opts)
+	info, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+	if !info.Mode().IsRegular() {
+		return fmt.Errorf("copy %s: not a regular file", src)
+	}
+	return copyFile(src, dst, info, o)
+}
+
+// CopyDir copies the directory tree at src to dst, creating dst and the directories below it
+// as needed, with the permission bits of their source. Files are copied as by CopyFile and
+// symbolic links according to CopySymlinks. Files other than regular files, directories and
+// symbolic links, such as sockets, are an error.
+// It stops at the first error, leaving what was copied so far in place. It returns an error
+// if dst is inside src.
+//
+// Examples:
+//
+//	err := CopyDir("/srv/data", "/backup/data",
+//		CopyOverwrite(OverwriteIfNewer),
+//		CopyPreserveTimes(),
+//		CopyOnProgress(func(src, dst string, n int64) { log.Printf("%s (%s)", dst, FormatBytes(n)) }),
+//	)
+func CopyDir(src, dst string, opts ...CopyOption) error {
+	o := applyCopyOptions(opts)
+	info, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("copy %s: not a directory", src)
+	}
+	absSrc, err := filepath.EvalSymlinks(src)
+	if err != nil {
+		return err
+	}
+	absDst, err := filepath.Abs(dst)
+	if err != nil {
+		return err
+	}
+	if absSrc, err = filepath.Abs(absSrc); err != nil {
+		return err
+	}
+	rel, err := filepath.Rel(absSrc, absDst)
+	if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("copy %s: destination %s is inside the source directory", src, dst)
+	}
+
+	c := &dirCopier{opts: o, active: map[string]bool{}}
+	if err := c.copyDir(src, dst, absSrc); err != nil {
+		return err
+	}
+	return c.finishDirs()
+}
+
+// applyCopyOptions returns the settings configured by opts.
+func applyCopyOptions(opts []CopyOption) copyOptions {
+	var o copyOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// dirCopier holds the state of a CopyDir call.
+type dirCopier struct {
+	opts   copyOptions
+	active map[string]bool // resolved paths of the directories being copied, to detect loops
+	dirs   []copiedDir     // created directories, whose mode and time are set at the end
+}
+
+// copiedDir is a directory created by CopyDir, with the mode and time to give it once its
+// contents are copied.
+type copiedDir struct {
+	path string
+	info fs.FileInfo
+}
+
+// copyDir copies the directory src, whose resolved path is real, to dst.
+func (c *dirCopier) copyDir(src, dst, real string) error {
+	info, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+	// Make the directory writable, so that its contents can be copied even if the source is
+	// read-only, or dst was copied from it before; its own mode is applied by finishDirs.
+	if err := os.MkdirAll(dst, info.Mode().Perm()|0o700); err != nil {
+		return err
+	}
+	if err := os.Chmod(dst, info.Mode().Perm()|0o700); err != nil {
+		return err
+	}
+	c.dirs = append(c.dirs, copiedDir{path: dst, info: info})
+	c.active[real] = true
+	defer delete(c.active, real)
+
+	entries, err := os.ReadDir(src)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		from, to := filepath.Join(src, entry.Name()), filepath.Join(dst, entry.Name())
+		switch mode := entry.Type(); {
+		case mode.IsDir():
+			err = c.copyDir(from, to, filepath.Join(real, entry.Name()))
+		case mode&fs.ModeSymlink != 0:
+			err = c.copySymlink(from, to)
+		case mode.IsRegular():
+			var info fs.FileInfo
+			if info, err = entry.Info(); err == nil {
+				err = copyFile(from, to, info, c.opts)
+			}
+		default:
+			err = fmt.Errorf("copy %s: not a regular file, directory or symbolic link", from)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copySymlink copies the symbolic link src to dst according to the symlink policy.
+func (c *dirCopier) copySymlink(src, dst string) error {
+	switch c.opts.symlinks {
+	case SymlinkSkip:
+		return nil
+	case SymlinkFollow:
+		info, err := os.Stat(src)
+		if err != nil {
+			return err
+		}
+		if info.Mode().IsRegular() {
+			return copyFile(src, dst, info, c.opts)
+		}
+		if !info.IsDir() {
+			return fmt.Errorf("copy %s: not a regular file or directory", src)
+		}
+		real, err := filepath.EvalSymlinks(src)
+		if err != nil {
+			return err
+		}
+		if real, err = filepath.Abs(real); err != nil {
+			return err
+		}
+		if c.active[real] {
+			return fmt.Errorf("copy %s: symbolic link loops back to %s", src, real)
+		}
+		return c.copyDir(src, dst, real)
+	}
+
+	target, err := os.Readlink(src)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Lstat(dst); err == nil {
+		switch c.opts.overwrite {
+		case OverwriteSkip:
+			return nil
+		case OverwriteNever:
+			return fmt.Errorf("copy %s: %w", dst, fs.ErrExist)
+		}
+		// For OverwriteIfNewer, a link has no contents to compare, so it is replaced.
+		if err := os.Remove(dst); err != nil {
+			return err
+		}
+	}
+	return os.Symlink(target, dst)
+}
+
+// finishDirs gives the created directories the mode, and if asked the time, of their source.
+// The deepest directories are done first, since setting a time is undone by changes inside.
+func (c *dirCopier) finishDirs() error {
+	for i := len(c.dirs) - 1; i >= 0; i-- {
+		d := c.dirs[i]
+		if err := os.Chmod(d.path, d.info.Mode().Perm()); err != nil {
+			return err
+		}
+		if c.opts.preserveTimes {
+			if err := os.Chtimes(d.path, d.info.ModTime(), d.info.ModTime()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// copyFile copies the regular file src, described by info, to dst.
+func copyFile(src, dst string, info fs.FileInfo, o copyOptions) error {
+	if existing, err := os.Stat(dst); err == nil {
+		switch o.overwrite {
+		case OverwriteNever:
+			return fmt.Errorf("copy %s: %w", dst, fs.ErrExist)
+		case OverwriteSkip:
+			return nil
+		case OverwriteIfNewer:
+			if !info.ModTime().After(existing.ModTime()) {
+				return nil
+			}
+		}
+		if existing.IsDir() {
+			return fmt.Errorf("copy %s: destination is a directory", dst)
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	tmp, err := os.CreateTemp(filepath.Dir(dst), "."+filepath.Base(dst)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	committed := false
+	defer func() {
+		if !committed {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	n, err := io.Copy(tmp, in)
+	if err != nil {
+		return err
+	}
+	mode := info.Mode().Perm()
+	if o.hasMode {
+		mode = o.mode
+	}
+	if err := tmp.Chmod(mode); err != nil {
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if o.preserveTimes {
+		if err := os.Chtimes(tmp.Name(), info.ModTime(), info.ModTime()); err != nil {
+			return err
+		}
+	}
+	if err := os.Rename(tmp.Name(), dst); err != nil {
+		return err
+	}
+	committed = true
+	if o.onProgress != nil {
+		o.onProgress(src, dst, n)
+	}
+	return nil
+}
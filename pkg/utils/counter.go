@@ -0,0 +1,107 @@
+package utils
+
+import "sort"
+
+// Counter counts occurrences of values, like a multiset or Python's collections.Counter.
+// It is a map from each value to its count, which is always positive: values whose count
+// drops to zero or below are removed. The zero value (nil) can be read but not added to;
+// use NewCounter or make. Like maps, counters are not safe for concurrent writes.
+type Counter[T comparable] map[T]int
+
+// CounterEntry is a value and its count, as returned by Counter.MostCommon.
+type CounterEntry[T comparable] struct {
+	Item  T
+	Count int
+}
+
+// NewCounter returns a counter holding the occurrences of items.
+//
+// Examples:
+//
+//	c := NewCounter(strings.Fields("the cat and the hat")...)
+//	c.Count("the") == 2
+func NewCounter[T comparable](items ...T) Counter[T] {
+	c := make(Counter[T])
+	c.Add(items...)
+	return c
+}
+
+// Add counts one occurrence of each of items.
+func (c Counter[T]) Add(items ...T) {
+	for _, item := range items {
+		c[item]++
+	}
+}
+
+// AddN adds n to the count of item, removing item if its count drops to zero or below.
+func (c Counter[T]) AddN(item T, n int) {
+	if n := c[item] + n; n > 0 {
+		c[item] = n
+	} else {
+		delete(c, item)
+	}
+}
+
+// Count returns the count of item, 0 if c does not hold it.
+func (c Counter[T]) Count(item T) int {
+	return c[item]
+}
+
+// Total returns the sum of all counts.
+func (c Counter[T]) Total() int {
+	total := 0
+	for _, n := range c {
+		total += n
+	}
+	return total
+}
+
+// MostCommon returns the n values with the highest counts, from the highest down, or all of
+// them if n is zero or less. Values with equal counts come in no particular order.
+//
+// Examples:
+//
+//	NewCounter("a", "b", "a", "c", "a", "b").MostCommon(2) == []CounterEntry[string]{{"a", 3}, {"b", 2}}
+func (c Counter[T]) MostCommon(n int) []CounterEntry[T] {
+	entries := make([]CounterEntry[T], 0, len(c))
+	for item, count := range c {
+		entries = append(entries, CounterEntry[T]{Item: item, Count: count})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Count > entries[j].Count })
+	if n > 0 && n < len(entries) {
+		entries = entries[:n]
+	}
+	return entries
+}
+
+// Merge adds the counts of other to c.
+func (c Counter[T]) Merge(other Counter[T]) {
+	for item, n := range other {
+		c.AddN(item, n)
+	}
+}
+
+// Subtract takes the counts of other away from c, removing the values whose count drops to
+// zero or below.
+//
+// Examples:
+//
+//	stock := NewCounter("apple", "apple", "pear")
+//	stock.Subtract(NewCounter("apple", "pear"))
+//	stock.Count("apple") == 1 && !stock.Has("pear")
+func (c Counter[T]) Subtract(other Counter[T]) {
+	for item, n := range other {
+		c.AddN(item, -n)
+	}
+}
+
+// Has reports whether c counts item at least once.
+func (c Counter[T]) Has(item T) bool {
+	_, ok := c[item]
+	return ok
+}
+
+// Len returns the number of distinct values in c.
+func (c Counter[T]) Len() int {
+	return len(c)
+}
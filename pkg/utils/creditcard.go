@@ -0,0 +1,141 @@
+package utils
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Brand identifies the payment network that issued a card number.
+type Brand string
+
+// Card brands recognized by CardBrand.
+const (
+	BrandUnknown    Brand = ""
+	BrandVisa       Brand = "Visa"
+	BrandMastercard Brand = "Mastercard"
+	BrandAmex       Brand = "American Express"
+	BrandDiscover   Brand = "Discover"
+	BrandDinersClub Brand = "Diners Club"
+	BrandJCB        Brand = "JCB"
+	BrandUnionPay   Brand = "UnionPay"
+	BrandMaestro    Brand = "Maestro"
+)
+
+// cardRange is an inclusive range of issuer identification number prefixes, all of the same length.
+type cardRange struct {
+	low, high int
+}
+
+// cardBrandRule describes the prefixes and lengths a brand issues numbers with.
+type cardBrandRule struct {
+	brand   Brand
+	ranges  []cardRange
+	lengths []int
+}
+
+// cardBrandRules are checked in order, so more specific ranges must come before broader ones
+// (Discover's 622126-622925 before UnionPay's 62, and both before Maestro).
+var cardBrandRules = []cardBrandRule{
+	{BrandAmex, []cardRange{{34, 34}, {37, 37}}, []int{15}},
+	{BrandDinersClub, []cardRange{{300, 305}, {36, 36}, {38, 39}}, []int{14, 15, 16, 17, 18, 19}},
+	{BrandJCB, []cardRange{{3528, 3589}}, []int{16, 17, 18, 19}},
+	{BrandVisa, []cardRange{{4, 4}}, []int{13, 16, 19}},
+	{BrandMastercard, []cardRange{{51, 55}, {2221, 2720}}, []int{16}},
+	{BrandDiscover, []cardRange{{6011, 6011}, {644, 649}, {65, 65}, {622126, 622925}}, []int{16, 17, 18, 19}},
+	{BrandUnionPay, []cardRange{{62, 62}}, []int{16, 17, 18, 19}},
+	{BrandMaestro, []cardRange{{50, 50}, {56, 69}}, []int{12, 13, 14, 15, 16, 17, 18, 19}},
+}
+
+// IsValidCreditCard reports whether number is a plausible card number according to ValidateCreditCard:
+// 13 to 19 digits, optionally separated by spaces or hyphens, that pass the Luhn check.
+//
+// Examples:
+//
+//	IsValidCreditCard("4111 1111 1111 1111") == true
+//	IsValidCreditCard("4111-1111-1111-1112") == false
+//	IsValidCreditCard("not a card") == false
+func IsValidCreditCard(number string) bool {
+	return ValidateCreditCard(number) == nil
+}
+
+// CardBrand returns the brand of a card number, judged by its issuer prefix and length.
+// Spaces and hyphens are ignored. The Luhn checksum is not verified; use IsValidCreditCard for that.
+// It returns BrandUnknown if the number does not match any known brand.
+//
+// Examples:
+//
+//	CardBrand("4111 1111 1111 1111") == BrandVisa
+//	CardBrand("5500 0000 0000 0004") == BrandMastercard
+//	CardBrand("3400 0000 0000 009") == BrandAmex
+//	CardBrand("6011 0000 0000 0004") == BrandDiscover
+//	CardBrand("1234") == BrandUnknown
+func CardBrand(number string) Brand {
+	digits, ok := cardDigits(number)
+	if !ok {
+		return BrandUnknown
+	}
+	for _, rule := range cardBrandRules {
+		if !slices.Contains(rule.lengths, len(digits)) {
+			continue
+		}
+		for _, r := range rule.ranges {
+			width := len(strconv.Itoa(r.low))
+			prefix, _ := strconv.Atoi(digits[:width])
+			if prefix >= r.low && prefix <= r.high {
+				return rule.brand
+			}
+		}
+	}
+	return BrandUnknown
+}
+
+// MaskCreditCard masks all but the last four digits of a card number using Mask,
+// and groups the result the way the brand prints it: 4-6-5 for American Express
+// and blocks of four for everything else.
+// Spaces and hyphens in the input are ignored. If number contains any other
+// non-digit characters, it is masked as-is without grouping.
+//
+// Examples:
+//
+//	MaskCreditCard("4111-1111-1111-1111") == "**** **** **** 1111"
+//	MaskCreditCard("378282246310005") == "**** ****** *0005"
+//	MaskCreditCard("4111111111111111", '#') == "#### #### #### 1111"
+func MaskCreditCard(number string, maskChar ...rune) string {
+	digits, ok := cardDigits(number)
+	if !ok {
+		return Mask(number, 4, maskChar...)
+	}
+	masked := []rune(Mask(digits, 4, maskChar...))
+
+	groups := []int{4}
+	if CardBrand(digits) == BrandAmex {
+		groups = []int{4, 6, 5}
+	}
+	var builder strings.Builder
+	for i, g := 0, 0; i < len(masked); g++ {
+		size := groups[Min(g, len(groups)-1)]
+		end := Min(i+size, len(masked))
+		if i > 0 {
+			builder.WriteByte(' ')
+		}
+		builder.WriteString(string(masked[i:end]))
+		i = end
+	}
+	return builder.String()
+}
+
+// cardDigits strips spaces and hyphens from number and reports whether
+// what remains is a non-empty string of ASCII digits.
+func cardDigits(number string) (string, bool) {
+	digits := strings.NewReplacer(" ", "", "-", "").Replace(number)
+	if digits == "" {
+		return "", false
+	}
+	for i := 0; i < len(digits); i++ {
+		if digits[i] < '0' || digits[i] > '9' {
+			return "", false
+		}
+	}
+	return digits, true
+}
@@ -0,0 +1,210 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a parsed cron schedule. Use ParseCron to create one.
+type Schedule struct {
+	expr                          string
+	minute, hour, dom, month, dow uint64 // one bit per allowed value
+	domAny, dowAny                bool   // whether the field started with "*", for the day-matching rule
+}
+
+// cronField describes the allowed range and names of one cron field.
+type cronField struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	cronMinute = cronField{"minute", 0, 59, nil}
+	cronHour   = cronField{"hour", 0, 23, nil}
+	cronDom    = cronField{"day of month", 1, 31, nil}
+	cronMonth  = cronField{"month", 1, 12, map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// Day of week 7 is accepted as Sunday, as in most cron implementations.
+	cronDow = cronField{"day of week", 0, 7, map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// cronMacros maps the @-macros accepted by ParseCron to their five-field equivalent.
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// ParseCron parses a cron expression in the standard five-field syntax
+// "minute hour day-of-month month day-of-week", or one of the macros @yearly (or @annually),
+// @monthly, @weekly, @daily (or @midnight) and @hourly.
+// Each field accepts "*", single values, ranges ("1-5"), lists ("1,15"), and steps ("*/15",
+// "0-30/10", "5/20"). Months and days of the week may be given by their three-letter English
+// names, in any case, and Sunday may be written as 0 or 7. As in Vixie cron, when both the day of
+// month and the day of week are restricted, a day matching either one matches; a field
+// starting with "*", such as "*/2", is not restricted.
+// It returns an error if expr is malformed or a value is out of range.
+//
+// Examples:
+//
+//	ParseCron("*/15 * * * *")      // every 15 minutes
+//	ParseCron("0 9 * * MON-FRI")   // 09:00 on weekdays
+//	ParseCron("30 2 1,15 * *")     // 02:30 on the 1st and 15th
+//	ParseCron("@daily")            // midnight every day
+//	ParseCron("60 * * * *") returns an error
+//	ParseCron("* * *") returns an error
+func ParseCron(expr string) (Schedule, error) {
+	spec := strings.TrimSpace(expr)
+	if strings.HasPrefix(spec, "@") {
+		macro, ok := cronMacros[strings.ToLower(spec)]
+		if !ok {
+			return Schedule{}, fmt.Errorf("invalid cron expression %q: unknown macro", expr)
+		}
+		spec = macro
+	}
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return Schedule{}, fmt.Errorf("invalid cron expression %q: expected 5 fields, got %d", expr, len(fields))
+	}
+
+	s := Schedule{expr: expr}
+	var err error
+	for i, target := range []*uint64{&s.minute, &s.hour, &s.dom, &s.month, &s.dow} {
+		field := []cronField{cronMinute, cronHour, cronDom, cronMonth, cronDow}[i]
+		if *target, err = parseCronField(fields[i], field); err != nil {
+			return Schedule{}, fmt.Errorf("invalid cron expression %q: %w", expr, err)
+		}
+	}
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1 // 7 is Sunday.
+	}
+	s.domAny = strings.HasPrefix(fields[2], "*")
+	s.dowAny = strings.HasPrefix(fields[4], "*")
+	return s, nil
+}
+
+// parseCronField parses one comma-separated cron field into a bit set of allowed values.
+func parseCronField(s string, f cronField) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(s, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepPart)
+			if err != nil || n < 1 {
+				return 0, fmt.Errorf("invalid step %q in %s field", stepPart, f.name)
+			}
+			step = n
+		}
+
+		var lo, hi int
+		switch {
+		case rangePart == "*":
+			lo, hi = f.min, f.max
+		case strings.Contains(rangePart, "-"):
+			loPart, hiPart, _ := strings.Cut(rangePart, "-")
+			var err error
+			if lo, err = parseCronValue(loPart, f); err != nil {
+				return 0, err
+			}
+			if hi, err = parseCronValue(hiPart, f); err != nil {
+				return 0, err
+			}
+			if lo > hi {
+				return 0, fmt.Errorf("invalid range %q in %s field", rangePart, f.name)
+			}
+		default:
+			v, err := parseCronValue(rangePart, f)
+			if err != nil {
+				return 0, err
+			}
+			lo, hi = v, v
+			if hasStep {
+				hi = f.max // "5/20" means from 5 to the end in steps of 20.
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << v
+		}
+	}
+	return bits, nil
+}
+
+// parseCronValue parses a single number or name within the range of f.
+func parseCronValue(s string, f cronField) (int, error) {
+	if v, ok := f.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q in %s field", s, f.name)
+	}
+	if v < f.min || v > f.max {
+		return 0, fmt.Errorf("value %d out of range [%d, %d] in %s field", v, f.min, f.max, f.name)
+	}
+	return v, nil
+}
+
+// String returns the expression the schedule was parsed from.
+func (s Schedule) String() string {
+	return s.expr
+}
+
+// Next returns the first time after after, to the minute, that matches the schedule, in after's
+// location. Times skipped by a daylight saving change never match, and a time repeated by one
+// matches at both occurrences. It returns the zero time if nothing matches
+// within five years, as for "0 0 30 2 *" (February 30), or if s is the zero Schedule.
+//
+// Examples:
+//
+//	s, _ := ParseCron("0 9 * * MON-FRI")
+//	s.Next(time.Date(2024, 5, 3, 10, 0, 0, 0, time.UTC)) == time.Date(2024, 5, 6, 9, 0, 0, 0, time.UTC)
+//	d, _ := ParseCron("@daily")
+//	d.Next(time.Date(2024, 5, 3, 0, 0, 0, 0, time.UTC)) == time.Date(2024, 5, 4, 0, 0, 0, 0, time.UTC)
+func (s Schedule) Next(after time.Time) time.Time {
+	if s.minute == 0 {
+		return time.Time{}
+	}
+	loc := after.Location()
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := after.Year() + 5
+
+	for t.Year() <= limit {
+		y, m, d := t.Date()
+		switch {
+		case s.month&(1<<uint(m)) == 0:
+			t = midnight(y, m+1, 1, loc)
+		case !s.dayMatches(t):
+			t = midnight(y, m, d+1, loc)
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			// Step by absolute time, so an hour repeated by daylight saving is not revisited forever.
+			t = t.Add(time.Hour - time.Duration(t.Minute())*time.Minute)
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// dayMatches reports whether t's day satisfies the day-of-month and day-of-week fields.
+func (s Schedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domAny || s.dowAny {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
@@ -0,0 +1,81 @@
+package utils
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// ParseCSVLine splits a single CSV record into its fields using sep as the separator, following
+// RFC 4180 as encoding/csv does: fields may be quoted with '"', quoted fields may contain the
+// separator, newlines and doubled quotes (""), and a trailing "\n" or "\r\n" is ignored.
+// An empty line yields a single empty field.
+// It returns an error if sep is not a valid separator (such as '"' or '\n'), if a quote is
+// misplaced or unterminated, or if s holds more than one record.
+//
+// Examples:
+//
+//	ParseCSVLine(`a,"b,c",d`, ',') == ([]string{"a", "b,c", "d"}, nil)
+//	ParseCSVLine(`"say ""hi""";2`, ';') == ([]string{`say "hi"`, "2"}, nil)
+//	ParseCSVLine("a,,", ',') == ([]string{"a", "", ""}, nil)
+//	ParseCSVLine(`a,"b`, ',') returns an error
+//	ParseCSVLine("a\nb", ',') returns an error
+func ParseCSVLine(s string, sep rune) ([]string, error) {
+	if !validCSVSeparator(sep) {
+		return nil, fmt.Errorf("invalid CSV separator %q", sep)
+	}
+	if strings.TrimRight(s, "\r\n") == "" {
+		return []string{""}, nil
+	}
+	r := csv.NewReader(strings.NewReader(s))
+	r.Comma = sep
+	r.FieldsPerRecord = -1
+	fields, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV line: %w", err)
+	}
+	if _, err := r.Read(); !errors.Is(err, io.EOF) {
+		return nil, errors.New("invalid CSV line: contains more than one record")
+	}
+	return fields, nil
+}
+
+// FormatCSVLine joins fields into a single CSV record using sep as the separator, quoting a field
+// only when needed: when it contains the separator, a quote, a newline, or leading whitespace,
+// or when it is the lone empty field (which would otherwise read back as an empty line).
+// Quotes inside fields are doubled. No line terminator is appended.
+// The output reads back unchanged with ParseCSVLine and encoding/csv.
+//
+// Examples:
+//
+//	FormatCSVLine([]string{"a", "b,c", "d"}, ',') == `a,"b,c",d`
+//	FormatCSVLine([]string{`say "hi"`, "2"}, ';') == `"say ""hi""";2`
+//	FormatCSVLine([]string{"x", ""}, '\t') == "x\t"
+func FormatCSVLine(fields []string, sep rune) string {
+	var b strings.Builder
+	for i, field := range fields {
+		if i > 0 {
+			b.WriteRune(sep)
+		}
+		needsQuotes := field == "" && len(fields) == 1 ||
+			strings.ContainsRune(field, sep) ||
+			strings.ContainsAny(field, "\"\r\n") ||
+			field != "" && (field[0] == ' ' || field[0] == '\t')
+		if !needsQuotes {
+			b.WriteString(field)
+			continue
+		}
+		b.WriteByte('"')
+		b.WriteString(strings.ReplaceAll(field, `"`, `""`))
+		b.WriteByte('"')
+	}
+	return b.String()
+}
+
+// validCSVSeparator reports whether sep can separate CSV fields, using the rules of encoding/csv.
+func validCSVSeparator(sep rune) bool {
+	return sep != 0 && sep != '"' && sep != '\r' && sep != '\n' && sep != utf8.RuneError && utf8.ValidRune(sep)
+}
@@ -0,0 +1,129 @@
+package utils
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// mergedContext is a context that is done as soon as either of two parent contexts is, and
+// looks up values in both.
+type mergedContext struct {
+	a, b context.Context
+	done chan struct{}
+	once sync.Once
+	mu   sync.Mutex
+	err  error
+	stop []func() bool // unregister the AfterFunc callbacks on a and b
+}
+
+// MergeContexts returns a context that is done as soon as a or b is done, with the error of
+// whichever finished first, and the earlier of their deadlines. Values are looked up in a,
+// then in b. This lets a request-scoped context also stop on a server-wide shutdown context,
+// for example.
+// Until a or b is done, both keep a reference to the merged context, so when merging with a
+// long-lived context such as a server's, the other one should be cancelled eventually.
+//
+// Examples:
+//
+//	ctx := MergeContexts(r.Context(), shutdownCtx)
+//	err := process(ctx, job) // stops if the client goes away or the server shuts down
+func MergeContexts(a, b context.Context) context.Context {
+	m := &mergedContext{a: a, b: b, done: make(chan struct{})}
+	m.mu.Lock()
+	m.stop = []func() bool{
+		context.AfterFunc(a, func() { m.finish(a) }),
+		context.AfterFunc(b, func() { m.finish(b) }),
+	}
+	m.mu.Unlock()
+	// AfterFunc runs its callback in a new goroutine, so a parent that is already done
+	// is handled here for the merged context to be done on return.
+	if a.Err() != nil {
+		m.finish(a)
+	} else if b.Err() != nil {
+		m.finish(b)
+	}
+	return m
+}
+
+// finish marks m as done with parent's error, the first time it is called.
+func (m *mergedContext) finish(parent context.Context) {
+	m.once.Do(func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		m.err = parent.Err()
+		close(m.done)
+		for _, stop := range m.stop {
+			stop()
+		}
+	})
+}
+
+func (m *mergedContext) Deadline() (time.Time, bool) {
+	da, okA := m.a.Deadline()
+	db, okB := m.b.Deadline()
+	switch {
+	case okA && okB:
+		if db.Before(da) {
+			return db, true
+		}
+		return da, true
+	case okB:
+		return db, true
+	}
+	return da, okA
+}
+
+func (m *mergedContext) Done() <-chan struct{} {
+	return m.done
+}
+
+func (m *mergedContext) Err() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.err
+}
+
+func (m *mergedContext) Value(key any) any {
+	if v := m.a.Value(key); v != nil {
+		return v
+	}
+	return m.b.Value(key)
+}
+
+// DetachContext returns a context that carries ctx's values but is never cancelled and has
+// no deadline, for work that must outlive the request that started it, such as writing an
+// audit log after the response is sent. It is context.WithoutCancel under a name that reads
+// better alongside MergeContexts.
+//
+// Examples:
+//
+//	go audit.Record(DetachContext(r.Context()), event) // keeps the trace ID, ignores the client hanging up
+func DetachContext(ctx context.Context) context.Context {
+	return context.WithoutCancel(ctx)
+}
+
+// CtxValue returns the value ctx holds for key as a T, and whether there is one of that type.
+//
+// Examples:
+//
+//	type traceIDKey struct{}
+//	ctx := context.WithValue(context.Background(), traceIDKey{}, "abc123")
+//	CtxValue[string](ctx, traceIDKey{}) == ("abc123", true)
+//	CtxValue[int](ctx, traceIDKey{}) == (0, false)
+func CtxValue[T any](ctx context.Context, key any) (T, bool) {
+	v, ok := ctx.Value(key).(T)
+	return v, ok
+}
+
+// CtxValueOr returns the value ctx holds for key as a T, or def if there is none of that type.
+//
+// Examples:
+//
+//	CtxValueOr(ctx, tenantKey{}, "default") == "default" // no tenant set
+func CtxValueOr[T any](ctx context.Context, key any, def T) T {
+	if v, ok := CtxValue[T](ctx, key); ok {
+		return v
+	}
+	return def
+}
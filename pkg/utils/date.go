@@ -0,0 +1,185 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultDateLayouts are the layouts tried by IsValidDate, ParseDate and ValidateDateRange
+// when the caller passes none: an ISO 8601 date, an RFC 3339 timestamp (with optional fractional seconds),
+// and date-time forms without a zone, separated by a space or a "T".
+var DefaultDateLayouts = []string{
+	time.DateOnly,
+	time.RFC3339,
+	time.DateTime,
+	"2006-01-02T15:04:05",
+}
+
+// ParseDate parses s using the first of layouts that matches it.
+// If no layouts are given, DefaultDateLayouts is used. Leading and trailing
+// whitespace is not trimmed. Values without a zone are interpreted as UTC, as time.Parse does.
+// It returns an error if s is empty or matches none of the layouts.
+//
+// Examples:
+//
+//	ParseDate("2023-10-27") returns 2023-10-27 00:00:00 UTC
+//	ParseDate("27/10/2023", "02/01/2006") returns 2023-10-27 00:00:00 UTC
+//	ParseDate("2023-02-30") returns an error (invalid day for month)
+func ParseDate(s string, layouts ...string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, errors.New("date string cannot be empty")
+	}
+	if len(layouts) == 0 {
+		layouts = DefaultDateLayouts
+	}
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	if len(layouts) == 1 {
+		return time.Time{}, fmt.Errorf("invalid date %q: expected layout %q", s, layouts[0])
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q: does not match any of %d layouts", s, len(layouts))
+}
+
+// IsValidDate reports whether s is a valid date in any of the given layouts,
+// or in DefaultDateLayouts if none are given. Impossible dates such as February 30 are rejected.
+//
+// Examples:
+//
+//	IsValidDate("2024-02-29") == true
+//	IsValidDate("2023-02-29") == false
+//	IsValidDate("10/27/2023", "01/02/2006", "2006-01-02") == true
+//	IsValidDate("yesterday") == false
+func IsValidDate(s string, layouts ...string) bool {
+	_, err := ParseDate(s, layouts...)
+	return err == nil
+}
+
+// ValidateDateRange checks if s is a valid date, parsed as ParseDate does, that falls
+// within [min, max], inclusive. A zero min or max leaves that side of the range open.
+// It returns an error if s cannot be parsed, if min is after max, or if the date is out of range.
+//
+// Examples:
+//
+//	ValidateDateRange("2023-10-27", start2023, end2023) == nil
+//	ValidateDateRange("2022-12-31", start2023, end2023) returns an error
+//	ValidateDateRange("1990-05-01", time.Time{}, eighteenYearsAgo) == nil
+//	ValidateDateRange("27.10.2023", start2023, end2023, "02.01.2006") == nil
+func ValidateDateRange(s string, min, max time.Time, layouts ...string) error {
+	if !min.IsZero() && !max.IsZero() && min.After(max) {
+		return errors.New("min cannot be after max")
+	}
+	t, err := ParseDate(s, layouts...)
+	if err != nil {
+		return err
+	}
+	if !min.IsZero() && t.Before(min) {
+		return fmt.Errorf("date must not be before %s", min.Format(time.RFC3339))
+	}
+	if !max.IsZero() && t.After(max) {
+		return fmt.Errorf("date must not be after %s", max.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// flexibleDateLayouts are the layouts ParseDateFlexible tries, in order. Layouts registered
+// with RegisterDateLayout are appended.
+var flexibleDateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	time.DateTime,
+	time.DateOnly,
+	"20060102",
+	"2006/01/02",
+	"02/01/2006 15:04:05",
+	"02/01/2006 15:04",
+	"02/01/2006",
+	"02-01-2006",
+	"02.01.2006",
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC850,
+	time.RFC822Z,
+	time.RFC822,
+	time.ANSIC,
+	time.UnixDate,
+	"Jan 2, 2006",
+	"January 2, 2006",
+	"2 Jan 2006",
+	"2 January 2006",
+}
+
+// minFlexibleUnixDigits is the fewest digits ParseDateFlexible reads as a Unix timestamp:
+// 9 digits is 1973 in seconds, and fewer would catch eight-digit dates that failed to parse.
+const minFlexibleUnixDigits = 9
+
+// flexibleDateMu guards flexibleDateLayouts against concurrent registration.
+var flexibleDateMu sync.RWMutex
+
+// RegisterDateLayout adds a layout, in the format of time.Parse, to those ParseDateFlexible tries.
+// Registered layouts are tried after the built-in ones, in the order they were registered.
+// It is safe to call concurrently with ParseDateFlexible.
+// It returns an error if layout is empty.
+//
+// Examples:
+//
+//	RegisterDateLayout("01/02/2006") == nil
+//	RegisterDateLayout("") returns an error
+func RegisterDateLayout(layout string) error {
+	if layout == "" {
+		return errors.New("date layout cannot be empty")
+	}
+
+	flexibleDateMu.Lock()
+	defer flexibleDateMu.Unlock()
+	flexibleDateLayouts = append(flexibleDateLayouts, layout)
+	return nil
+}
+
+// ParseDateFlexible parses dates in the many shapes found in spreadsheets and CSV exports.
+// After trimming whitespace, it tries RFC 3339 and ISO 8601 forms ("2006-01-02T15:04:05Z",
+// "2006-01-02 15:04:05", "2006-01-02", "20060102"), then day-first forms ("02/01/2006", "02-01-2006",
+// "02.01.2006", with optional time), then RFC 1123, RFC 850, RFC 822, ANSI C and Unix date forms,
+// then written-out forms such as "Jan 2, 2006" and "2 January 2006", and finally any layouts
+// added with RegisterDateLayout. Slashed dates are read day first, as in most of the world;
+// register "01/02/2006" or use ParseDate for US month-first input.
+// A string of at least 9 digits that matches no layout, such as "1714730400", is read as a Unix
+// timestamp by ParseUnixAny, which tells seconds, milliseconds, microseconds and nanoseconds
+// apart by magnitude; shorter ones are rejected, as they are more likely mistyped dates.
+// Values without a zone are interpreted as UTC.
+// It returns an error if s matches none of the layouts.
+//
+// Examples:
+//
+//	ParseDateFlexible("2024-05-03T10:00:00Z") returns 2024-05-03 10:00:00 UTC
+//	ParseDateFlexible("03/05/2024") returns 2024-05-03 00:00:00 UTC
+//	ParseDateFlexible("May 3, 2024") returns 2024-05-03 00:00:00 UTC
+//	ParseDateFlexible("1714730400") returns 2024-05-03 10:00:00 UTC
+//	ParseDateFlexible("1714730400000") returns 2024-05-03 10:00:00 UTC
+//	ParseDateFlexible("20240503") returns 2024-05-03 00:00:00 UTC
+//	ParseDateFlexible("20241399") returns an error
+//	ParseDateFlexible("next tuesday") returns an error
+func ParseDateFlexible(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, errors.New("date string cannot be empty")
+	}
+
+	flexibleDateMu.RLock()
+	layouts := flexibleDateLayouts
+	flexibleDateMu.RUnlock()
+
+	t, err := ParseDate(s, layouts...)
+	if err == nil {
+		return t, nil
+	}
+	if len(s) >= minFlexibleUnixDigits && isASCIIDigits(s) {
+		return ParseUnixAny(s)
+	}
+	return time.Time{}, fmt.Errorf("unrecognized date format %q", s)
+}
@@ -0,0 +1,88 @@
+package utils
+
+import "time"
+
+// civilDays returns the number of days from the Unix epoch to t's calendar date,
+// ignoring its time of day and location offset.
+func civilDays(t time.Time) int {
+	y, m, d := t.Date()
+	return int(time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Unix() / 86400)
+}
+
+// DayRange returns an iterator over each calendar day from from's date through to's date,
+// inclusive, for use with range-over-func. Its type is that of iter.Seq[time.Time], spelled out
+// because the iter package needs a newer Go than this module targets. Each value keeps
+// from's time of day and location; days are stepped with AddDate, so daylight saving changes
+// do not skip or repeat a date. It yields nothing if to's date is before from's.
+//
+// Examples:
+//
+//	for day := range DayRange(start, end) {
+//		report.AddRow(day.Format(time.DateOnly))
+//	}
+func DayRange(from, to time.Time) func(yield func(time.Time) bool) {
+	return func(yield func(time.Time) bool) {
+		n := DaysBetween(from, to)
+		for i := 0; i <= n; i++ {
+			if !yield(from.AddDate(0, 0, i)) {
+				return
+			}
+		}
+	}
+}
+
+// EachDay calls fn for each calendar day from from's date through to's date, inclusive,
+// as DayRange yields them. It does nothing if to's date is before from's.
+//
+// Examples:
+//
+//	start := time.Date(2024, 2, 28, 0, 0, 0, 0, time.UTC)
+//	EachDay(start, start.AddDate(0, 0, 2), func(d time.Time) { fmt.Println(d.Format(time.DateOnly)) })
+//	// prints 2024-02-28, 2024-02-29 and 2024-03-01
+func EachDay(from, to time.Time, fn func(time.Time)) {
+	DayRange(from, to)(func(day time.Time) bool {
+		fn(day)
+		return true
+	})
+}
+
+// DaysBetween returns the number of calendar days from a's date to b's date, negative if b is
+// before a. Times of day are ignored, so 23:00 to 01:00 the next day is one day apart, and b is
+// read in a's location. Days lengthened or shortened by daylight saving still count as one.
+//
+// Examples:
+//
+//	DaysBetween(time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC), time.Date(2024, 1, 2, 1, 0, 0, 0, time.UTC)) == 1
+//	DaysBetween(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)) == -29
+func DaysBetween(a, b time.Time) int {
+	return civilDays(b.In(a.Location())) - civilDays(a)
+}
+
+// MonthsBetween returns the number of complete months from a to b, negative if b is before a.
+// A month is complete once b reaches the same day of the month and time of day as a, so
+// January 15 to March 14 is one month and to March 15 is two; b is read in a's location.
+// Months are not clamped at their end, so January 31 to February 29 is zero months.
+//
+// Examples:
+//
+//	MonthsBetween(time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)) == 2
+//	MonthsBetween(time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 14, 0, 0, 0, 0, time.UTC)) == 1
+//	MonthsBetween(time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)) == -2
+func MonthsBetween(a, b time.Time) int {
+	b = b.In(a.Location())
+	if b.Before(a) {
+		return -MonthsBetween(b, a)
+	}
+	months := (b.Year()-a.Year())*12 + int(b.Month()-a.Month())
+	if months > 0 && timeInMonth(b) < timeInMonth(a) {
+		months--
+	}
+	return months
+}
+
+// timeInMonth returns how far into its month t is, for comparing positions within months.
+func timeInMonth(t time.Time) time.Duration {
+	return time.Duration(t.Day())*24*time.Hour +
+		time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute +
+		time.Duration(t.Second())*time.Second + time.Duration(t.Nanosecond())
+}
@@ -0,0 +1,427 @@
+package utils
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Decimal is an exact, arbitrary-precision decimal number suitable for monetary amounts.
+// It stores an integer coefficient and a scale (the number of digits after the decimal point),
+// so values such as 19.99 are represented exactly instead of approximately as with float64.
+// Decimal values are immutable; every operation returns a new value.
+// The zero value is 0 and is ready to use.
+type Decimal struct {
+	coef  *big.Int
+	scale int
+}
+
+// maxDecimalExponent is the largest exponent magnitude accepted by ParseDecimal.
+const maxDecimalExponent = 100000
+
+// bigTen is the constant 10 shared by scaling operations. It must never be modified.
+var bigTen = big.NewInt(10)
+
+// pow10 returns 10^n as a new big.Int.
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(bigTen, big.NewInt(int64(n)), nil)
+}
+
+// coefficient returns the coefficient of d, treating the zero value as 0.
+func (d Decimal) coefficient() *big.Int {
+	if d.coef == nil {
+		return new(big.Int)
+	}
+	return d.coef
+}
+
+// rescale returns the coefficient of d expressed at a scale greater than or equal to d.scale.
+func (d Decimal) rescale(scale int) *big.Int {
+	c := new(big.Int).Set(d.coefficient())
+	if scale > d.scale {
+		c.Mul(c, pow10(scale-d.scale))
+	}
+	return c
+}
+
+// NewDecimal returns the decimal value unscaled × 10^-scale.
+// It returns an error if scale is negative.
+//
+// Examples:
+//
+//	NewDecimal(1999, 2) == 19.99
+//	NewDecimal(-5, 1) == -0.5
+//	NewDecimal(42, 0) == 42
+func NewDecimal(unscaled int64, scale int) (Decimal, error) {
+	if scale < 0 {
+		return Decimal{}, errors.New("scale cannot be negative")
+	}
+	return Decimal{coef: big.NewInt(unscaled), scale: scale}, nil
+}
+
+// DecimalFromInt returns the decimal value of an integer.
+//
+// Examples:
+//
+//	DecimalFromInt(42).String() == "42"
+func DecimalFromInt(n int64) Decimal {
+	return Decimal{coef: big.NewInt(n)}
+}
+
+// DecimalFromFloat returns the decimal value of the shortest representation of f,
+// so DecimalFromFloat(0.1) is exactly 0.1.
+// It returns an error if f is NaN or infinite.
+//
+// Examples:
+//
+//	DecimalFromFloat(0.1).String() == "0.1"
+//	DecimalFromFloat(-2.50).String() == "-2.5"
+//	DecimalFromFloat(math.NaN()) returns an error
+func DecimalFromFloat(f float64) (Decimal, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return Decimal{}, errors.New("cannot convert NaN or infinity to decimal")
+	}
+	return ParseDecimal(strconv.FormatFloat(f, 'f', -1, 64))
+}
+
+// ParseDecimal parses a decimal string such as "19.99", "-0.5", "+3", ".25" or "1.5e3".
+// The number of digits after the decimal point is preserved as the scale, so "1.50" keeps two places.
+// It returns an error if the string is not a valid decimal number.
+//
+// Examples:
+//
+//	ParseDecimal("19.99") == 19.99
+//	ParseDecimal("1.50").String() == "1.50"
+//	ParseDecimal("1.5e3").String() == "1500"
+//	ParseDecimal("12,5") returns an error
+func ParseDecimal(s string) (Decimal, error) {
+	original := s
+	exp := 0
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		e, err := strconv.Atoi(s[i+1:])
+		// The bound keeps a hostile exponent from forcing a huge allocation.
+		if err != nil || e < -maxDecimalExponent || e > maxDecimalExponent {
+			return Decimal{}, errors.New("invalid decimal exponent: " + original)
+		}
+		exp = e
+		s = s[:i]
+	}
+
+	sign := ""
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		sign = s[:1]
+		s = s[1:]
+	}
+	intPart, fracPart, _ := strings.Cut(s, ".")
+	digits := intPart + fracPart
+	if digits == "" || strings.TrimLeft(digits, "0123456789") != "" {
+		return Decimal{}, errors.New("invalid decimal: " + original)
+	}
+
+	coef, _ := new(big.Int).SetString(sign+digits, 10)
+	scale := len(fracPart) - exp
+	if scale < 0 {
+		coef.Mul(coef, pow10(-scale))
+		scale = 0
+	}
+	return Decimal{coef: coef, scale: scale}, nil
+}
+
+// MustParseDecimal is like ParseDecimal but panics if the string cannot be parsed.
+// It is intended for constants and tests.
+//
+// Examples:
+//
+//	MustParseDecimal("0.07") == 0.07
+func MustParseDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// Add returns d + o exactly.
+//
+// Examples:
+//
+//	MustParseDecimal("0.1").Add(MustParseDecimal("0.2")).String() == "0.3"
+func (d Decimal) Add(o Decimal) Decimal {
+	scale := Max(d.scale, o.scale)
+	return Decimal{coef: new(big.Int).Add(d.rescale(scale), o.rescale(scale)), scale: scale}
+}
+
+// Sub returns d - o exactly.
+//
+// Examples:
+//
+//	MustParseDecimal("10").Sub(MustParseDecimal("0.01")).String() == "9.99"
+func (d Decimal) Sub(o Decimal) Decimal {
+	scale := Max(d.scale, o.scale)
+	return Decimal{coef: new(big.Int).Sub(d.rescale(scale), o.rescale(scale)), scale: scale}
+}
+
+// Mul returns d × o exactly. The scale of the result is the sum of both scales.
+//
+// Examples:
+//
+//	MustParseDecimal("19.99").Mul(DecimalFromInt(3)).String() == "59.97"
+//	MustParseDecimal("1.5").Mul(MustParseDecimal("0.2")).String() == "0.30"
+func (d Decimal) Mul(o Decimal) Decimal {
+	return Decimal{coef: new(big.Int).Mul(d.coefficient(), o.coefficient()), scale: d.scale + o.scale}
+}
+
+// Div returns d ÷ o rounded to the given number of decimal places using banker's rounding
+// (ties to even), which avoids systematic bias when many results are summed.
+// It returns an error if o is zero or places is negative.
+//
+// Examples:
+//
+//	DecimalFromInt(10).Div(DecimalFromInt(3), 2) == (3.33, nil)
+//	MustParseDecimal("0.125").Div(DecimalFromInt(1), 2) == (0.12, nil)
+//	DecimalFromInt(1).Div(Decimal{}, 2) returns an error
+func (d Decimal) Div(o Decimal, places int) (Decimal, error) {
+	return d.DivMode(o, places, RoundHalfEven)
+}
+
+// DivMode returns d ÷ o rounded to the given number of decimal places using the given rounding mode.
+// It returns an error if o is zero or places is negative.
+//
+// Examples:
+//
+//	DecimalFromInt(2).DivMode(DecimalFromInt(3), 2, RoundFloor) == (0.66, nil)
+func (d Decimal) DivMode(o Decimal, places int, mode RoundingMode) (Decimal, error) {
+	if o.IsZero() {
+		return Decimal{}, errors.New("division by zero")
+	}
+	if places < 0 {
+		return Decimal{}, errors.New("places cannot be negative")
+	}
+	// d/o = (d.coef × 10^o.scale) / (o.coef × 10^d.scale), then shifted by 10^places.
+	num := new(big.Int).Mul(d.coefficient(), pow10(o.scale+places))
+	den := new(big.Int).Mul(o.coefficient(), pow10(d.scale))
+	q := roundRatToInt(new(big.Rat).SetFrac(num, den), mode)
+	return Decimal{coef: q, scale: places}, nil
+}
+
+// Round returns d rounded to the given number of decimal places using the given rounding mode.
+// If d already has no more than that many places, it is returned with its scale extended to places.
+// A negative number of places is treated as zero.
+//
+// Examples:
+//
+//	MustParseDecimal("2.345").Round(2, RoundHalfEven).String() == "2.34"
+//	MustParseDecimal("2.345").Round(2, RoundHalfAwayFromZero).String() == "2.35"
+//	MustParseDecimal("2.5").Round(2, RoundHalfEven).String() == "2.50"
+func (d Decimal) Round(places int, mode RoundingMode) Decimal {
+	places = Max(places, 0)
+	if places >= d.scale {
+		return Decimal{coef: d.rescale(places), scale: places}
+	}
+	r := new(big.Rat).SetFrac(d.coefficient(), pow10(d.scale-places))
+	return Decimal{coef: roundRatToInt(r, mode), scale: places}
+}
+
+// Neg returns -d.
+func (d Decimal) Neg() Decimal {
+	return Decimal{coef: new(big.Int).Neg(d.coefficient()), scale: d.scale}
+}
+
+// Abs returns the absolute value of d.
+func (d Decimal) Abs() Decimal {
+	return Decimal{coef: new(big.Int).Abs(d.coefficient()), scale: d.scale}
+}
+
+// Sign returns -1, 0, or +1 depending on whether d is negative, zero, or positive.
+func (d Decimal) Sign() int {
+	return d.coefficient().Sign()
+}
+
+// IsZero reports whether d is zero.
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Cmp compares d and o numerically, ignoring scale, and returns -1, 0, or +1.
+//
+// Examples:
+//
+//	MustParseDecimal("1.50").Cmp(MustParseDecimal("1.5")) == 0
+//	MustParseDecimal("0.99").Cmp(DecimalFromInt(1)) == -1
+func (d Decimal) Cmp(o Decimal) int {
+	scale := Max(d.scale, o.scale)
+	return d.rescale(scale).Cmp(o.rescale(scale))
+}
+
+// Equal reports whether d and o are numerically equal, ignoring scale.
+func (d Decimal) Equal(o Decimal) bool {
+	return d.Cmp(o) == 0
+}
+
+// Scale returns the number of digits after the decimal point.
+func (d Decimal) Scale() int {
+	return d.scale
+}
+
+// Float64 returns the nearest float64 value to d.
+func (d Decimal) Float64() float64 {
+	f, _ := new(big.Rat).SetFrac(d.coefficient(), pow10(d.scale)).Float64()
+	return f
+}
+
+// String returns the plain decimal representation of d, keeping its scale.
+//
+// Examples:
+//
+//	MustParseDecimal("19.990").String() == "19.990"
+//	MustParseDecimal("-0.5").String() == "-0.5"
+func (d Decimal) String() string {
+	digits := new(big.Int).Abs(d.coefficient()).String()
+	sign := ""
+	if d.Sign() < 0 {
+		sign = "-"
+	}
+	if d.scale == 0 {
+		return sign + digits
+	}
+	if len(digits) <= d.scale {
+		digits = strings.Repeat("0", d.scale-len(digits)+1) + digits
+	}
+	point := len(digits) - d.scale
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+// StringFixed returns d rounded half-even to the given number of places and formatted as a string.
+//
+// Examples:
+//
+//	MustParseDecimal("3.14159").StringFixed(2) == "3.14"
+//	DecimalFromInt(5).StringFixed(2) == "5.00"
+func (d Decimal) StringFixed(places int) string {
+	return d.Round(places, RoundHalfEven).String()
+}
+
+// MarshalJSON encodes d as a JSON string to avoid precision loss in JSON decoders.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(d.String())), nil
+}
+
+// UnmarshalJSON decodes d from a JSON string or number.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+	parsed, err := ParseDecimal(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (d *Decimal) UnmarshalText(text []byte) error {
+	parsed, err := ParseDecimal(string(text))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// Currency describes how amounts in a currency are rounded and displayed.
+type Currency struct {
+	Code         string // ISO 4217 code, e.g. "USD"
+	Symbol       string // Display symbol, e.g. "$"
+	Decimals     int    // Number of minor-unit digits, e.g. 2 for cents
+	DecimalSep   string // Separator between the integer and fractional parts
+	ThousandsSep string // Separator between groups of three integer digits
+	SymbolAfter  bool   // Whether the symbol follows the amount instead of preceding it
+	SymbolSpace  bool   // Whether a space separates the symbol from the amount
+}
+
+// Common currencies with their conventional formatting.
+var (
+	USD = Currency{Code: "USD", Symbol: "$", Decimals: 2, DecimalSep: ".", ThousandsSep: ","}
+	EUR = Currency{Code: "EUR", Symbol: "€", Decimals: 2, DecimalSep: ",", ThousandsSep: ".", SymbolAfter: true, SymbolSpace: true}
+	GBP = Currency{Code: "GBP", Symbol: "£", Decimals: 2, DecimalSep: ".", ThousandsSep: ","}
+	BRL = Currency{Code: "BRL", Symbol: "R$", Decimals: 2, DecimalSep: ",", ThousandsSep: ".", SymbolSpace: true}
+	JPY = Currency{Code: "JPY", Symbol: "¥", Decimals: 0, DecimalSep: ".", ThousandsSep: ","}
+)
+
+// currencies indexes the built-in currencies by ISO 4217 code.
+var currencies = map[string]Currency{
+	USD.Code: USD,
+	EUR.Code: EUR,
+	GBP.Code: GBP,
+	BRL.Code: BRL,
+	JPY.Code: JPY,
+}
+
+// CurrencyByCode returns the built-in Currency for an ISO 4217 code, ignoring case.
+// The ok result is false if the code is unknown.
+//
+// Examples:
+//
+//	CurrencyByCode("usd") == (USD, true)
+//	CurrencyByCode("XYZ") == (Currency{}, false)
+func CurrencyByCode(code string) (Currency, bool) {
+	c, ok := currencies[strings.ToUpper(code)]
+	return c, ok
+}
+
+// formatAmount returns the absolute value of d, rounded to the currency minor unit, with separators applied.
+func (c Currency) formatAmount(d Decimal) string {
+	plain := d.Abs().Round(c.Decimals, RoundHalfEven).String()
+	intPart, fracPart, _ := strings.Cut(plain, ".")
+
+	var b strings.Builder
+	for i, r := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteString(c.ThousandsSep)
+		}
+		b.WriteRune(r)
+	}
+	if fracPart != "" {
+		b.WriteString(c.DecimalSep)
+		b.WriteString(fracPart)
+	}
+	return b.String()
+}
+
+// FormatCurrency rounds d half-even to the currency's minor unit and formats it
+// with the currency's symbol and separators.
+//
+// Examples:
+//
+//	MustParseDecimal("1234.567").FormatCurrency(USD) == "$1,234.57"
+//	MustParseDecimal("-5").FormatCurrency(USD) == "-$5.00"
+//	MustParseDecimal("1234.5").FormatCurrency(EUR) == "1.234,50 €"
+//	MustParseDecimal("1234.5").FormatCurrency(BRL) == "R$ 1.234,50"
+//	MustParseDecimal("1234.5").FormatCurrency(JPY) == "¥1,234"
+func (d Decimal) FormatCurrency(c Currency) string {
+	amount := c.formatAmount(d)
+	space := ""
+	if c.SymbolSpace {
+		space = " "
+	}
+	sign := ""
+	if d.Round(c.Decimals, RoundHalfEven).Sign() < 0 {
+		sign = "-"
+	}
+	if c.SymbolAfter {
+		return sign + amount + space + c.Symbol
+	}
+	return sign + c.Symbol + space + amount
+}
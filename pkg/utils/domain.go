@@ -0,0 +1,363 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ValidateHostname checks if a string is a valid host name under RFC 1123, or an IP address:
+// at most 253 characters, made of dot-separated labels of 1 to 63 letters, digits
+// and hyphens, none starting or ending with a hyphen. Labels may start with a digit,
+// but the last one may not be all digits, so that malformed IPv4 addresses are not taken for names.
+// A single label such as "localhost" is allowed, as is one trailing dot for a fully qualified name.
+// Internationalized names may be given in Unicode, as in "bücher.example", or in their
+// ASCII ("xn--") form; Unicode labels are lower-cased and converted to Punycode, without the
+// rest of the IDNA mapping, and the limits apply to the converted name.
+// IP addresses are accepted because they can stand wherever a host name can, as in a URL;
+// use IsValidHostname to accept names only.
+// It returns an error if the string is neither.
+//
+// Examples:
+//
+//	ValidateHostname("localhost") == nil
+//	ValidateHostname("db-01.internal.example.com") == nil
+//	ValidateHostname("192.168.1.1") == nil
+//	ValidateHostname("bücher.example") == nil
+//	ValidateHostname("-bad.example.com") returns an error (label starts with hyphen)
+//	ValidateHostname("under_score.example.com") returns an error (invalid character)
+//	ValidateHostname("example..com") returns an error (empty label)
+func ValidateHostname(s string) error {
+	if net.ParseIP(s) != nil {
+		return nil
+	}
+	return validateHostname(s)
+}
+
+// IsValidHostname reports whether s is a valid host name according to ValidateHostname,
+// not counting IP addresses.
+//
+// Examples:
+//
+//	IsValidHostname("localhost") == true
+//	IsValidHostname("db-01.internal.example.com") == true
+//	IsValidHostname("3com.net.") == true
+//	IsValidHostname("-bad.example.com") == false
+//	IsValidHostname("under_score.example.com") == false
+//	IsValidHostname("192.168.1.1") == false
+func IsValidHostname(s string) bool {
+	return validateHostname(s) == nil
+}
+
+// validateHostname checks s against the host name rules of ValidateHostname.
+func validateHostname(s string) error {
+	labels, err := hostnameLabels(s, "hostname")
+	if err != nil {
+		return err
+	}
+	if strings.Trim(labels[len(labels)-1], "0123456789") == "" {
+		return errors.New("invalid hostname format: last label cannot be all digits")
+	}
+	return nil
+}
+
+// ValidateDomain checks if a string is a valid registrable-style domain name: a host name
+// (see ValidateHostname) with at least two labels and a top-level label of two or more letters.
+// Internationalized names are accepted in Unicode or in their ASCII form, as for
+// ValidateHostname; each "xn--" label (including the top-level one, as in "xn--p1ai") must be
+// valid Punycode that decodes to a non-ASCII name of letters, digits and combining marks. Other labels with hyphens in both the third and fourth positions
+// are reserved and rejected.
+// It returns an error if the string is not a valid domain name.
+//
+// Examples:
+//
+//	ValidateDomain("example.com") == nil
+//	ValidateDomain("shop.example.co.uk") == nil
+//	ValidateDomain("xn--mnchen-3ya.de") == nil // münchen.de
+//	ValidateDomain("münchen.de") == nil
+//	ValidateDomain("пример.рф") == nil
+//	ValidateDomain("example") returns an error (missing TLD)
+//	ValidateDomain("example.c") returns an error (TLD too short)
+//	ValidateDomain("example.123") returns an error (numeric TLD)
+//	ValidateDomain("xn--invalid-.com") returns an error (label ends with hyphen)
+//	ValidateDomain("ab--cd.com") returns an error (reserved label)
+func ValidateDomain(s string) error {
+	labels, err := hostnameLabels(s, "domain")
+	if err != nil {
+		return err
+	}
+	if len(labels) < 2 {
+		return errors.New("invalid domain format: must have at least two labels (e.g., example.com)")
+	}
+	for _, label := range labels {
+		if len(label) >= 4 && label[2:4] == "--" {
+			if !strings.EqualFold(label[:2], "xn") {
+				return fmt.Errorf("invalid domain format: label %q is reserved", label)
+			}
+			if !isValidPunycodeLabel(label[4:]) {
+				return fmt.Errorf("invalid domain format: label %q is not valid Punycode", label)
+			}
+		}
+	}
+	tld := labels[len(labels)-1]
+	if len(tld) >= 4 && strings.EqualFold(tld[:4], "xn--") {
+		return nil
+	}
+	if len(tld) < 2 {
+		return errors.New("invalid domain format: top-level domain (TLD) must be at least two characters long")
+	}
+	for i := 0; i < len(tld); i++ {
+		if !(tld[i] >= 'a' && tld[i] <= 'z' || tld[i] >= 'A' && tld[i] <= 'Z') {
+			return errors.New("invalid domain format: top-level domain (TLD) must contain only letters")
+		}
+	}
+	return nil
+}
+
+// IsValidDomain reports whether s is a valid domain name according to ValidateDomain.
+//
+// Examples:
+//
+//	IsValidDomain("example.com") == true
+//	IsValidDomain("xn--e1afmkfd.xn--p1ai") == true // пример.рф
+//	IsValidDomain("localhost") == false
+//	IsValidDomain("example.c") == false
+func IsValidDomain(s string) bool {
+	return ValidateDomain(s) == nil
+}
+
+// hostnameLabels splits s into its labels, converting Unicode labels to their "xn--" form,
+// and checks the RFC 1123 length and character rules. One trailing dot is allowed. what
+// names the kind of name in errors.
+func hostnameLabels(s, what string) ([]string, error) {
+	s = strings.TrimSuffix(s, ".")
+	if s == "" {
+		return nil, fmt.Errorf("%s cannot be empty", what)
+	}
+	labels := strings.Split(s, ".")
+	length := len(labels) - 1
+	for i, label := range labels {
+		if !isASCII(label) {
+			encoded, ok := encodePunycodeLabel(label)
+			if !ok {
+				return nil, fmt.Errorf("invalid %s format: label %q cannot be converted to Punycode", what, label)
+			}
+			labels[i], label = encoded, encoded
+		}
+		length += len(label)
+		switch {
+		case label == "":
+			return nil, fmt.Errorf("invalid %s format: labels cannot be empty", what)
+		case len(label) > 63:
+			return nil, fmt.Errorf("invalid %s format: label length exceeds 63 characters", what)
+		case label[0] == '-' || label[len(label)-1] == '-':
+			return nil, fmt.Errorf("invalid %s format: labels cannot start or end with a hyphen", what)
+		}
+		for i := 0; i < len(label); i++ {
+			c := label[i]
+			if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-') {
+				return nil, fmt.Errorf("invalid %s format: labels can only contain letters, digits and hyphens", what)
+			}
+		}
+	}
+	if length > 253 {
+		return nil, fmt.Errorf("invalid %s format: longer than 253 characters", what)
+	}
+	return labels, nil
+}
+
+// isASCII reports whether s contains only ASCII characters.
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// Punycode parameters from RFC 3492, section 5.
+const (
+	punycodeBase        = 36
+	punycodeTMin        = 1
+	punycodeTMax        = 26
+	punycodeSkew        = 38
+	punycodeDamp        = 700
+	punycodeInitialBias = 72
+	punycodeInitialN    = 128
+)
+
+// isValidPunycodeLabel reports whether encoded (a label without its "xn--" prefix)
+// decodes as RFC 3492 Punycode to a string containing at least one non-ASCII letter or digit.
+func isValidPunycodeLabel(encoded string) bool {
+	decoded, ok := decodePunycode(strings.ToLower(encoded))
+	if !ok {
+		return false
+	}
+	hasNonASCII := false
+	for _, r := range decoded {
+		if r >= utf8.RuneSelf {
+			hasNonASCII = true
+			if !unicode.IsLetter(r) && !unicode.IsNumber(r) && !unicode.Is(unicode.Mn, r) && !unicode.Is(unicode.Mc, r) {
+				return false
+			}
+		}
+	}
+	return hasNonASCII
+}
+
+// decodePunycode decodes a Punycode string as described in RFC 3492, section 6.2.
+func decodePunycode(encoded string) (string, bool) {
+	var output []rune
+	rest := encoded
+	if i := strings.LastIndexByte(encoded, '-'); i >= 0 {
+		for _, r := range encoded[:i] {
+			if r >= utf8.RuneSelf {
+				return "", false
+			}
+			output = append(output, r)
+		}
+		rest = encoded[i+1:]
+	}
+	if rest == "" {
+		return "", false
+	}
+
+	n, bias, i := punycodeInitialN, punycodeInitialBias, 0
+	for pos := 0; pos < len(rest); {
+		oldI, w := i, 1
+		for k := punycodeBase; ; k += punycodeBase {
+			if pos == len(rest) {
+				return "", false
+			}
+			digit, ok := punycodeDigit(rest[pos])
+			pos++
+			if !ok || digit > (1<<31-1-i)/w {
+				return "", false
+			}
+			i += digit * w
+			t := k - bias
+			if t < punycodeTMin {
+				t = punycodeTMin
+			} else if t > punycodeTMax {
+				t = punycodeTMax
+			}
+			if digit < t {
+				break
+			}
+			if w > (1<<31-1)/(punycodeBase-t) {
+				return "", false
+			}
+			w *= punycodeBase - t
+		}
+		length := len(output) + 1
+		bias = punycodeAdapt(i-oldI, length, oldI == 0)
+		if i/length > utf8.MaxRune-n {
+			return "", false
+		}
+		n += i / length
+		i %= length
+		if n < punycodeInitialN || n > utf8.MaxRune || (n >= 0xD800 && n <= 0xDFFF) {
+			return "", false
+		}
+		output = append(output, 0)
+		copy(output[i+1:], output[i:])
+		output[i] = rune(n)
+		i++
+	}
+	return string(output), true
+}
+
+// encodePunycodeLabel returns the "xn--" form of label, lower-cased and encoded as
+// described in RFC 3492, section 6.3. It reports false if label is not valid UTF-8 or too
+// long to fit in a 63-character label, which also keeps the arithmetic from overflowing.
+func encodePunycodeLabel(label string) (string, bool) {
+	if !utf8.ValidString(label) || len(label) > 4*63 {
+		return "", false
+	}
+	input := []rune(strings.ToLower(label))
+	var output []byte
+	for _, r := range input {
+		if r < utf8.RuneSelf {
+			output = append(output, byte(r))
+		}
+	}
+	basic := len(output)
+	if basic > 0 {
+		output = append(output, '-')
+	}
+
+	n, delta, bias := punycodeInitialN, 0, punycodeInitialBias
+	for handled := basic; handled < len(input); {
+		next := int(utf8.MaxRune) + 1
+		for _, r := range input {
+			if int(r) >= n && int(r) < next {
+				next = int(r)
+			}
+		}
+		delta += (next - n) * (handled + 1)
+		n = next
+		for _, r := range input {
+			if int(r) < n {
+				delta++
+			}
+			if int(r) != n {
+				continue
+			}
+			q := delta
+			for k := punycodeBase; ; k += punycodeBase {
+				t := min(max(k-bias, punycodeTMin), punycodeTMax)
+				if q < t {
+					break
+				}
+				output = append(output, punycodeEncodeDigit(t+(q-t)%(punycodeBase-t)))
+				q = (q - t) / (punycodeBase - t)
+			}
+			output = append(output, punycodeEncodeDigit(q))
+			bias = punycodeAdapt(delta, handled+1, handled == basic)
+			delta = 0
+			handled++
+		}
+		delta++
+		n++
+	}
+	return "xn--" + string(output), true
+}
+
+// punycodeEncodeDigit returns the Punycode basic code point for the digit d, below 36.
+func punycodeEncodeDigit(d int) byte {
+	if d < 26 {
+		return byte('a' + d)
+	}
+	return byte('0' + d - 26)
+}
+
+// punycodeDigit returns the value of a Punycode basic code point.
+func punycodeDigit(c byte) (int, bool) {
+	switch {
+	case c >= 'a' && c <= 'z':
+		return int(c - 'a'), true
+	case c >= '0' && c <= '9':
+		return int(c-'0') + 26, true
+	}
+	return 0, false
+}
+
+// punycodeAdapt is the bias adaptation function from RFC 3492, section 6.1.
+func punycodeAdapt(delta, numPoints int, firstTime bool) int {
+	if firstTime {
+		delta /= punycodeDamp
+	} else {
+		delta /= 2
+	}
+	delta += delta / numPoints
+	k := 0
+	for delta > ((punycodeBase-punycodeTMin)*punycodeTMax)/2 {
+		delta /= punycodeBase - punycodeTMin
+		k += punycodeBase
+	}
+	return k + (punycodeBase-punycodeTMin+1)*delta/(delta+punycodeSkew)
+}
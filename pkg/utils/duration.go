@@ -0,0 +1,196 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Calendar units accepted by ParseDurationExtended in addition to those of time.ParseDuration.
+// Months and years have no fixed length, so they are approximated with fixed lengths
+// that do not depend on the calendar.
+const (
+	Day   = 24 * time.Hour
+	Week  = 7 * Day
+	Month = 30 * Day
+	Year  = 365 * Day
+)
+
+// extendedDurationUnits maps each unit accepted by ParseDurationExtended to its length.
+var extendedDurationUnits = map[string]time.Duration{
+	"ns": time.Nanosecond,
+	"us": time.Microsecond,
+	"µs": time.Microsecond, // U+00B5 micro sign
+	"μs": time.Microsecond, // U+03BC Greek letter mu
+	"ms": time.Millisecond,
+	"s":  time.Second,
+	"m":  time.Minute,
+	"h":  time.Hour,
+	"d":  Day,
+	"w":  Week,
+	"mo": Month,
+	"y":  Year,
+}
+
+// ParseDurationExtended parses a duration like time.ParseDuration, such as "1h30m" or "-1.5h",
+// with the extra units "d" (day), "w" (week), "mo" (month) and "y" (year).
+// A day is always 24 hours and a week 7 days; a month is 30 days and a year 365 days, so
+// "1mo" and "1y" are approximations that ignore month lengths, leap years and daylight saving
+// changes. Use time.Time.AddDate when calendar-exact arithmetic is needed.
+// It returns an error if s is malformed, uses an unknown unit, or does not fit in a time.Duration.
+//
+// Examples:
+//
+//	ParseDurationExtended("2d12h") == (60*time.Hour, nil)
+//	ParseDurationExtended("1w") == (7*24*time.Hour, nil)
+//	ParseDurationExtended("1.5d") == (36*time.Hour, nil)
+//	ParseDurationExtended("1y2mo") == ((365+60)*24*time.Hour, nil)
+//	ParseDurationExtended("90m") == (90*time.Minute, nil)
+//	ParseDurationExtended("3 days") returns an error
+func ParseDurationExtended(s string) (time.Duration, error) {
+	orig := s
+	neg := false
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+	if s == "0" {
+		return 0, nil
+	}
+	if s == "" {
+		return 0, fmt.Errorf("invalid duration %q", orig)
+	}
+
+	var total uint64
+	for s != "" {
+		// The number: integer digits, then an optional fraction.
+		i := 0
+		for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+			i++
+		}
+		intDigits := s[:i]
+		s = s[i:]
+		fracDigits := ""
+		if s != "" && s[0] == '.' {
+			s = s[1:]
+			i = 0
+			for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+				i++
+			}
+			fracDigits = s[:i]
+			s = s[i:]
+		}
+		if intDigits == "" && fracDigits == "" {
+			return 0, fmt.Errorf("invalid duration %q", orig)
+		}
+
+		// The unit: everything up to the next digit or '.'.
+		i = 0
+		for i < len(s) && s[i] != '.' && (s[i] < '0' || s[i] > '9') {
+			i++
+		}
+		if i == 0 {
+			return 0, fmt.Errorf("missing unit in duration %q", orig)
+		}
+		unit, ok := extendedDurationUnits[s[:i]]
+		if !ok {
+			return 0, fmt.Errorf("unknown unit %q in duration %q", s[:i], orig)
+		}
+		s = s[i:]
+
+		v, err := durationComponent(intDigits, fracDigits, uint64(unit))
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q: %w", orig, err)
+		}
+		total += v
+		if total > 1<<63 {
+			return 0, fmt.Errorf("invalid duration %q: %w", orig, ErrOverflow)
+		}
+	}
+
+	if neg {
+		return -time.Duration(total), nil
+	}
+	if total > math.MaxInt64 {
+		return 0, fmt.Errorf("invalid duration %q: %w", orig, ErrOverflow)
+	}
+	return time.Duration(total), nil
+}
+
+// durationComponent returns intDigits.fracDigits multiplied by unit, in nanoseconds,
+// truncating any fraction of a nanosecond.
+func durationComponent(intDigits, fracDigits string, unit uint64) (uint64, error) {
+	var whole uint64
+	if intDigits != "" {
+		n, err := strconv.ParseUint(intDigits, 10, 64)
+		if err != nil || n > (1<<63)/unit {
+			return 0, ErrOverflow
+		}
+		whole = n * unit
+	}
+	if fracDigits == "" {
+		return whole, nil
+	}
+	// Compute fraction*unit/10^len(fracDigits) in 128 bits, ignoring digits beyond
+	// the 18th, which cannot affect the result for any unit up to a year.
+	if len(fracDigits) > 18 {
+		fracDigits = fracDigits[:18]
+	}
+	frac, _ := strconv.ParseUint(fracDigits, 10, 64)
+	scale := uint64(1)
+	for range fracDigits {
+		scale *= 10
+	}
+	hi, lo := bits.Mul64(frac, unit)
+	part, _ := bits.Div64(hi, lo, scale)
+	return whole + part, nil
+}
+
+// FormatDurationCompact formats d using days, hours, minutes and seconds, such as "2d12h" or
+// "1d30m15s", omitting zero units. It is the inverse of ParseDurationExtended for those units.
+// Weeks, months and years are never used, since they would read as calendar periods.
+// Fractions of a second are kept, as in "1m0.5s", and durations under one second
+// are formatted as time.Duration.String does, such as "250ms".
+//
+// Examples:
+//
+//	FormatDurationCompact(60*time.Hour) == "2d12h"
+//	FormatDurationCompact(90*time.Minute) == "1h30m"
+//	FormatDurationCompact(-36*time.Hour) == "-1d12h"
+//	FormatDurationCompact(1500*time.Millisecond) == "1.5s"
+//	FormatDurationCompact(250*time.Millisecond) == "250ms"
+//	FormatDurationCompact(0) == "0s"
+func FormatDurationCompact(d time.Duration) string {
+	if d > -time.Second && d < time.Second {
+		return d.String()
+	}
+	var b strings.Builder
+	// Work on the magnitude as a uint64 so that math.MinInt64 is handled.
+	u := uint64(d)
+	if d < 0 {
+		b.WriteByte('-')
+		u = -u
+	}
+	for _, unit := range []struct {
+		suffix string
+		length time.Duration
+	}{{"d", Day}, {"h", time.Hour}, {"m", time.Minute}} {
+		if n := u / uint64(unit.length); n > 0 {
+			b.WriteString(strconv.FormatUint(n, 10))
+			b.WriteString(unit.suffix)
+			u -= n * uint64(unit.length)
+		}
+	}
+	if u > 0 {
+		b.WriteString(strconv.FormatUint(u/uint64(time.Second), 10))
+		if ns := u % uint64(time.Second); ns > 0 {
+			b.WriteByte('.')
+			b.WriteString(strings.TrimRight(fmt.Sprintf("%09d", ns), "0"))
+		}
+		b.WriteByte('s')
+	}
+	return b.String()
+}
@@ -0,0 +1,188 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"time"
+)
+
+// emailLookupTimeout bounds the DNS queries made by WithMXLookup.
+const emailLookupTimeout = 5 * time.Second
+
+// emailOptions holds the settings applied by EmailOption values.
+type emailOptions struct {
+	lookupMX bool
+	resolver *net.Resolver
+}
+
+// EmailOption configures the checks performed by ValidateEmail.
+type EmailOption func(*emailOptions)
+
+// WithMXLookup makes ValidateEmail verify that the address's domain can receive mail,
+// by resolving its MX records (or, failing that, its A/AAAA records as RFC 5321 allows).
+// If resolver is nil, net.DefaultResolver is used. Lookups time out after five seconds.
+func WithMXLookup(resolver *net.Resolver) EmailOption {
+	return func(o *emailOptions) {
+		o.lookupMX = true
+		o.resolver = resolver
+	}
+}
+
+// ValidateEmail checks if a string is a valid email address.
+// It applies the practical subset of RFC 5322 used by mail providers:
+//   - the address is at most 254 characters, with a local part of at most 64;
+//   - the local part is a dot-atom (letters, digits and !#$%&'*+/=?^_`{|}~- separated by
+//     single dots) or a quoted string such as "john doe";
+//   - the domain is a hostname with at least two labels and an alphabetic top-level label,
+//     or an IP address literal such as [192.0.2.1] or [IPv6:2001:db8::1].
+//
+// Pass WithMXLookup to additionally check that the domain accepts mail.
+// It returns an error describing the first problem found.
+//
+// Examples:
+//
+//	ValidateEmail("test@example.com") == nil
+//	ValidateEmail("user+alias@domain.co.uk") == nil
+//	ValidateEmail(`"john doe"@example.com`) == nil
+//	ValidateEmail("invalid-email") returns an error
+//	ValidateEmail("john..doe@example.com") returns an error
+//	ValidateEmail("user@domain.") returns an error
+//	ValidateEmail("user@example.com", WithMXLookup(nil)) checks DNS for example.com
+func ValidateEmail(email string, opts ...EmailOption) error {
+	var o emailOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if len(email) > 254 {
+		return errors.New("email address is too long")
+	}
+	at := strings.LastIndexByte(email, '@')
+	if at < 0 {
+		return errors.New("email address must contain '@'")
+	}
+	local, domain := email[:at], email[at+1:]
+	if local == "" || len(local) > 64 {
+		return errors.New("email local part must be between 1 and 64 characters")
+	}
+	if !isValidEmailLocal(local) {
+		return errors.New("invalid email local part")
+	}
+
+	if strings.HasPrefix(domain, "[") && strings.HasSuffix(domain, "]") {
+		literal := strings.TrimSuffix(strings.TrimPrefix(domain, "["), "]")
+		if ip, ok := strings.CutPrefix(literal, "IPv6:"); ok {
+			if parsed := net.ParseIP(ip); parsed == nil || parsed.To4() != nil {
+				return errors.New("invalid email IPv6 domain literal")
+			}
+		} else if parsed := net.ParseIP(literal); parsed == nil || parsed.To4() == nil {
+			return errors.New("invalid email IPv4 domain literal")
+		}
+		// There is nothing to look up for a literal address.
+		return nil
+	}
+	if !isValidEmailDomain(domain) {
+		return errors.New("invalid email domain")
+	}
+
+	if o.lookupMX {
+		return lookupMailServer(domain, o.resolver)
+	}
+	return nil
+}
+
+// IsValidEmail reports whether s is a valid email address according to ValidateEmail.
+// No DNS lookups are performed.
+//
+// Examples:
+//
+//	IsValidEmail("test@example.com") == true
+//	IsValidEmail("@example.com") == false
+func IsValidEmail(s string) bool {
+	return ValidateEmail(s) == nil
+}
+
+// isEmailAtext reports whether r is an RFC 5322 "atext" character.
+func isEmailAtext(r rune) bool {
+	return r < 128 && (r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' ||
+		strings.ContainsRune("!#$%&'*+/=?^_`{|}~-", r))
+}
+
+// isValidEmailLocal reports whether local is a valid dot-atom or quoted-string local part.
+func isValidEmailLocal(local string) bool {
+	if len(local) >= 2 && local[0] == '"' && local[len(local)-1] == '"' {
+		inner := local[1 : len(local)-1]
+		for i := 0; i < len(inner); i++ {
+			c := inner[i]
+			switch {
+			case c == '\\':
+				// A backslash escapes the following printable character.
+				i++
+				if i == len(inner) || inner[i] < 32 || inner[i] > 126 {
+					return false
+				}
+			case c == '"' || c < 32 || c > 126:
+				return false
+			}
+		}
+		return true
+	}
+	for _, atom := range strings.Split(local, ".") {
+		if atom == "" {
+			return false
+		}
+		for _, r := range atom {
+			if !isEmailAtext(r) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// isValidEmailDomain reports whether domain is a hostname with at least two labels
+// and a top-level label that is not purely numeric.
+func isValidEmailDomain(domain string) bool {
+	if domain == "" || len(domain) > 253 {
+		return false
+	}
+	labels := strings.Split(domain, ".")
+	if len(labels) < 2 {
+		return false
+	}
+	for _, label := range labels {
+		if len(label) == 0 || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, r := range label {
+			if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-') {
+				return false
+			}
+		}
+	}
+	tld := labels[len(labels)-1]
+	return len(tld) >= 2 && strings.Trim(tld, "0123456789") != ""
+}
+
+// lookupMailServer returns an error if domain has neither MX records nor an address record.
+func lookupMailServer(domain string, resolver *net.Resolver) error {
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), emailLookupTimeout)
+	defer cancel()
+
+	if mx, err := resolver.LookupMX(ctx, domain); err == nil && len(mx) > 0 {
+		// A single "." record is a null MX (RFC 7505): the domain explicitly accepts no mail.
+		if len(mx) == 1 && mx[0].Host == "." {
+			return errors.New("email domain does not accept mail")
+		}
+		return nil
+	}
+	if addrs, err := resolver.LookupHost(ctx, domain); err == nil && len(addrs) > 0 {
+		return nil
+	}
+	return errors.New("email domain has no mail servers")
+}
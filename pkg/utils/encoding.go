@@ -0,0 +1,114 @@
+package utils
+
+import (
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// EncodeHex returns the lower-case hexadecimal encoding of s.
+//
+// Examples:
+//
+//	EncodeHex("Hi!") == "486921"
+//	EncodeHex("") == ""
+func EncodeHex(s string) string {
+	return hex.EncodeToString([]byte(s))
+}
+
+// DecodeHex decodes the hexadecimal string s, in either case.
+// It returns an error if s has an odd length or contains a non-hexadecimal character.
+//
+// Examples:
+//
+//	DecodeHex("486921") == ("Hi!", nil)
+//	DecodeHex("48692") returns ("", error)
+//	DecodeHex("zz") returns ("", error)
+func DecodeHex(s string) (string, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return "", fmt.Errorf("invalid hex string: %w", err)
+	}
+	return string(b), nil
+}
+
+// EncodeBase64 returns the standard, padded base64 encoding of s (RFC 4648 section 4).
+//
+// Examples:
+//
+//	EncodeBase64("hello?") == "aGVsbG8/"
+//	EncodeBase64("hi") == "aGk="
+func EncodeBase64(s string) string {
+	return base64.StdEncoding.EncodeToString([]byte(s))
+}
+
+// DecodeBase64 decodes the standard base64 string s. Padding is optional.
+// It returns an error if s is not valid base64.
+//
+// Examples:
+//
+//	DecodeBase64("aGk=") == ("hi", nil)
+//	DecodeBase64("aGk") == ("hi", nil)
+//	DecodeBase64("a$b") returns ("", error)
+func DecodeBase64(s string) (string, error) {
+	b, err := base64.RawStdEncoding.DecodeString(strings.TrimRight(s, "="))
+	if err != nil {
+		return "", fmt.Errorf("invalid base64 string: %w", err)
+	}
+	return string(b), nil
+}
+
+// EncodeBase64URL returns the URL- and filename-safe base64 encoding of s (RFC 4648 section 5)
+// without padding, as used in JWTs and URL tokens.
+//
+// Examples:
+//
+//	EncodeBase64URL("hello?") == "aGVsbG8_"
+//	EncodeBase64URL("hi") == "aGk"
+func EncodeBase64URL(s string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(s))
+}
+
+// DecodeBase64URL decodes the URL-safe base64 string s. Padding is optional.
+// It returns an error if s is not valid URL-safe base64.
+//
+// Examples:
+//
+//	DecodeBase64URL("aGVsbG8_") == ("hello?", nil)
+//	DecodeBase64URL("aGk=") == ("hi", nil)
+//	DecodeBase64URL("aGVsbG8/") returns ("", error)
+func DecodeBase64URL(s string) (string, error) {
+	b, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+	if err != nil {
+		return "", fmt.Errorf("invalid base64url string: %w", err)
+	}
+	return string(b), nil
+}
+
+// EncodeBase32 returns the standard, padded base32 encoding of s (RFC 4648 section 6).
+//
+// Examples:
+//
+//	EncodeBase32("hi") == "NBUQ===="
+func EncodeBase32(s string) string {
+	return base32.StdEncoding.EncodeToString([]byte(s))
+}
+
+// DecodeBase32 decodes the standard base32 string s. Padding is optional and letters
+// may be in either case, as base32 secrets for authenticator apps are often written.
+// It returns an error if s is not valid base32.
+//
+// Examples:
+//
+//	DecodeBase32("NBUQ====") == ("hi", nil)
+//	DecodeBase32("nbuq") == ("hi", nil)
+//	DecodeBase32("NBU1") returns ("", error)
+func DecodeBase32(s string) (string, error) {
+	b, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(strings.ToUpper(strings.TrimRight(s, "=")))
+	if err != nil {
+		return "", fmt.Errorf("invalid base32 string: %w", err)
+	}
+	return string(b), nil
+}
@@ -0,0 +1,136 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// runEveryOptions holds the settings applied by RunEveryOption values.
+type runEveryOptions struct {
+	immediate bool
+	onPanic   func(recovered any)
+}
+
+// RunEveryOption configures RunEvery and RunEveryWithJitter.
+type RunEveryOption func(*runEveryOptions)
+
+// RunEveryImmediately makes RunEvery and RunEveryWithJitter run the function once right away,
+// instead of waiting for the first interval to pass.
+func RunEveryImmediately() RunEveryOption {
+	return func(o *runEveryOptions) {
+		o.immediate = true
+	}
+}
+
+// RunEveryOnPanic sets a function called with the recovered value when a run panics, for
+// logging. Without it, panics are recovered silently. Either way, the runs go on.
+func RunEveryOnPanic(onPanic func(recovered any)) RunEveryOption {
+	return func(o *runEveryOptions) {
+		o.onPanic = onPanic
+	}
+}
+
+// RunEvery calls fn every interval until ctx is done, then returns ctx's error, so it is
+// usually started in its own goroutine. Runs never overlap: if a run takes longer than
+// interval, the ticks missed meanwhile are dropped and the next run starts at the next tick.
+// A panic in fn is recovered, so one failed run does not stop the task; see RunEveryOnPanic.
+// fn receives ctx, to stop early when it is done.
+// It returns an error at once if interval is not positive.
+//
+// Examples:
+//
+//	go RunEvery(ctx, time.Minute, func(ctx context.Context) {
+//		sessions.PurgeExpired(ctx)
+//	}, RunEveryImmediately())
+func RunEvery(ctx context.Context, interval time.Duration, fn func(ctx context.Context), opts ...RunEveryOption) error {
+	if interval <= 0 {
+		return errors.New("interval must be positive")
+	}
+	o := applyRunEveryOptions(opts)
+	if o.immediate && ctx.Err() == nil {
+		callEvery(ctx, fn, o)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			callEvery(ctx, fn, o)
+			// The ticker holds on to one tick that came during a long run; drop it, so the
+			// next run waits for the next tick instead of starting right away.
+			select {
+			case <-ticker.C:
+			default:
+			}
+		}
+	}
+}
+
+// RunEveryWithJitter is like RunEvery, but waits a random time of interval*(1±jitter) between
+// the end of one run and the start of the next, so that many instances of a task spread out
+// instead of running in lockstep. jitter is clamped to [0, 1]; 0.1 varies each wait by up to
+// 10% either way.
+// It returns an error at once if interval is not positive.
+//
+// Examples:
+//
+//	go RunEveryWithJitter(ctx, 5*time.Minute, 0.2, refreshCache)
+func RunEveryWithJitter(ctx context.Context, interval time.Duration, jitter float64, fn func(ctx context.Context), opts ...RunEveryOption) error {
+	if interval <= 0 {
+		return errors.New("interval must be positive")
+	}
+	if math.IsNaN(jitter) {
+		jitter = 0
+	}
+	jitter = min(max(jitter, 0), 1)
+	o := applyRunEveryOptions(opts)
+	if o.immediate && ctx.Err() == nil {
+		callEvery(ctx, fn, o)
+	}
+
+	timer := time.NewTimer(jitteredInterval(interval, jitter))
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+			callEvery(ctx, fn, o)
+			timer.Reset(jitteredInterval(interval, jitter))
+		}
+	}
+}
+
+// applyRunEveryOptions returns the settings configured by opts.
+func applyRunEveryOptions(opts []RunEveryOption) runEveryOptions {
+	var o runEveryOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// jitteredInterval returns a random duration uniformly distributed in interval*(1±jitter).
+func jitteredInterval(interval time.Duration, jitter float64) time.Duration {
+	d := float64(interval) * (1 + (2*rand.Float64()-1)*jitter)
+	if d >= math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return time.Duration(d)
+}
+
+// callEvery calls fn once, recovering a panic.
+func callEvery(ctx context.Context, fn func(context.Context), o runEveryOptions) {
+	defer func() {
+		if r := recover(); r != nil && o.onPanic != nil {
+			o.onPanic(r)
+		}
+	}()
+	fn(ctx)
+}
@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+)
+
+// PathInfoError is the error returned by PathInfo. It wraps the error from the file system,
+// so errors.Is(err, fs.ErrNotExist) also works.
+type PathInfoError struct {
+	Path string
+	Err  error
+}
+
+// Error returns the underlying error's message, which includes the path.
+func (e *PathInfoError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *PathInfoError) Unwrap() error {
+	return e.Err
+}
+
+// NotExist reports whether the error is due to the path, or one of its parent directories,
+// not existing.
+func (e *PathInfoError) NotExist() bool {
+	return errors.Is(e.Err, fs.ErrNotExist)
+}
+
+// PermissionDenied reports whether the error is due to the path not being accessible, which
+// means it may exist or not.
+func (e *PathInfoError) PermissionDenied() bool {
+	return errors.Is(e.Err, fs.ErrPermission)
+}
+
+// PathInfo returns information about the file or directory at path, following symbolic
+// links. It returns a *PathInfoError if that fails, which tells a missing path apart from one
+// that cannot be accessed, when FileExists and DirExists would only report false.
+//
+// Examples:
+//
+//	info, err := PathInfo("/var/log/app.log")
+//	var perr *PathInfoError
+//	switch {
+//	case errors.As(err, &perr) && perr.NotExist():
+//		// create it
+//	case err != nil:
+//		return err // e.g. permission denied
+//	}
+func PathInfo(path string) (fs.FileInfo, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, &PathInfoError{Path: path, Err: err}
+	}
+	return info, nil
+}
+
+// FileExists reports whether path is a regular file, or a symbolic link to one. It reports
+// false when path cannot be checked; use PathInfo to tell why.
+func FileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Mode().IsRegular()
+}
+
+// DirExists reports whether path is a directory, or a symbolic link to one. It reports false
+// when path cannot be checked; use PathInfo to tell why.
+func DirExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+// IsSymlink reports whether path is a symbolic link, whether or not its target exists.
+func IsSymlink(path string) bool {
+	info, err := os.Lstat(path)
+	return err == nil && info.Mode()&fs.ModeSymlink != 0
+}
@@ -0,0 +1,95 @@
+package utils
+
+import (
+	"context"
+	"runtime/debug"
+)
+
+// Future is the eventual result of a function running in its own goroutine, as started by
+// Async. Its result can be awaited any number of times, from any goroutine.
+type Future[T any] struct {
+	done  chan struct{}
+	value T
+	err   error
+}
+
+// Async calls fn in a new goroutine and returns a Future for its result. A panic in fn is
+// recovered and becomes the Future's error, a *PanicError.
+//
+// Examples:
+//
+//	user := Async(func() (User, error) { return users.Get(ctx, id) })
+//	orders := Async(func() ([]Order, error) { return orders.List(ctx, id) })
+//	u, err := user.Await(ctx)
+func Async[T any](fn func() (T, error)) *Future[T] {
+	f := &Future[T]{done: make(chan struct{})}
+	go func() {
+		defer close(f.done)
+		defer func() {
+			if r := recover(); r != nil {
+				f.err = &PanicError{Value: r, Stack: debug.Stack()}
+			}
+		}()
+		f.value, f.err = fn()
+	}()
+	return f
+}
+
+// Await waits for f's function to return and returns its result, or returns ctx's error if
+// ctx is done first. Giving up on a Future does not stop its function, which keeps running
+// until it returns.
+func (f *Future[T]) Await(ctx context.Context) (T, error) {
+	select {
+	case <-f.done:
+		return f.value, f.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// Done returns a channel that is closed when f's function has returned, for use in select.
+func (f *Future[T]) Done() <-chan struct{} {
+	return f.done
+}
+
+// Then returns a Future for the result of calling fn with f's value once f completes.
+// If f fails, fn is not called and the returned Future fails with the same error.
+// Then is a function rather than a method because methods cannot change the result type.
+//
+// Examples:
+//
+//	body := Async(func() ([]byte, error) { return download(url) })
+//	size := Then(body, func(b []byte) (int, error) { return len(b), nil })
+func Then[T, U any](f *Future[T], fn func(T) (U, error)) *Future[U] {
+	return Async(func() (U, error) {
+		<-f.done
+		if f.err != nil {
+			var zero U
+			return zero, f.err
+		}
+		return fn(f.value)
+	})
+}
+
+// AwaitAll waits for all futures and returns their values in the same order. It returns
+// the first error in that order, or ctx's error if ctx is done before every future completes.
+//
+// Examples:
+//
+//	pages := make([]*Future[[]byte], len(urls))
+//	for i, url := range urls {
+//		pages[i] = Async(func() ([]byte, error) { return download(url) })
+//	}
+//	bodies, err := AwaitAll(ctx, pages...)
+func AwaitAll[T any](ctx context.Context, futures ...*Future[T]) ([]T, error) {
+	values := make([]T, len(futures))
+	for i, f := range futures {
+		v, err := f.Await(ctx)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+	return values, nil
+}
@@ -0,0 +1,200 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Graph is a directed graph of dependencies between nodes of type T, such as tasks or
+// migrations: an edge from a to b means b depends on a, so a must come first. Nodes are kept
+// in the order they were first added, which makes the results of its methods deterministic.
+// The zero value is an empty graph ready to use. It is not safe for concurrent writes.
+type Graph[T comparable] struct {
+	nodes []T
+	index map[T]int
+	out   [][]int // out[i] are the nodes that depend on node i
+	in    [][]int // in[i] are the nodes node i depends on
+}
+
+// CycleError is returned by Graph.TopologicalSort when the graph has a cycle, which it lists.
+type CycleError[T comparable] struct {
+	Cycle []T // the nodes of the cycle, starting and ending with the same node
+}
+
+func (e *CycleError[T]) Error() string {
+	parts := make([]string, len(e.Cycle))
+	for i, n := range e.Cycle {
+		parts[i] = fmt.Sprint(n)
+	}
+	return "graph has a cycle: " + strings.Join(parts, " -> ")
+}
+
+// NewGraph returns an empty graph.
+//
+// Examples:
+//
+//	g := NewGraph[string]()
+//	g.AddEdge("create_users", "add_email_index")
+//	g.AddEdge("create_users", "create_orders")
+//	order, err := g.TopologicalSort() // [create_users add_email_index create_orders]
+func NewGraph[T comparable]() *Graph[T] {
+	return &Graph[T]{}
+}
+
+// AddNode adds node to g if it is not already there, for nodes without edges.
+func (g *Graph[T]) AddNode(node T) {
+	g.node(node)
+}
+
+// AddEdge records that to depends on from, adding both nodes if needed. Adding an edge
+// again has no effect.
+func (g *Graph[T]) AddEdge(from, to T) {
+	f, t := g.node(from), g.node(to)
+	for _, i := range g.out[f] {
+		if i == t {
+			return
+		}
+	}
+	g.out[f] = append(g.out[f], t)
+	g.in[t] = append(g.in[t], f)
+}
+
+// Has reports whether g holds node.
+func (g *Graph[T]) Has(node T) bool {
+	_, ok := g.index[node]
+	return ok
+}
+
+// Len returns the number of nodes in g.
+func (g *Graph[T]) Len() int {
+	return len(g.nodes)
+}
+
+// Nodes returns the nodes of g, in the order they were added.
+func (g *Graph[T]) Nodes() []T {
+	return append([]T(nil), g.nodes...)
+}
+
+// Dependencies returns the nodes that node depends on directly, that is, those with an edge
+// to it, in the order the edges were added.
+func (g *Graph[T]) Dependencies(node T) []T {
+	i, ok := g.index[node]
+	if !ok {
+		return nil
+	}
+	return g.values(g.in[i])
+}
+
+// Dependents returns the nodes that depend on node directly, that is, those it has an edge
+// to, in the order the edges were added.
+func (g *Graph[T]) Dependents(node T) []T {
+	i, ok := g.index[node]
+	if !ok {
+		return nil
+	}
+	return g.values(g.out[i])
+}
+
+// TopologicalSort returns the nodes of g ordered so that each comes after all the nodes it
+// depends on. Among nodes that could come next, those added first come first.
+// It returns a *CycleError listing one of the cycles if g has any, since no such order
+// exists then.
+//
+// Examples:
+//
+//	g.AddEdge("a", "b")
+//	g.AddEdge("b", "a")
+//	_, err := g.TopologicalSort() // graph has a cycle: a -> b -> a
+func (g *Graph[T]) TopologicalSort() ([]T, error) {
+	pending := make([]int, len(g.nodes)) // dependencies not yet sorted, per node
+	for i := range g.nodes {
+		pending[i] = len(g.in[i])
+	}
+	ready := NewPriorityQueue(func(a, b int) bool { return a < b })
+	for i, n := range pending {
+		if n == 0 {
+			ready.Push(i)
+		}
+	}
+
+	order := make([]T, 0, len(g.nodes))
+	for ready.Len() > 0 {
+		i, _ := ready.Pop()
+		order = append(order, g.nodes[i])
+		for _, d := range g.out[i] {
+			if pending[d]--; pending[d] == 0 {
+				ready.Push(d)
+			}
+		}
+	}
+	if len(order) < len(g.nodes) {
+		return nil, &CycleError[T]{Cycle: g.findCycle(pending)}
+	}
+	return order, nil
+}
+
+// findCycle returns a cycle among the nodes left unsorted by TopologicalSort, those whose
+// pending count is positive, starting from its node added first. Each of them depends on
+// another one, so following dependencies from any of them must come back to a node already
+// seen.
+func (g *Graph[T]) findCycle(pending []int) []T {
+	start := 0
+	for pending[start] == 0 {
+		start++
+	}
+	seen := make(map[int]int) // node to its position in path
+	var path []int
+	for i := start; ; {
+		if pos, ok := seen[i]; ok {
+			path = path[pos:]
+			break
+		}
+		seen[i] = len(path)
+		path = append(path, i)
+		for _, d := range g.in[i] {
+			if pending[d] > 0 {
+				i = d
+				break
+			}
+		}
+	}
+
+	// path follows the edges backwards; reverse it, starting from the node added first, and
+	// close the loop.
+	first := 0
+	for k, i := range path {
+		if i < path[first] {
+			first = k
+		}
+	}
+	cycle := make([]T, 0, len(path)+1)
+	for k := range path {
+		cycle = append(cycle, g.nodes[path[(first-k+len(path))%len(path)]])
+	}
+	return append(cycle, cycle[0])
+}
+
+// node returns the index of node, adding it if needed.
+func (g *Graph[T]) node(node T) int {
+	if i, ok := g.index[node]; ok {
+		return i
+	}
+	if g.index == nil {
+		g.index = make(map[T]int)
+	}
+	i := len(g.nodes)
+	g.index[node] = i
+	g.nodes = append(g.nodes, node)
+	g.out = append(g.out, nil)
+	g.in = append(g.in, nil)
+	return i
+}
+
+// values returns the nodes at indexes.
+func (g *Graph[T]) values(indexes []int) []T {
+	values := make([]T, len(indexes))
+	for k, i := range indexes {
+		values[k] = g.nodes[i]
+	}
+	return values
+}
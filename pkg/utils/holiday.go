@@ -0,0 +1,276 @@
+package utils
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// HolidayCalendar is a set of holidays, such as a country's public holidays.
+// Its IsHoliday method can be passed wherever a HolidayFunc is expected:
+//
+//	cal := USFederalCalendar()
+//	AddBusinessDays(t, 5, cal.IsHoliday)
+type HolidayCalendar interface {
+	// IsHoliday reports whether t's calendar date, in t's location, is a holiday.
+	IsHoliday(t time.Time) bool
+}
+
+// Holiday is a holiday on a particular date. Date is midnight UTC of that calendar date.
+type Holiday struct {
+	Date time.Time
+	Name string
+}
+
+// HolidayRule computes the date of a holiday in a given year, as midnight UTC, and reports
+// whether it is observed that year. The date may fall in a neighboring year, as when a
+// January 1 holiday on a Saturday is observed on the Friday before.
+type HolidayRule func(year int) (date time.Time, ok bool)
+
+// FixedHoliday returns a HolidayRule for the same month and day every year.
+//
+// Examples:
+//
+//	FixedHoliday(time.December, 25)(2024) == (time.Date(2024, 12, 25, 0, 0, 0, 0, time.UTC), true)
+func FixedHoliday(month time.Month, day int) HolidayRule {
+	return func(year int) (time.Time, bool) {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC), true
+	}
+}
+
+// NthWeekdayHoliday returns a HolidayRule for the nth given weekday of month, counting from 1,
+// or from the end of the month if n is negative, so -1 is the last. n must not be 0.
+//
+// Examples:
+//
+//	NthWeekdayHoliday(4, time.Thursday, time.November)(2024) == (time.Date(2024, 11, 28, 0, 0, 0, 0, time.UTC), true)
+//	NthWeekdayHoliday(-1, time.Monday, time.May)(2024) == (time.Date(2024, 5, 27, 0, 0, 0, 0, time.UTC), true)
+func NthWeekdayHoliday(n int, weekday time.Weekday, month time.Month) HolidayRule {
+	return func(year int) (time.Time, bool) {
+		if n < 0 {
+			last := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC)
+			back := (int(last.Weekday()) - int(weekday) + 7) % 7
+			return last.AddDate(0, 0, -back+(n+1)*7), true
+		}
+		first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+		ahead := (int(weekday) - int(first.Weekday()) + 7) % 7
+		return first.AddDate(0, 0, ahead+(n-1)*7), true
+	}
+}
+
+// EasterHoliday returns a HolidayRule for the day offset days from Easter Sunday in the
+// Gregorian calendar, such as -2 for Good Friday or 60 for Corpus Christi.
+//
+// Examples:
+//
+//	EasterHoliday(0)(2024) == (time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), true)
+//	EasterHoliday(-2)(2025) == (time.Date(2025, 4, 18, 0, 0, 0, 0, time.UTC), true)
+func EasterHoliday(offset int) HolidayRule {
+	return func(year int) (time.Time, bool) {
+		return easterSunday(year).AddDate(0, 0, offset), true
+	}
+}
+
+// ObservedHoliday returns a HolidayRule that moves rule's date to the Friday before when it
+// falls on a Saturday and to the Monday after when it falls on a Sunday, as US federal
+// holidays are observed.
+func ObservedHoliday(rule HolidayRule) HolidayRule {
+	return func(year int) (time.Time, bool) {
+		date, ok := rule(year)
+		switch date.Weekday() {
+		case time.Saturday:
+			date = date.AddDate(0, 0, -1)
+		case time.Sunday:
+			date = date.AddDate(0, 0, 1)
+		}
+		return date, ok
+	}
+}
+
+// HolidaySince returns a HolidayRule that is observed only from the year since on,
+// for holidays introduced by law at some point.
+func HolidaySince(since int, rule HolidayRule) HolidayRule {
+	return func(year int) (time.Time, bool) {
+		if year < since {
+			return time.Time{}, false
+		}
+		return rule(year)
+	}
+}
+
+// easterSunday returns the date of Easter Sunday in the Gregorian calendar, as midnight UTC,
+// using the anonymous Gregorian algorithm (Meeus/Jones/Butcher).
+func easterSunday(year int) time.Time {
+	a := year % 19
+	b, c := year/100, year%100
+	d, e := b/4, b%4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i, k := c/4, c%4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
+
+// RuleCalendar is a HolidayCalendar built from named HolidayRules and one-off dates.
+// Holidays are computed per year on first use and cached. It is safe for concurrent use,
+// including adding holidays while others call IsHoliday.
+type RuleCalendar struct {
+	mu    sync.RWMutex
+	rules []namedHolidayRule
+	years map[int][]Holiday // cache of the holidays computed for each year
+}
+
+// namedHolidayRule is a HolidayRule with the name of its holiday.
+type namedHolidayRule struct {
+	name string
+	rule HolidayRule
+}
+
+// NewRuleCalendar returns an empty RuleCalendar, to which holidays are added with
+// AddRule and AddDate.
+//
+// Examples:
+//
+//	payroll := NewRuleCalendar().
+//		AddRule("Carnival Monday", EasterHoliday(-48)).
+//		AddDate("Company anniversary", time.Date(2024, 8, 19, 0, 0, 0, 0, time.UTC))
+func NewRuleCalendar() *RuleCalendar {
+	return &RuleCalendar{}
+}
+
+// AddRule adds a holiday named name whose date in each year is given by rule, and returns c
+// so that calls can be chained.
+func (c *RuleCalendar) AddRule(name string, rule HolidayRule) *RuleCalendar {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.rules = append(c.rules, namedHolidayRule{name, rule})
+	c.years = nil
+	return c
+}
+
+// AddDate adds a one-off holiday named name on date's calendar date, in date's location,
+// and returns c so that calls can be chained.
+func (c *RuleCalendar) AddDate(name string, date time.Time) *RuleCalendar {
+	y, m, d := date.Date()
+	day := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	return c.AddRule(name, func(year int) (time.Time, bool) {
+		return day, year == y
+	})
+}
+
+// IsHoliday reports whether t's calendar date, in t's location, is one of c's holidays.
+//
+// Examples:
+//
+//	USFederalCalendar().IsHoliday(time.Date(2024, 7, 4, 15, 0, 0, 0, time.UTC)) == true
+//	USFederalCalendar().IsHoliday(time.Date(2024, 7, 5, 15, 0, 0, 0, time.UTC)) == false
+func (c *RuleCalendar) IsHoliday(t time.Time) bool {
+	_, ok := c.HolidayName(t)
+	return ok
+}
+
+// HolidayName returns the name of the holiday on t's calendar date, in t's location, and
+// reports whether there is one. If several holidays share the date, the first added is returned.
+//
+// Examples:
+//
+//	BrazilNationalCalendar().HolidayName(time.Date(2024, 4, 21, 0, 0, 0, 0, time.UTC)) == ("Tiradentes", true)
+func (c *RuleCalendar) HolidayName(t time.Time) (string, bool) {
+	y, m, d := t.Date()
+	for _, h := range c.Holidays(y) {
+		if h.Date.Month() == m && h.Date.Day() == d {
+			return h.Name, true
+		}
+	}
+	return "", false
+}
+
+// Holidays returns c's holidays that fall in year, sorted by date.
+//
+// Examples:
+//
+//	for _, h := range USFederalCalendar().Holidays(2024) {
+//		fmt.Println(h.Date.Format(time.DateOnly), h.Name)
+//	}
+func (c *RuleCalendar) Holidays(year int) []Holiday {
+	c.mu.RLock()
+	holidays, ok := c.years[year]
+	c.mu.RUnlock()
+	if ok {
+		return append([]Holiday(nil), holidays...)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	holidays = nil
+	for _, r := range c.rules {
+		// A rule's date can move into a neighboring year, so the years around year are checked too.
+		for _, y := range []int{year - 1, year, year + 1} {
+			if date, ok := r.rule(y); ok && date.Year() == year {
+				holidays = append(holidays, Holiday{Date: date, Name: r.name})
+			}
+		}
+	}
+	sort.SliceStable(holidays, func(i, j int) bool { return holidays[i].Date.Before(holidays[j].Date) })
+	if c.years == nil {
+		c.years = make(map[int][]Holiday)
+	}
+	c.years[year] = holidays
+	return append([]Holiday(nil), holidays...)
+}
+
+// USFederalCalendar returns a new RuleCalendar with the US federal holidays established by
+// 5 U.S.C. 6103, on the days they are observed: a holiday on a Saturday is observed on the
+// Friday before, and one on a Sunday on the Monday after. Juneteenth is included from 2021
+// and Martin Luther King Jr. Day from 1986. The calendar can be extended with AddRule and AddDate.
+//
+// Examples:
+//
+//	us := USFederalCalendar()
+//	us.IsHoliday(time.Date(2024, 11, 28, 0, 0, 0, 0, time.UTC)) == true // Thanksgiving
+//	us.IsHoliday(time.Date(2021, 12, 31, 0, 0, 0, 0, time.UTC)) == true // New Year's Day 2022, observed
+//	AddBusinessDays(time.Date(2024, 7, 3, 0, 0, 0, 0, time.UTC), 1, us.IsHoliday) is Friday, July 5
+func USFederalCalendar() *RuleCalendar {
+	return NewRuleCalendar().
+		AddRule("New Year's Day", ObservedHoliday(FixedHoliday(time.January, 1))).
+		AddRule("Martin Luther King Jr. Day", HolidaySince(1986, NthWeekdayHoliday(3, time.Monday, time.January))).
+		AddRule("Washington's Birthday", NthWeekdayHoliday(3, time.Monday, time.February)).
+		AddRule("Memorial Day", NthWeekdayHoliday(-1, time.Monday, time.May)).
+		AddRule("Juneteenth National Independence Day", HolidaySince(2021, ObservedHoliday(FixedHoliday(time.June, 19)))).
+		AddRule("Independence Day", ObservedHoliday(FixedHoliday(time.July, 4))).
+		AddRule("Labor Day", NthWeekdayHoliday(1, time.Monday, time.September)).
+		AddRule("Columbus Day", NthWeekdayHoliday(2, time.Monday, time.October)).
+		AddRule("Veterans Day", ObservedHoliday(FixedHoliday(time.November, 11))).
+		AddRule("Thanksgiving Day", NthWeekdayHoliday(4, time.Thursday, time.November)).
+		AddRule("Christmas Day", ObservedHoliday(FixedHoliday(time.December, 25)))
+}
+
+// BrazilNationalCalendar returns a new RuleCalendar with Brazil's national holidays, which are
+// not moved when they fall on a weekend: the civil holidays set by federal law, Good Friday,
+// and the Black Consciousness Day from 2024. Carnival and Corpus Christi are optional days off
+// (pontos facultativos) rather than holidays and are not included, but banks and many employers
+// close on them; add them with EasterHoliday(-48), EasterHoliday(-47) and EasterHoliday(60).
+//
+// Examples:
+//
+//	br := BrazilNationalCalendar()
+//	br.IsHoliday(time.Date(2024, 9, 7, 0, 0, 0, 0, time.UTC)) == true // Independence Day
+//	br.IsHoliday(time.Date(2024, 3, 29, 0, 0, 0, 0, time.UTC)) == true // Good Friday
+//	br.AddRule("Carnaval", EasterHoliday(-47))
+func BrazilNationalCalendar() *RuleCalendar {
+	return NewRuleCalendar().
+		AddRule("Confraternização Universal", FixedHoliday(time.January, 1)).
+		AddRule("Sexta-feira Santa", EasterHoliday(-2)).
+		AddRule("Tiradentes", FixedHoliday(time.April, 21)).
+		AddRule("Dia do Trabalho", FixedHoliday(time.May, 1)).
+		AddRule("Independência do Brasil", FixedHoliday(time.September, 7)).
+		AddRule("Nossa Senhora Aparecida", FixedHoliday(time.October, 12)).
+		AddRule("Finados", FixedHoliday(time.November, 2)).
+		AddRule("Proclamação da República", FixedHoliday(time.November, 15)).
+		AddRule("Dia Nacional de Zumbi e da Consciência Negra", HolidaySince(2024, FixedHoliday(time.November, 20))).
+		AddRule("Natal", FixedHoliday(time.December, 25))
+}
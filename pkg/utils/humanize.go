@@ -0,0 +1,154 @@
+package utils
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// humanizeNumber scales n by powers of 1000 and appends the matching suffix,
+// keeping at most decimals decimal places and dropping trailing zeros.
+func humanizeNumber(n float64, decimals int, suffixes []string) string {
+	if math.IsNaN(n) || math.IsInf(n, 0) {
+		return strconv.FormatFloat(n, 'f', -1, 64)
+	}
+	if decimals < 0 {
+		decimals = 0
+	}
+	value := math.Abs(n)
+	i := 0
+	for value >= 1000 && i < len(suffixes)-1 {
+		value /= 1000
+		i++
+	}
+	// Rounding may carry into the next suffix, as in 999950 shown as 1000.0K.
+	value = RoundTo(value, decimals)
+	if value >= 1000 && i < len(suffixes)-1 {
+		value = RoundTo(value/1000, decimals)
+		i++
+	}
+	s := strconv.FormatFloat(value, 'f', decimals, 64)
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	if n < 0 && s != "0" {
+		s = "-" + s
+	}
+	return s + suffixes[i]
+}
+
+// HumanizeNumber abbreviates n with the short-scale suffixes K (thousand), M (million),
+// B (billion) and T (trillion), keeping at most decimals decimal places and dropping trailing
+// zeros, as dashboards and social counters show figures. Numbers below 1000 get no suffix, and
+// numbers of a thousand trillion or more keep the T suffix. A negative decimals is treated as 0.
+//
+// Examples:
+//
+//	HumanizeNumber(1234, 1) == "1.2K"
+//	HumanizeNumber(3_400_000, 1) == "3.4M"
+//	HumanizeNumber(1_000_000, 2) == "1M"
+//	HumanizeNumber(999_950, 1) == "1M"
+//	HumanizeNumber(-2_500_000_000, 1) == "-2.5B"
+//	HumanizeNumber(999, 1) == "999"
+func HumanizeNumber(n float64, decimals int) string {
+	return humanizeNumber(n, decimals, []string{"", "K", "M", "B", "T"})
+}
+
+// HumanizeNumberSI abbreviates n like HumanizeNumber, but with the SI prefixes k (kilo), M (mega),
+// G (giga), T (tera), P (peta) and E (exa), as used for metrics such as request rates.
+//
+// Examples:
+//
+//	HumanizeNumberSI(1234, 1) == "1.2k"
+//	HumanizeNumberSI(5_600_000_000, 1) == "5.6G"
+//	HumanizeNumberSI(12, 0) == "12"
+func HumanizeNumberSI(n float64, decimals int) string {
+	return humanizeNumber(n, decimals, []string{"", "k", "M", "G", "T", "P", "E"})
+}
+
+// humanDurationUnits are the units HumanizeDuration writes, largest first.
+var humanDurationUnits = []struct {
+	length          time.Duration
+	short, singular string
+}{
+	{Day, "", "day"},
+	{time.Hour, "h", ""},
+	{time.Minute, "m", ""},
+	{time.Second, "s", ""},
+	{time.Millisecond, "ms", ""},
+}
+
+// HumanizeDuration formats d for user-facing messages, such as "2h 35m" or "3 days", instead of
+// the "2h35m0s" of time.Duration.String. Days are spelled out and smaller units abbreviated.
+// At most maxUnits adjacent units are written (2 if omitted), starting from the largest non-zero
+// one; the last is rounded to the nearest, half up, and units that round to zero are left out.
+// Milliseconds appear only for durations under a second, and durations under half a millisecond
+// are "0s". Negative durations are prefixed with "-".
+//
+// Examples:
+//
+//	HumanizeDuration(2*time.Hour + 35*time.Minute + 10*time.Second) == "2h 35m"
+//	HumanizeDuration(2*time.Hour + 35*time.Minute + 40*time.Second) == "2h 36m"
+//	HumanizeDuration(2*time.Hour + 35*time.Minute + 10*time.Second, 3) == "2h 35m 10s"
+//	HumanizeDuration(72*time.Hour + 5*time.Minute) == "3 days"
+//	HumanizeDuration(26*time.Hour, 1) == "1 day"
+//	HumanizeDuration(90*time.Second, 1) == "2m"
+//	HumanizeDuration(250*time.Millisecond) == "250ms"
+//	HumanizeDuration(0) == "0s"
+func HumanizeDuration(d time.Duration, maxUnits ...int) string {
+	limit := 2
+	if len(maxUnits) > 0 {
+		limit = max(maxUnits[0], 1)
+	}
+	sign := ""
+	if d < 0 {
+		sign = "-"
+		d = -d
+		if d < 0 {
+			d = math.MaxInt64 // -math.MinInt64 overflows; one nanosecond is not visible.
+		}
+	}
+
+	// Find the largest unit present, round to the smallest unit shown, and repeat once
+	// in case rounding carried into a larger unit (as 59m59.6s becomes 1h).
+	first := len(humanDurationUnits) - 1
+	for i, u := range humanDurationUnits {
+		if d >= u.length {
+			first = i
+			break
+		}
+	}
+	last := min(first+limit-1, len(humanDurationUnits)-1)
+	if first < len(humanDurationUnits)-1 && last == len(humanDurationUnits)-1 {
+		last-- // Only write milliseconds for durations under a second.
+	}
+	d = d.Round(humanDurationUnits[last].length)
+	if d == 0 {
+		return "0s"
+	}
+	for i, u := range humanDurationUnits[:first] {
+		if d >= u.length {
+			first = i
+			break
+		}
+	}
+
+	var parts []string
+	for _, u := range humanDurationUnits[first : last+1] {
+		n := d / u.length
+		d -= n * u.length
+		if n == 0 {
+			continue
+		}
+		switch {
+		case u.short != "":
+			parts = append(parts, strconv.FormatInt(int64(n), 10)+u.short)
+		case n == 1:
+			parts = append(parts, "1 "+u.singular)
+		default:
+			parts = append(parts, strconv.FormatInt(int64(n), 10)+" "+u.singular+"s")
+		}
+	}
+	return sign + strings.Join(parts, " ")
+}
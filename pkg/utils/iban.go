@@ -0,0 +1,108 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ibanLengths maps the country codes of the IBAN registry (ISO 13616) to the length of their IBANs.
+var ibanLengths = map[string]int{
+	"AD": 24, "AE": 23, "AL": 28, "AT": 20, "AZ": 28, "BA": 20, "BE": 16, "BG": 22,
+	"BH": 22, "BI": 27, "BR": 29, "BY": 28, "CH": 21, "CR": 22, "CY": 28, "CZ": 24,
+	"DE": 22, "DJ": 27, "DK": 18, "DO": 28, "EE": 20, "EG": 29, "ES": 24, "FI": 18,
+	"FK": 18, "FO": 18, "FR": 27, "GB": 22, "GE": 22, "GI": 23, "GL": 18, "GR": 27,
+	"GT": 28, "HR": 21, "HU": 28, "IE": 22, "IL": 23, "IQ": 23, "IS": 26, "IT": 27,
+	"JO": 30, "KW": 30, "KZ": 20, "LB": 28, "LC": 32, "LI": 21, "LT": 20, "LU": 20,
+	"LV": 21, "LY": 25, "MC": 27, "MD": 24, "ME": 22, "MK": 19, "MN": 20, "MR": 27,
+	"MT": 31, "MU": 30, "NI": 28, "NL": 18, "NO": 15, "OM": 23, "PK": 24, "PL": 28,
+	"PS": 29, "PT": 25, "QA": 29, "RO": 24, "RS": 22, "RU": 33, "SA": 24, "SC": 31,
+	"SD": 18, "SE": 24, "SI": 19, "SK": 24, "SM": 27, "SO": 23, "ST": 25, "SV": 28,
+	"TL": 23, "TN": 24, "TR": 26, "UA": 29, "VA": 22, "VG": 24, "XK": 20, "YE": 30,
+}
+
+// normalizeIBAN removes spaces and converts s to upper case.
+func normalizeIBAN(s string) string {
+	return strings.ToUpper(strings.ReplaceAll(s, " ", ""))
+}
+
+// ValidateIBAN checks if a string is a valid International Bank Account Number.
+// Spaces are ignored and letters may be in either case. The country code must be one
+// of the IBAN registry's, the length must match that country's, and the ISO 7064
+// mod-97 checksum must hold.
+// It returns an error if the string is not a valid IBAN.
+//
+// Examples:
+//
+//	ValidateIBAN("DE89 3704 0044 0532 0130 00") == nil
+//	ValidateIBAN("gb82west12345698765432") == nil
+//	ValidateIBAN("DE89 3704 0044 0532 0130 01") returns an error (checksum)
+//	ValidateIBAN("DE89 3704 0044 0532 0130") returns an error (length)
+//	ValidateIBAN("ZZ12 3456") returns an error (unknown country)
+func ValidateIBAN(s string) error {
+	iban := normalizeIBAN(s)
+	if len(iban) < 4 {
+		return errors.New("IBAN is too short")
+	}
+	want, ok := ibanLengths[iban[:2]]
+	if !ok {
+		return fmt.Errorf("unknown IBAN country code %q", iban[:2])
+	}
+	if len(iban) != want {
+		return fmt.Errorf("invalid IBAN length for %s: must be %d characters", iban[:2], want)
+	}
+
+	// Move the country code and check digits to the end, replace letters with 10-35,
+	// and compute the remainder digit by digit so the number never overflows.
+	remainder := 0
+	for _, c := range iban[4:] + iban[:4] {
+		switch {
+		case c >= '0' && c <= '9':
+			remainder = (remainder*10 + int(c-'0')) % 97
+		case c >= 'A' && c <= 'Z':
+			remainder = (remainder*100 + int(c-'A') + 10) % 97
+		default:
+			return errors.New("invalid character in IBAN")
+		}
+	}
+	if remainder != 1 {
+		return errors.New("invalid IBAN checksum")
+	}
+	return nil
+}
+
+// IsValidIBAN reports whether s is a valid IBAN according to ValidateIBAN.
+//
+// Examples:
+//
+//	IsValidIBAN("NL91 ABNA 0417 1643 00") == true
+//	IsValidIBAN("NL91 ABNA 0417 1643 01") == false
+func IsValidIBAN(s string) bool {
+	return ValidateIBAN(s) == nil
+}
+
+// FormatIBAN returns s in the print format of the IBAN standard: upper case,
+// in groups of four characters separated by single spaces.
+// It returns an error if s is not a valid IBAN according to ValidateIBAN.
+//
+// Examples:
+//
+//	FormatIBAN("de89370400440532013000") == ("DE89 3704 0044 0532 0130 00", nil)
+//	FormatIBAN("NO93 8601 1117 947") == ("NO93 8601 1117 947", nil)
+//	FormatIBAN("DE00") returns an error
+func FormatIBAN(s string) (string, error) {
+	if err := ValidateIBAN(s); err != nil {
+		return "", err
+	}
+	iban := normalizeIBAN(s)
+
+	var builder strings.Builder
+	builder.Grow(len(iban) + len(iban)/4)
+	for i := 0; i < len(iban); i += 4 {
+		if i > 0 {
+			builder.WriteByte(' ')
+		}
+		builder.WriteString(iban[i:Min(i+4, len(iban))])
+	}
+	return builder.String(), nil
+}
@@ -0,0 +1,179 @@
+package utils
+
+// imListBits is the number of index bits consumed by each level of an ImList's tree, which
+// has imListWidth children per node.
+const (
+	imListBits  = 5
+	imListWidth = 1 << imListBits
+	imListMask  = imListWidth - 1
+)
+
+// ImList is an immutable list. Append, Set and Slice return a new list and leave the original
+// unchanged, sharing most of their structure with it instead of copying, so they take
+// logarithmic rather than linear time. Since a list never changes, it can be shared between
+// goroutines without locking. The zero value is an empty list ready to use.
+//
+// Like a Go slice, a list made by Slice keeps the elements outside its bounds alive.
+type ImList[T any] struct {
+	root   *imListNode[T] // a tree holding elements 0 to count-1
+	shift  uint           // index bits below the root; 0 if the root is a leaf
+	count  int            // number of elements in the tree
+	offset int            // index in the tree of the list's first element
+	size   int            // number of elements in the list
+}
+
+// imListNode is a node of an ImList's tree: an inner node with children, or a leaf with values.
+type imListNode[T any] struct {
+	children []*imListNode[T]
+	values   []T
+}
+
+// NewImList returns a list holding items.
+//
+// Examples:
+//
+//	base := NewImList("a", "b")
+//	next := base.Append("c")
+//	base.Len() == 2 && next.Len() == 3
+func NewImList[T any](items ...T) ImList[T] {
+	return ImList[T]{}.Append(items...)
+}
+
+// Len returns the number of elements in l.
+func (l ImList[T]) Len() int {
+	return l.size
+}
+
+// Get returns the element at index i. It panics if i is out of range.
+func (l ImList[T]) Get(i int) T {
+	if i < 0 || i >= l.size {
+		panic("utils: ImList index out of range")
+	}
+	i += l.offset
+	n := l.root
+	for s := l.shift; s > 0; s -= imListBits {
+		n = n.children[(i>>s)&imListMask]
+	}
+	return n.values[i&imListMask]
+}
+
+// Set returns a list like l with the element at index i replaced by value.
+// It panics if i is out of range.
+//
+// Examples:
+//
+//	cfg2 := cfg.Set(0, "debug")
+func (l ImList[T]) Set(i int, value T) ImList[T] {
+	if i < 0 || i >= l.size {
+		panic("utils: ImList index out of range")
+	}
+	l.root = l.root.set(l.shift, i+l.offset, value)
+	return l
+}
+
+// Append returns a list like l with items added at the end.
+//
+// Examples:
+//
+//	servers := NewImList[string]()
+//	servers = servers.Append("10.0.0.1", "10.0.0.2")
+func (l ImList[T]) Append(items ...T) ImList[T] {
+	for _, item := range items {
+		end := l.offset + l.size
+		if end < l.count {
+			// l is a slice that ends before the tree does: overwrite the hidden element.
+			l.root = l.root.set(l.shift, end, item)
+		} else {
+			l = l.push(item)
+		}
+		l.size++
+	}
+	return l
+}
+
+// Slice returns the list of the elements of l from index lo to hi-1, like l[lo:hi] for a
+// slice. It panics if the bounds are out of range.
+func (l ImList[T]) Slice(lo, hi int) ImList[T] {
+	if lo < 0 || hi < lo || hi > l.size {
+		panic("utils: ImList slice bounds out of range")
+	}
+	l.offset += lo
+	l.size = hi - lo
+	return l
+}
+
+// All returns an iterator over the elements of l in order, for use with range-over-func.
+// Its type is that of iter.Seq2[int, T], spelled out as for DayRange.
+//
+// Examples:
+//
+//	for i, host := range servers.All() {
+//		fmt.Println(i, host)
+//	}
+func (l ImList[T]) All() func(yield func(int, T) bool) {
+	return func(yield func(int, T) bool) {
+		for i := 0; i < l.size; i++ {
+			if !yield(i, l.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+// ToSlice returns the elements of l in a new slice.
+func (l ImList[T]) ToSlice() []T {
+	s := make([]T, l.size)
+	for i := range s {
+		s[i] = l.Get(i)
+	}
+	return s
+}
+
+// push returns l with value added at the end of its tree, where l.offset+l.size == l.count.
+func (l ImList[T]) push(value T) ImList[T] {
+	if l.root == nil {
+		l.root = &imListNode[T]{}
+	} else if l.count == 1<<(l.shift+imListBits) {
+		// The tree is full: add a level above the root.
+		l.root = &imListNode[T]{children: []*imListNode[T]{l.root}}
+		l.shift += imListBits
+	}
+	l.root = l.root.push(l.shift, l.count, value)
+	l.count++
+	return l
+}
+
+// set returns a copy of n with the element at index i set to value, sharing the subtrees it
+// does not change.
+func (n *imListNode[T]) set(shift uint, i int, value T) *imListNode[T] {
+	c := &imListNode[T]{}
+	if shift == 0 {
+		c.values = append([]T(nil), n.values...)
+		c.values[i&imListMask] = value
+		return c
+	}
+	c.children = append([]*imListNode[T](nil), n.children...)
+	k := (i >> shift) & imListMask
+	c.children[k] = n.children[k].set(shift-imListBits, i, value)
+	return c
+}
+
+// push returns a copy of n, or a new node if n is nil, with value added at index i, the
+// first index past the end of n.
+func (n *imListNode[T]) push(shift uint, i int, value T) *imListNode[T] {
+	c := &imListNode[T]{}
+	if n == nil {
+		n = c
+	}
+	if shift == 0 {
+		c.values = append(append(make([]T, 0, len(n.values)+1), n.values...), value)
+		return c
+	}
+	k := (i >> shift) & imListMask
+	c.children = append(make([]*imListNode[T], 0, k+1), n.children...)
+	if k == len(c.children) {
+		c.children = append(c.children, nil)
+	}
+	c.children[k] = c.children[k].push(shift-imListBits, i, value)
+	return c
+}
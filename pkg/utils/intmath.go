@@ -0,0 +1,127 @@
+package utils
+
+import "errors"
+
+// GCD returns the greatest common divisor of the given integers.
+// The result is always non-negative; GCD() and GCD(0, 0) return 0.
+// For signed types, the result is undefined when it would be the magnitude of the type's minimum value.
+//
+// Examples:
+//
+//	GCD(12, 18) == 6
+//	GCD(12, 18, 8) == 2
+//	GCD(-4, 6) == 2
+//	GCD(7) == 7
+func GCD[T Integer](values ...T) T {
+	var result T
+	for _, v := range values {
+		a, b := result, v
+		for b != 0 {
+			a, b = b, a%b
+		}
+		result = a
+	}
+	if result < 0 {
+		result = -result
+	}
+	return result
+}
+
+// LCM returns the least common multiple of the given integers.
+// The result is always non-negative; if any value is zero, the result is zero.
+// It returns ErrOverflow if the result does not fit in T and an error if no values are given.
+//
+// Examples:
+//
+//	LCM(4, 6) == (12, nil)
+//	LCM(2, 3, 4) == (12, nil)
+//	LCM(-3, 5) == (15, nil)
+//	LCM(int8(100), int8(3)) returns (0, ErrOverflow)
+func LCM[T Integer](values ...T) (T, error) {
+	if len(values) == 0 {
+		return 0, errors.New("at least one value is required")
+	}
+	result := values[0]
+	if result < 0 {
+		result = -result
+		if result < 0 {
+			return 0, ErrOverflow
+		}
+	}
+	for _, v := range values[1:] {
+		if result == 0 || v == 0 {
+			return 0, nil
+		}
+		if v < 0 {
+			v = -v
+			if v < 0 {
+				return 0, ErrOverflow
+			}
+		}
+		var err error
+		result, err = SafeMul(result/GCD(result, v), v)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return result, nil
+}
+
+// Pow returns base raised to the power exp using exponentiation by squaring.
+// It returns ErrOverflow if the result does not fit in T and an error if exp is negative.
+//
+// Examples:
+//
+//	Pow(2, 10) == (1024, nil)
+//	Pow(-3, 3) == (-27, nil)
+//	Pow(5, 0) == (1, nil)
+//	Pow(int64(10), 19) returns (0, ErrOverflow)
+//	Pow(2, -1) returns an error
+func Pow[T Integer](base T, exp int) (T, error) {
+	if exp < 0 {
+		return 0, errors.New("exponent cannot be negative")
+	}
+	result := T(1)
+	for exp > 0 {
+		var err error
+		if exp&1 == 1 {
+			if result, err = SafeMul(result, base); err != nil {
+				return 0, err
+			}
+		}
+		exp >>= 1
+		if exp > 0 {
+			if base, err = SafeMul(base, base); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return result, nil
+}
+
+// DivMod returns the floored quotient and remainder of a divided by b.
+// Unlike Go's / and % operators, which truncate toward zero, the remainder
+// always has the same sign as the divisor (matching Python's divmod), so that
+// a == q*b + r holds with 0 <= |r| < |b|.
+// It returns an error if b is zero and ErrOverflow for the minimum signed value divided by -1.
+//
+// Examples:
+//
+//	DivMod(7, 2) == (3, 1, nil)
+//	DivMod(-7, 2) == (-4, 1, nil)
+//	DivMod(7, -2) == (-4, -1, nil)
+//	DivMod(7, 0) returns an error
+func DivMod[T Integer](a, b T) (q, r T, err error) {
+	if b == 0 {
+		return 0, 0, errors.New("division by zero")
+	}
+	if isSigned[T]() && b == ^T(0) && a < 0 && -a < 0 {
+		return 0, 0, ErrOverflow
+	}
+	q, r = a/b, a%b
+	if r != 0 && (r < 0) != (b < 0) {
+		q--
+		r += b
+	}
+	return q, r, nil
+}
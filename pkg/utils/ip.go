@@ -0,0 +1,109 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+)
+
+// MaxExpandCIDR is the largest number of addresses ExpandCIDR will return.
+const MaxExpandCIDR = 65536
+
+// IsValidIPv4 reports whether s is an IPv4 address in dotted-decimal form.
+// Leading zeros in an octet are rejected, since they are ambiguous (some parsers read them as octal).
+//
+// Examples:
+//
+//	IsValidIPv4("192.168.1.1") == true
+//	IsValidIPv4("192.168.1.256") == false
+//	IsValidIPv4("192.168.01.1") == false
+//	IsValidIPv4("::1") == false
+func IsValidIPv4(s string) bool {
+	addr, err := netip.ParseAddr(s)
+	return err == nil && addr.Is4()
+}
+
+// IsValidIPv6 reports whether s is an IPv6 address, including IPv4-mapped forms
+// such as "::ffff:192.0.2.1". Zone identifiers ("fe80::1%eth0") are accepted.
+//
+// Examples:
+//
+//	IsValidIPv6("2001:db8::1") == true
+//	IsValidIPv6("::ffff:192.0.2.1") == true
+//	IsValidIPv6("192.168.1.1") == false
+//	IsValidIPv6("2001:db8:::1") == false
+func IsValidIPv6(s string) bool {
+	addr, err := netip.ParseAddr(s)
+	return err == nil && addr.Is6()
+}
+
+// IsPrivateIP reports whether s is an address from a private-use range:
+// 10.0.0.0/8, 172.16.0.0/12 and 192.168.0.0/16 for IPv4 (RFC 1918),
+// or fc00::/7 for IPv6 (RFC 4193). IPv4-mapped IPv6 addresses are checked as IPv4.
+// Loopback and link-local addresses are not considered private.
+// It returns false if s is not a valid IP address.
+//
+// Examples:
+//
+//	IsPrivateIP("10.1.2.3") == true
+//	IsPrivateIP("172.31.255.255") == true
+//	IsPrivateIP("fd00::1") == true
+//	IsPrivateIP("8.8.8.8") == false
+//	IsPrivateIP("127.0.0.1") == false
+func IsPrivateIP(s string) bool {
+	addr, err := netip.ParseAddr(s)
+	return err == nil && addr.Unmap().IsPrivate()
+}
+
+// CIDRContains reports whether the network cidr (for example "10.0.0.0/8") contains the address ip.
+// An IPv4 address is never contained in an IPv6 network, and vice versa,
+// except that IPv4-mapped IPv6 addresses are treated as IPv4.
+// It returns an error if cidr or ip cannot be parsed.
+//
+// Examples:
+//
+//	CIDRContains("10.0.0.0/8", "10.20.30.40") == (true, nil)
+//	CIDRContains("192.168.1.0/24", "192.168.2.1") == (false, nil)
+//	CIDRContains("2001:db8::/32", "2001:db8::1") == (true, nil)
+//	CIDRContains("10.0.0.0/33", "10.0.0.1") returns an error
+func CIDRContains(cidr, ip string) (bool, error) {
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return false, fmt.Errorf("invalid CIDR %q: %w", cidr, err)
+	}
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false, fmt.Errorf("invalid IP address %q: %w", ip, err)
+	}
+	return prefix.Contains(addr.Unmap()), nil
+}
+
+// ExpandCIDR returns every address in the network cidr, in ascending order,
+// including the network and broadcast addresses. Host bits set in cidr are ignored,
+// so "192.168.1.7/30" expands the same as "192.168.1.4/30".
+// It is meant for small ranges: it returns an error if the network holds more than
+// MaxExpandCIDR addresses, or if cidr cannot be parsed.
+//
+// Examples:
+//
+//	ExpandCIDR("192.168.1.4/30") == ([]string{"192.168.1.4", "192.168.1.5", "192.168.1.6", "192.168.1.7"}, nil)
+//	ExpandCIDR("10.0.0.1/32") == ([]string{"10.0.0.1"}, nil)
+//	ExpandCIDR("2001:db8::/127") == ([]string{"2001:db8::", "2001:db8::1"}, nil)
+//	ExpandCIDR("10.0.0.0/8") returns an error
+func ExpandCIDR(cidr string) ([]string, error) {
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CIDR %q: %w", cidr, err)
+	}
+	prefix = prefix.Masked()
+	hostBits := prefix.Addr().BitLen() - prefix.Bits()
+	if hostBits >= 63 || 1<<hostBits > MaxExpandCIDR {
+		return nil, errors.New("CIDR range is too large to expand")
+	}
+
+	result := make([]string, 0, 1<<hostBits)
+	for addr := prefix.Addr(); addr.IsValid() && prefix.Contains(addr); addr = addr.Next() {
+		result = append(result, addr.String())
+	}
+	return result, nil
+}
@@ -0,0 +1,100 @@
+package utils
+
+import (
+	"errors"
+	"strings"
+)
+
+// normalizeISBN removes the hyphens and spaces commonly used to group ISBN digits.
+func normalizeISBN(isbn string) string {
+	return strings.NewReplacer("-", "", " ", "").Replace(isbn)
+}
+
+// ValidateISBN13 checks if a string is a valid ISBN-13 number.
+// An ISBN-13 consists of 13 digits starting with the prefix 978 or 979; hyphens and spaces are ignored.
+// The check digit calculation is: (d1 + 3*d2 + d3 + 3*d4 + ... + d13) mod 10 == 0.
+// It returns an error if the string is not a valid ISBN-13.
+//
+// Examples:
+//
+//	ValidateISBN13("9780306406157") == nil
+//	ValidateISBN13("978-0-306-40615-7") == nil
+//	ValidateISBN13("9780306406158") returns an error (invalid check digit)
+//	ValidateISBN13("1234567890128") returns an error (invalid prefix)
+func ValidateISBN13(isbn string) error {
+	isbn = normalizeISBN(isbn)
+
+	if len(isbn) != 13 {
+		return errors.New("invalid ISBN-13 length")
+	}
+
+	sum := 0
+	for i := 0; i < 13; i++ {
+		c := isbn[i]
+		if c < '0' || c > '9' {
+			return errors.New("invalid character in ISBN-13")
+		}
+		digit := int(c - '0')
+		if i%2 == 1 {
+			digit *= 3
+		}
+		sum += digit
+	}
+
+	if !strings.HasPrefix(isbn, "978") && !strings.HasPrefix(isbn, "979") {
+		return errors.New("invalid ISBN-13 prefix: must be 978 or 979")
+	}
+	if sum%10 != 0 {
+		return errors.New("invalid ISBN-13 check digit")
+	}
+
+	return nil
+}
+
+// IsValidISBN reports whether s is a valid ISBN in either format: an ISBN-10 according to
+// ValidateISBN10 or an ISBN-13 according to ValidateISBN13. Hyphens and spaces are ignored.
+//
+// Examples:
+//
+//	IsValidISBN("0-306-40615-2") == true
+//	IsValidISBN("978 0 306 40615 7") == true
+//	IsValidISBN("0-306-40615-3") == false
+//	IsValidISBN("hello") == false
+func IsValidISBN(s string) bool {
+	s = normalizeISBN(s)
+	switch len(s) {
+	case 10:
+		return ValidateISBN10(s) == nil
+	case 13:
+		return ValidateISBN13(s) == nil
+	}
+	return false
+}
+
+// ISBN10To13 converts a valid ISBN-10 to its ISBN-13 form by adding the 978 prefix
+// and recomputing the check digit. Hyphens and spaces in the input are ignored, and
+// the result contains digits only.
+// It returns an error if isbn is not a valid ISBN-10.
+//
+// Examples:
+//
+//	ISBN10To13("0-306-40615-2") == ("9780306406157", nil)
+//	ISBN10To13("080442957X") == ("9780804429573", nil)
+//	ISBN10To13("0306406153") returns an error
+func ISBN10To13(isbn string) (string, error) {
+	isbn = normalizeISBN(isbn)
+	if err := ValidateISBN10(isbn); err != nil {
+		return "", err
+	}
+
+	body := "978" + isbn[:9]
+	sum := 0
+	for i := 0; i < 12; i++ {
+		digit := int(body[i] - '0')
+		if i%2 == 1 {
+			digit *= 3
+		}
+		sum += digit
+	}
+	return body + string(rune('0'+(10-sum%10)%10)), nil
+}
@@ -0,0 +1,50 @@
+package utils
+
+import "time"
+
+// ISOWeek returns the ISO 8601 year and week number of t, in t's location. Weeks start on
+// Monday and week 1 is the week containing the year's first Thursday, so the first days of
+// January may belong to the last week of the previous year, and the last days of December to
+// week 1 of the next.
+//
+// Examples:
+//
+//	ISOWeek(time.Date(2024, 5, 3, 0, 0, 0, 0, time.UTC)) == (2024, 18)
+//	ISOWeek(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)) == (2020, 53)
+//	ISOWeek(time.Date(2024, 12, 30, 0, 0, 0, 0, time.UTC)) == (2025, 1)
+func ISOWeek(t time.Time) (year, week int) {
+	return t.ISOWeek()
+}
+
+// FirstDayOfISOWeek returns midnight on the Monday that starts ISO week week of year, in loc.
+// Weeks outside the range of the year are normalized, so week 0 is the last week of the
+// previous year and week 54 of a 52-week year is week 2 of the next. If loc is nil, UTC is used.
+//
+// Examples:
+//
+//	FirstDayOfISOWeek(2024, 18, time.UTC) == time.Date(2024, 4, 29, 0, 0, 0, 0, time.UTC)
+//	FirstDayOfISOWeek(2020, 53, time.UTC) == time.Date(2020, 12, 28, 0, 0, 0, 0, time.UTC)
+//	FirstDayOfISOWeek(2025, 1, time.UTC) == time.Date(2024, 12, 30, 0, 0, 0, 0, time.UTC)
+func FirstDayOfISOWeek(year, week int, loc *time.Location) time.Time {
+	if loc == nil {
+		loc = time.UTC
+	}
+	// Week 1 is the week containing January 4.
+	jan4 := time.Date(year, time.January, 4, 12, 0, 0, 0, loc)
+	offset := (int(jan4.Weekday()) + 6) % 7 // days since Monday
+	return midnight(year, time.January, 4-offset+(week-1)*7, loc)
+}
+
+// WeeksInYear returns the number of ISO 8601 weeks in year, 52 or 53.
+// A year has 53 weeks when it starts on a Thursday, or is a leap year starting on a Wednesday.
+//
+// Examples:
+//
+//	WeeksInYear(2024) == 52
+//	WeeksInYear(2020) == 53
+//	WeeksInYear(2026) == 53
+func WeeksInYear(year int) int {
+	// December 28 is always in the year's last week.
+	_, week := time.Date(year, time.December, 28, 0, 0, 0, 0, time.UTC).ISOWeek()
+	return week
+}
@@ -0,0 +1,193 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// JSONType is the kind of value at the top level of a JSON document.
+type JSONType string
+
+// JSON value types returned by JSONTypeOf.
+const (
+	JSONInvalid JSONType = ""
+	JSONObject  JSONType = "object"
+	JSONArray   JSONType = "array"
+	JSONString  JSONType = "string"
+	JSONNumber  JSONType = "number"
+	JSONBoolean JSONType = "boolean"
+	JSONNull    JSONType = "null"
+)
+
+// IsValidJSON reports whether s is a well-formed JSON document.
+// Surrounding whitespace is allowed; trailing data after the value is not.
+//
+// Examples:
+//
+//	IsValidJSON(`{"id": 1, "tags": ["a", "b"]}`) == true
+//	IsValidJSON(`"just a string"`) == true
+//	IsValidJSON(`{"id": 1,}`) == false
+//	IsValidJSON("") == false
+func IsValidJSON(s string) bool {
+	return json.Valid([]byte(s))
+}
+
+// JSONTypeOf returns the type of the top-level value in the JSON document s,
+// without decoding it. It returns JSONInvalid if s is not well-formed JSON.
+//
+// Examples:
+//
+//	JSONTypeOf(`{"event": "push"}`) == JSONObject
+//	JSONTypeOf(` [1, 2, 3] `) == JSONArray
+//	JSONTypeOf(`-12.5e3`) == JSONNumber
+//	JSONTypeOf(`null`) == JSONNull
+//	JSONTypeOf(`{broken`) == JSONInvalid
+func JSONTypeOf(s string) JSONType {
+	data := []byte(s)
+	if !json.Valid(data) {
+		return JSONInvalid
+	}
+	data = bytes.TrimLeft(data, " \t\r\n")
+	switch data[0] {
+	case '{':
+		return JSONObject
+	case '[':
+		return JSONArray
+	case '"':
+		return JSONString
+	case 't', 'f':
+		return JSONBoolean
+	case 'n':
+		return JSONNull
+	}
+	return JSONNumber
+}
+
+// ValidateJSONKeys checks that s is a JSON object containing every key in required.
+// A key may be a dot-separated path such as "data.user.id" to require a key inside
+// nested objects. A key that is present with a null value counts as present.
+// Only the objects along each path are decoded, so the check stays cheap for large payloads.
+// It returns an error if s is not a JSON object, or one listing all missing keys.
+//
+// Examples:
+//
+//	ValidateJSONKeys(`{"id": 1, "data": {"user": "ana"}}`, []string{"id", "data.user"}) == nil
+//	ValidateJSONKeys(`{"id": 1}`, []string{"id", "event"}) returns an error mentioning "event"
+//	ValidateJSONKeys(`[1, 2]`, []string{"id"}) returns an error
+func ValidateJSONKeys(s string, required []string) error {
+	var root map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(s), &root); err != nil || root == nil {
+		return errors.New("JSON document must be an object")
+	}
+
+	// Decoded nested objects are cached by path, so shared prefixes are only decoded once.
+	objects := map[string]map[string]json.RawMessage{"": root}
+	var missing []string
+	for _, key := range required {
+		if !hasJSONPath(objects, key) {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		return errors.New("missing required JSON keys: " + strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// hasJSONPath reports whether the dot-separated path exists in the objects cache,
+// decoding and caching intermediate objects as needed.
+func hasJSONPath(objects map[string]map[string]json.RawMessage, path string) bool {
+	parts := strings.Split(path, ".")
+	prefix := ""
+	for i, part := range parts {
+		obj, ok := objects[prefix]
+		if !ok {
+			return false
+		}
+		raw, ok := obj[part]
+		if !ok {
+			return false
+		}
+		if i == len(parts)-1 {
+			return true
+		}
+		next := prefix + "." + part
+		if _, cached := objects[next]; !cached {
+			var child map[string]json.RawMessage
+			if json.Unmarshal(raw, &child) != nil || child == nil {
+				return false
+			}
+			objects[next] = child
+		}
+		prefix = next
+	}
+	return false
+}
+
+// PrettyJSON reformats the JSON document s with two-space indentation and one value per line,
+// keeping keys in their original order. Strings and numbers are copied exactly.
+// It returns an error if s is not well-formed JSON.
+//
+// Examples:
+//
+//	PrettyJSON(`{"a":1,"b":[true]}`) == ("{\n  \"a\": 1,\n  \"b\": [\n    true\n  ]\n}", nil)
+//	PrettyJSON(`{broken`) returns an error
+func PrettyJSON(s string) (string, error) {
+	var b bytes.Buffer
+	if err := json.Indent(&b, []byte(strings.TrimSpace(s)), "", "  "); err != nil {
+		return "", fmt.Errorf("invalid JSON: %w", err)
+	}
+	return b.String(), nil
+}
+
+// MinifyJSON removes all insignificant whitespace from the JSON document s,
+// keeping keys in their original order. Strings and numbers are copied exactly.
+// It returns an error if s is not well-formed JSON.
+//
+// Examples:
+//
+//	MinifyJSON("{\n  \"a\": 1,\n  \"b\": [ true ]\n}") == (`{"a":1,"b":[true]}`, nil)
+//	MinifyJSON(`[1,`) returns an error
+func MinifyJSON(s string) (string, error) {
+	var b bytes.Buffer
+	if err := json.Compact(&b, []byte(s)); err != nil {
+		return "", fmt.Errorf("invalid JSON: %w", err)
+	}
+	return b.String(), nil
+}
+
+// SortJSONKeys canonicalizes the JSON document s: object keys are sorted at every level and
+// whitespace is removed, so documents with the same content compare equal byte for byte.
+// Numbers keep their original text, and characters such as '<' and '&' are not escaped.
+// If an object repeats a key, the last value wins, as encoding/json decodes it.
+// Pass the result to PrettyJSON for a diff-friendly layout.
+// It returns an error if s is not well-formed JSON.
+//
+// Examples:
+//
+//	SortJSONKeys(`{"b": 1, "a": {"d": 2, "c": 3}}`) == (`{"a":{"c":3,"d":2},"b":1}`, nil)
+//	SortJSONKeys(`[{"z": 1.50, "y": "<x>"}]`) == (`[{"y":"<x>","z":1.50}]`, nil)
+//	SortJSONKeys(`{`) returns an error
+func SortJSONKeys(s string) (string, error) {
+	if !json.Valid([]byte(s)) {
+		return "", errors.New("invalid JSON")
+	}
+	dec := json.NewDecoder(strings.NewReader(s))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return "", fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	// encoding/json writes map keys in sorted order.
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(b.String(), "\n"), nil
+}
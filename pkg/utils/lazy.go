@@ -0,0 +1,67 @@
+package utils
+
+import (
+	"sync"
+	"time"
+)
+
+// Lazy returns a function that calls fn on first use and then returns its result without
+// calling it again, like sync.OnceValues, except that errors are not cached: a call that
+// fails is retried by the next call, so a transient failure does not stick. Concurrent calls
+// wait for the one in progress instead of calling fn again. If fn panics, the panic is
+// propagated and the next call retries.
+//
+// Examples:
+//
+//	loadConfig := Lazy(func() (*Config, error) { return readConfig("app.yaml") })
+//	cfg, err := loadConfig() // reads the file
+//	cfg, err = loadConfig()  // returns the cached config
+func Lazy[T any](fn func() (T, error)) func() (T, error) {
+	var (
+		mu    sync.Mutex
+		done  bool
+		value T
+	)
+	return func() (T, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if done {
+			return value, nil
+		}
+		v, err := fn()
+		if err != nil {
+			return v, err
+		}
+		value, done = v, true
+		return value, nil
+	}
+}
+
+// LazyWithTTL is like Lazy, but a successful result is only reused for ttl after fn
+// returned it; the first call after that calls fn again. A ttl of zero or less never reuses
+// a result. Until a refresh succeeds, callers get its error rather than the expired value.
+//
+// Examples:
+//
+//	token := LazyWithTTL(50*time.Minute, func() (string, error) { return fetchAccessToken(ctx) })
+//	tok, err := token() // fetches a new token at most every 50 minutes
+func LazyWithTTL[T any](ttl time.Duration, fn func() (T, error)) func() (T, error) {
+	var (
+		mu      sync.Mutex
+		expires time.Time
+		value   T
+	)
+	return func() (T, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if time.Now().Before(expires) {
+			return value, nil
+		}
+		v, err := fn()
+		if err != nil {
+			return v, err
+		}
+		value, expires = v, time.Now().Add(ttl)
+		return value, nil
+	}
+}
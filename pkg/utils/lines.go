@@ -0,0 +1,97 @@
+package utils
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"os"
+	"strings"
+)
+
+// errStopLines stops EachLine from Lines when the range loop ends early.
+var errStopLines = errors.New("stop reading lines")
+
+// ReadLines returns the lines of the file at path, without their "\n" or "\r\n" endings.
+// A final line without an ending is included. It loads the whole file into memory; use
+// EachLine or Lines for large files.
+//
+// Examples:
+//
+//	hosts, err := ReadLines("hosts.txt")
+func ReadLines(path string) ([]string, error) {
+	var lines []string
+	err := EachLine(path, func(line string) error {
+		lines = append(lines, line)
+		return nil
+	})
+	return lines, err
+}
+
+// EachLine calls fn for each line of the file at path, in order, reading the file as it goes
+// so that only one line is held in memory at a time. Lines have no length limit, and are
+// passed without their "\n" or "\r\n" endings. It stops at the first error fn returns and
+// returns it, or returns the error from opening or reading the file.
+//
+// Examples:
+//
+//	errorCount := 0
+//	err := EachLine("/var/log/app.log", func(line string) error {
+//		if strings.Contains(line, "ERROR") {
+//			errorCount++
+//		}
+//		return nil
+//	})
+func EachLine(path string, fn func(line string) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	r := bufio.NewReaderSize(f, 64*1024)
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+		if line != "" {
+			line = strings.TrimSuffix(line, "\n")
+			if err := fn(strings.TrimSuffix(line, "\r")); err != nil {
+				return err
+			}
+		}
+		if err != nil {
+			return nil
+		}
+	}
+}
+
+// Lines returns an iterator over the lines of the file at path, read as for EachLine, for use
+// with range-over-func, and a function returning the error that ended the iteration, if any,
+// to check after the loop. The iterator's type is that of iter.Seq[string], spelled out as
+// for DayRange. Each iteration opens and reads the file again.
+//
+// Examples:
+//
+//	lines, errf := Lines("/var/log/app.log")
+//	for line := range lines {
+//		process(line)
+//	}
+//	if err := errf(); err != nil {
+//		return err
+//	}
+func Lines(path string) (lines func(yield func(string) bool), errf func() error) {
+	var err error
+	lines = func(yield func(string) bool) {
+		err = EachLine(path, func(line string) error {
+			if !yield(line) {
+				return errStopLines
+			}
+			return nil
+		})
+		if errors.Is(err, errStopLines) {
+			err = nil
+		}
+	}
+	return lines, func() error { return err }
+}
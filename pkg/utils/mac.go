@@ -0,0 +1,111 @@
+package utils
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// MACStyle selects the notation NormalizeMAC writes a MAC address in.
+type MACStyle int
+
+// MAC address notations supported by NormalizeMAC.
+const (
+	// MACColon is the IEEE notation used by Linux and macOS: "00:1a:2b:3c:4d:5e".
+	MACColon MACStyle = iota
+	// MACHyphen is the notation used by Windows: "00-1A-2B-3C-4D-5E".
+	MACHyphen
+	// MACDot is the Cisco notation of three dot-separated groups: "001a.2b3c.4d5e".
+	MACDot
+	// MACBare is the twelve hex digits with no separators: "001a2b3c4d5e".
+	MACBare
+)
+
+// parseMAC decodes a 48-bit MAC address written in colon, hyphen, Cisco dot or bare notation.
+func parseMAC(s string) ([]byte, error) {
+	var hexDigits string
+	switch {
+	case len(s) == 17 && (s[2] == ':' || s[2] == '-'):
+		sep := s[2]
+		for i := 2; i < 17; i += 3 {
+			if s[i] != sep {
+				return nil, errors.New("invalid MAC address format: separators must be consistent")
+			}
+		}
+		hexDigits = strings.ReplaceAll(s, string(sep), "")
+	case len(s) == 14 && s[4] == '.' && s[9] == '.':
+		hexDigits = s[0:4] + s[5:9] + s[10:14]
+	case len(s) == 12:
+		hexDigits = s
+	default:
+		return nil, errors.New("invalid MAC address format: expected 00:1a:2b:3c:4d:5e, 00-1a-2b-3c-4d-5e, 001a.2b3c.4d5e or 001a2b3c4d5e")
+	}
+	mac, err := hex.DecodeString(hexDigits)
+	if err != nil || len(mac) != 6 {
+		return nil, errors.New("invalid MAC address format: contains non-hexadecimal characters")
+	}
+	return mac, nil
+}
+
+// ValidateMACAddress checks if a string is a 48-bit MAC address in colon ("00:1a:2b:3c:4d:5e"),
+// hyphen ("00-1A-2B-3C-4D-5E"), Cisco dot ("001a.2b3c.4d5e") or bare ("001a2b3c4d5e") notation.
+// Hex digits may be in either case, but separators may not be mixed.
+// It returns an error if the string is not a valid MAC address.
+//
+// Examples:
+//
+//	ValidateMACAddress("00:1A:2B:3C:4D:5E") == nil
+//	ValidateMACAddress("00-1A-2B-3C-4D-5E") == nil
+//	ValidateMACAddress("001a.2b3c.4d5e") == nil
+//	ValidateMACAddress("001A2B3C4D5E") == nil
+//	ValidateMACAddress("00:1A-2B:3C:4D:5E") returns an error // Mixed separators
+//	ValidateMACAddress("00:1A:2B:3C:4D:5G") returns an error // Invalid character 'G'
+//	ValidateMACAddress("00:1A:2B:3C:4D") returns an error // Incorrect length
+func ValidateMACAddress(s string) error {
+	_, err := parseMAC(s)
+	return err
+}
+
+// IsValidMAC reports whether s is a valid MAC address according to ValidateMACAddress.
+//
+// Examples:
+//
+//	IsValidMAC("00:1A:2B:3C:4D:5E") == true
+//	IsValidMAC("001a.2b3c.4d5e") == true
+//	IsValidMAC("00:1A-2B:3C:4D:5E") == false
+//	IsValidMAC("00:1A:2B:3C:4D") == false
+func IsValidMAC(s string) bool {
+	return ValidateMACAddress(s) == nil
+}
+
+// NormalizeMAC rewrites a MAC address accepted by ValidateMACAddress in the given style.
+// Hex digits are written in lower case, except in MACHyphen style, which uses upper case as Windows does.
+// It returns an error if s is not a valid MAC address or style is unknown.
+//
+// Examples:
+//
+//	NormalizeMAC("00-1A-2B-3C-4D-5E", MACColon) == ("00:1a:2b:3c:4d:5e", nil)
+//	NormalizeMAC("001a.2b3c.4d5e", MACHyphen) == ("00-1A-2B-3C-4D-5E", nil)
+//	NormalizeMAC("00:1a:2b:3c:4d:5e", MACDot) == ("001a.2b3c.4d5e", nil)
+//	NormalizeMAC("00:1A:2B:3C:4D:5E", MACBare) == ("001a2b3c4d5e", nil)
+//	NormalizeMAC("not-a-mac", MACColon) returns an error
+func NormalizeMAC(s string, style MACStyle) (string, error) {
+	mac, err := parseMAC(s)
+	if err != nil {
+		return "", err
+	}
+	h := hex.EncodeToString(mac)
+	switch style {
+	case MACColon:
+		return h[0:2] + ":" + h[2:4] + ":" + h[4:6] + ":" + h[6:8] + ":" + h[8:10] + ":" + h[10:12], nil
+	case MACHyphen:
+		h = strings.ToUpper(h)
+		return h[0:2] + "-" + h[2:4] + "-" + h[4:6] + "-" + h[6:8] + "-" + h[8:10] + "-" + h[10:12], nil
+	case MACDot:
+		return h[0:4] + "." + h[4:8] + "." + h[8:12], nil
+	case MACBare:
+		return h, nil
+	}
+	return "", fmt.Errorf("unknown MAC address style %d", style)
+}
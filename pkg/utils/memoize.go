@@ -0,0 +1,53 @@
+package utils
+
+import "time"
+
+// memoizeOptions holds the settings applied by MemoizeOption values.
+type memoizeOptions struct {
+	ttl        time.Duration
+	maxEntries int
+}
+
+// MemoizeOption configures Memoize.
+type MemoizeOption func(*memoizeOptions)
+
+// MemoizeTTL makes memoized results expire ttl after they were computed, so the next call
+// for the same argument computes them again. By default results never expire.
+func MemoizeTTL(ttl time.Duration) MemoizeOption {
+	return func(o *memoizeOptions) {
+		o.ttl = ttl
+	}
+}
+
+// MemoizeMaxEntries keeps at most n memoized results, evicting the one computed longest ago
+// to make room. By default there is no limit.
+func MemoizeMaxEntries(n int) MemoizeOption {
+	return func(o *memoizeOptions) {
+		o.maxEntries = n
+	}
+}
+
+// Memoize returns a function that calls fn once per argument and then returns the cached
+// result for that argument. Errors are not cached, so a failed call is retried by the next
+// one. The returned function is safe for concurrent use, and concurrent calls with the same
+// argument wait for the call in progress instead of calling fn again. Results are kept in a
+// TTLCache without background cleanup: expired results are dropped when next requested or
+// when the cache is full.
+//
+// Examples:
+//
+//	compile := Memoize(regexp.Compile)
+//	re, err := compile(`^\d+$`) // compiles the pattern
+//	re, err = compile(`^\d+$`)  // returns the cached *regexp.Regexp
+//
+//	geocode := Memoize(lookupCity, MemoizeTTL(24*time.Hour), MemoizeMaxEntries(10_000))
+func Memoize[K comparable, V any](fn func(K) (V, error), opts ...MemoizeOption) func(K) (V, error) {
+	var o memoizeOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	cache := NewTTLCache[K, V](o.ttl, CacheMaxSize[K, V](o.maxEntries), CacheCleanupInterval[K, V](0))
+	return func(key K) (V, error) {
+		return cache.GetOrSet(key, 0, func() (V, error) { return fn(key) })
+	}
+}
@@ -0,0 +1,82 @@
+package utils
+
+import (
+	"cmp"
+	"math"
+)
+
+// MinMax returns the smallest and largest elements of a slice in a single pass.
+// The ok result is false if the slice is empty.
+// For floating-point slices containing NaN, the result depends on where the NaN appears;
+// use Extent to skip NaN values.
+//
+// Examples:
+//
+//	MinMax([]int{3, 1, 4, 1, 5}) == (1, 5, true)
+//	MinMax([]string{"pear", "apple", "fig"}) == ("apple", "pear", true)
+//	MinMax([]int{}) == (0, 0, false)
+func MinMax[T cmp.Ordered](slice []T) (min, max T, ok bool) {
+	if len(slice) == 0 {
+		return min, max, false
+	}
+	min, max = slice[0], slice[0]
+	for _, v := range slice[1:] {
+		if v < min {
+			min = v
+		} else if v > max {
+			max = v
+		}
+	}
+	return min, max, true
+}
+
+// MinMaxBy returns the smallest and largest elements of a slice in a single pass,
+// ordering elements with the given comparison function, which should return a negative
+// number when a < b, zero when a == b, and a positive number when a > b (like cmp.Compare).
+// When several elements compare equal, the first of them is returned.
+// The ok result is false if the slice is empty.
+//
+// Examples:
+//
+//	MinMaxBy([]string{"ccc", "a", "bb"}, func(a, b string) int { return len(a) - len(b) }) == ("a", "ccc", true)
+//	MinMaxBy([]int{}, cmp.Compare[int]) == (0, 0, false)
+func MinMaxBy[T any](slice []T, compare func(a, b T) int) (min, max T, ok bool) {
+	if len(slice) == 0 {
+		return min, max, false
+	}
+	min, max = slice[0], slice[0]
+	for _, v := range slice[1:] {
+		if compare(v, min) < 0 {
+			min = v
+		} else if compare(v, max) > 0 {
+			max = v
+		}
+	}
+	return min, max, true
+}
+
+// Extent returns the smallest and largest non-NaN values of a floating-point slice in a single pass.
+// The ok result is false if the slice is empty or contains only NaN values.
+//
+// Examples:
+//
+//	Extent([]float64{2.5, math.NaN(), -1, 7}) == (-1, 7, true)
+//	Extent([]float64{math.NaN()}) == (0, 0, false)
+//	Extent([]float64{}) == (0, 0, false)
+func Extent[T Float](values []T) (min, max T, ok bool) {
+	for _, v := range values {
+		if math.IsNaN(float64(v)) {
+			continue
+		}
+		if !ok {
+			min, max, ok = v, v, true
+			continue
+		}
+		if v < min {
+			min = v
+		} else if v > max {
+			max = v
+		}
+	}
+	return min, max, ok
+}
@@ -0,0 +1,140 @@
+package utils
+
+import (
+	"errors"
+	"math"
+)
+
+// MovingAverage computes the simple moving average over a fixed window of the most recent values.
+// Each update runs in constant time regardless of the window size.
+// A MovingAverage is not safe for concurrent use.
+type MovingAverage struct {
+	window []float64
+	next   int
+	count  int
+	sum    float64
+}
+
+// NewMovingAverage returns a MovingAverage over the last size values.
+// It returns an error if size is not positive.
+//
+// Examples:
+//
+//	ma, _ := NewMovingAverage(3)
+//	ma.Add(1) == 1
+//	ma.Add(2) == 1.5
+//	ma.Add(3) == 2
+//	ma.Add(4) == 3 (1 has left the window)
+//	NewMovingAverage(0) returns an error
+func NewMovingAverage(size int) (*MovingAverage, error) {
+	if size <= 0 {
+		return nil, errors.New("window size must be positive")
+	}
+	return &MovingAverage{window: make([]float64, size)}, nil
+}
+
+// Add records a value, evicting the oldest one once the window is full,
+// and returns the updated average.
+func (m *MovingAverage) Add(v float64) float64 {
+	if m.count == len(m.window) {
+		m.sum -= m.window[m.next]
+	} else {
+		m.count++
+	}
+	m.window[m.next] = v
+	m.sum += v
+	m.next = (m.next + 1) % len(m.window)
+	return m.Value()
+}
+
+// Value returns the average of the values currently in the window, or 0 if no values have been added.
+func (m *MovingAverage) Value() float64 {
+	if m.count == 0 {
+		return 0
+	}
+	return m.sum / float64(m.count)
+}
+
+// Len returns the number of values currently in the window.
+func (m *MovingAverage) Len() int {
+	return m.count
+}
+
+// Full reports whether the window holds its maximum number of values.
+func (m *MovingAverage) Full() bool {
+	return m.count == len(m.window)
+}
+
+// Reset discards all values while keeping the window size.
+func (m *MovingAverage) Reset() {
+	clear(m.window)
+	m.next, m.count, m.sum = 0, 0, 0
+}
+
+// EMA computes an exponential moving average, where each new value v updates the average as
+// alpha*v + (1-alpha)*average. Higher alpha values react faster to recent changes.
+// The first value added seeds the average.
+// An EMA is not safe for concurrent use.
+type EMA struct {
+	alpha  float64
+	value  float64
+	seeded bool
+}
+
+// NewEMA returns an EMA with the given smoothing factor.
+// It returns an error if alpha is not in the range (0, 1].
+//
+// Examples:
+//
+//	ema, _ := NewEMA(0.5)
+//	ema.Add(10) == 10
+//	ema.Add(20) == 15
+//	ema.Add(20) == 17.5
+//	NewEMA(0) returns an error
+func NewEMA(alpha float64) (*EMA, error) {
+	if math.IsNaN(alpha) || alpha <= 0 || alpha > 1 {
+		return nil, errors.New("alpha must be in the range (0, 1]")
+	}
+	return &EMA{alpha: alpha}, nil
+}
+
+// NewEMAWithPeriod returns an EMA whose smoothing factor is derived from a period of n samples,
+// using the conventional alpha = 2 / (n + 1).
+// It returns an error if n is not positive.
+//
+// Examples:
+//
+//	NewEMAWithPeriod(9) returns an EMA with alpha 0.2
+//	NewEMAWithPeriod(0) returns an error
+func NewEMAWithPeriod(n int) (*EMA, error) {
+	if n <= 0 {
+		return nil, errors.New("period must be positive")
+	}
+	return NewEMA(2 / float64(n+1))
+}
+
+// Add records a value and returns the updated average.
+func (e *EMA) Add(v float64) float64 {
+	if !e.seeded {
+		e.value = v
+		e.seeded = true
+	} else {
+		e.value = e.alpha*v + (1-e.alpha)*e.value
+	}
+	return e.value
+}
+
+// Value returns the current average, or 0 if no values have been added.
+func (e *EMA) Value() float64 {
+	return e.value
+}
+
+// Alpha returns the smoothing factor.
+func (e *EMA) Alpha() float64 {
+	return e.alpha
+}
+
+// Reset discards the current average so that the next value seeds it again.
+func (e *EMA) Reset() {
+	e.value, e.seeded = 0, false
+}
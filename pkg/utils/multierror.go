@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MultiError collects several errors, to report all the failures of an operation instead of
+// only the first. errors.Is and errors.As look through each of them. The zero value is an
+// empty collector ready to use; it is not safe for concurrent use.
+type MultiError struct {
+	Errors []error
+}
+
+// Append adds the non-nil errors of errs. The errors of an appended *MultiError are added
+// one by one rather than nested.
+//
+// Examples:
+//
+//	var merr MultiError
+//	for _, f := range files {
+//		merr.Append(f.Close())
+//	}
+//	return merr.ErrorOrNil()
+func (m *MultiError) Append(errs ...error) {
+	for _, err := range errs {
+		switch e := err.(type) {
+		case nil:
+		case *MultiError:
+			if e != nil {
+				m.Errors = append(m.Errors, e.Errors...)
+			}
+		default:
+			m.Errors = append(m.Errors, err)
+		}
+	}
+}
+
+// Len returns the number of errors collected.
+func (m *MultiError) Len() int {
+	if m == nil {
+		return 0
+	}
+	return len(m.Errors)
+}
+
+// ErrorOrNil returns m as an error, or nil if it holds no errors. Return it rather than m
+// itself, since a nil *MultiError stored in an error is not a nil error.
+func (m *MultiError) ErrorOrNil() error {
+	if m.Len() == 0 {
+		return nil
+	}
+	return m
+}
+
+// Error returns the message of the only error, or the number of errors followed by each
+// message, as in "3 errors: name is required; age must be positive; email is invalid".
+func (m *MultiError) Error() string {
+	switch m.Len() {
+	case 0:
+		return "no errors"
+	case 1:
+		return m.Errors[0].Error()
+	}
+	parts := make([]string, len(m.Errors))
+	for i, err := range m.Errors {
+		parts[i] = err.Error()
+	}
+	return fmt.Sprintf("%d errors: %s", len(m.Errors), strings.Join(parts, "; "))
+}
+
+// Unwrap returns the errors collected, for errors.Is and errors.As.
+func (m *MultiError) Unwrap() []error {
+	if m == nil {
+		return nil
+	}
+	return m.Errors
+}
@@ -0,0 +1,35 @@
+package utils
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Must returns v, and panics if err is not nil. It is meant for package-level initialization
+// from values known to be valid, where an error is a bug. The panic value is an error wrapping
+// err, naming the type of v.
+//
+// Examples:
+//
+//	var slugPattern = Must(regexp.Compile(`^[a-z0-9-]+$`))
+//	var pageTemplate = Must(template.ParseFS(templates, "page.html"))
+func Must[T any](v T, err error) T {
+	if err != nil {
+		panic(fmt.Errorf("utils: Must[%v]: %w", reflect.TypeFor[T](), err))
+	}
+	return v
+}
+
+// MustOk returns v, and panics if ok is false, for functions that return a value and an ok
+// flag, such as os.LookupEnv.
+//
+// Examples:
+//
+//	home := MustOk(os.LookupEnv("HOME"))
+//	codec := MustOk(codecs.Lookup("json"))
+func MustOk[T any](v T, ok bool) T {
+	if !ok {
+		panic(fmt.Sprintf("utils: MustOk[%v]: ok is false", reflect.TypeFor[T]()))
+	}
+	return v
+}
@@ -0,0 +1,139 @@
+package utils
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// normalizeLocaleNumber rewrites a number written with the given separators in the form
+// strconv understands: an optional "-", digits, and an optional "." followed by digits.
+func normalizeLocaleNumber(s string, decimalSep, thousandsSep rune) (string, error) {
+	if decimalSep == thousandsSep {
+		return "", errors.New("decimal and thousands separators must differ")
+	}
+	if unicode.IsDigit(decimalSep) || decimalSep == '-' || decimalSep == '+' ||
+		unicode.IsDigit(thousandsSep) || thousandsSep == '-' || thousandsSep == '+' {
+		return "", errors.New("separators cannot be digits or signs")
+	}
+
+	s = strings.TrimSpace(s)
+	var b strings.Builder
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		if s[0] == '-' {
+			b.WriteByte('-')
+		}
+		s = s[1:]
+	}
+
+	intPart, fracPart, hasFrac := strings.Cut(s, string(decimalSep))
+	if intPart == "" && fracPart == "" {
+		return "", errors.New("number has no digits")
+	}
+
+	// The integer part is either plain digits or groups of three after a leading group of one to three.
+	groups := []string{intPart}
+	if thousandsSep != 0 {
+		groups = splitThousands(intPart, thousandsSep)
+	}
+	for i, group := range groups {
+		if group == "" && len(groups) == 1 && hasFrac {
+			break // A bare fraction such as ",5".
+		}
+		if !isASCIIDigits(group) {
+			return "", errors.New("invalid character in number")
+		}
+		if len(groups) > 1 && (i == 0 && len(group) > 3 || i > 0 && len(group) != 3) {
+			return "", errors.New("thousands separators must group the integer part in threes")
+		}
+		b.WriteString(group)
+	}
+
+	if hasFrac {
+		if fracPart == "" || !isASCIIDigits(fracPart) {
+			return "", errors.New("decimal separator must be followed by digits")
+		}
+		if b.Len() == 0 || b.String() == "-" {
+			b.WriteByte('0')
+		}
+		b.WriteByte('.')
+		b.WriteString(fracPart)
+	}
+	return b.String(), nil
+}
+
+// isThousandsSep reports whether r acts as the thousands separator sep.
+// A space separator also matches the no-break spaces that many locales use for grouping.
+func isThousandsSep(r, sep rune) bool {
+	return r == sep || sep == ' ' && (r == '\u00a0' || r == '\u202f')
+}
+
+// splitThousands splits s around each thousands separator sep, keeping empty groups.
+func splitThousands(s string, sep rune) []string {
+	var groups []string
+	start := 0
+	for i, r := range s {
+		if isThousandsSep(r, sep) {
+			groups = append(groups, s[start:i])
+			start = i + len(string(r))
+		}
+	}
+	return append(groups, s[start:])
+}
+
+// isASCIIDigits reports whether s is a non-empty string of ASCII digits.
+func isASCIIDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// IsNumericLocale reports whether s is a decimal number written with the given separators,
+// such as "1.234,56" with decimalSep ',' and thousandsSep '.'.
+// The number may have a leading sign and surrounding whitespace. Thousands separators are
+// optional, but where used they must split the integer part into groups of three digits.
+// Pass 0 as thousandsSep to disallow grouping. When thousandsSep is ' ', no-break spaces
+// are accepted as well.
+//
+// Examples:
+//
+//	IsNumericLocale("1.234,56", ',', '.') == true
+//	IsNumericLocale("1234,56", ',', '.') == true
+//	IsNumericLocale("-1,234.5", '.', ',') == true
+//	IsNumericLocale("1 234 567,8", ',', ' ') == true
+//	IsNumericLocale("12.34,56", ',', '.') == false
+//	IsNumericLocale("1.234.56", ',', '.') == false
+//	IsNumericLocale("abc", '.', ',') == false
+func IsNumericLocale(s string, decimalSep, thousandsSep rune) bool {
+	_, err := normalizeLocaleNumber(s, decimalSep, thousandsSep)
+	return err == nil
+}
+
+// ParseFloatLocale parses a number written with the given separators, accepting the same
+// forms as IsNumericLocale, and returns the nearest float64.
+// It returns an error if s is not a valid number for those separators or is out of range.
+//
+// Examples:
+//
+//	ParseFloatLocale("1.234,56", ',', '.') == (1234.56, nil)
+//	ParseFloatLocale("1,234.56", '.', ',') == (1234.56, nil)
+//	ParseFloatLocale("-0,5", ',', '.') == (-0.5, nil)
+//	ParseFloatLocale("1.234,56", '.', ',') returns an error
+func ParseFloatLocale(s string, decimalSep, thousandsSep rune) (float64, error) {
+	normalized, err := normalizeLocaleNumber(s, decimalSep, thousandsSep)
+	if err != nil {
+		return 0, err
+	}
+	f, err := strconv.ParseFloat(normalized, 64)
+	if err != nil {
+		return 0, errors.New("number is out of range")
+	}
+	return f, nil
+}
@@ -0,0 +1,143 @@
+package utils
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// Option is a value that may be absent. Create one with SomeOf or NoneOf (the names Some and
+// None are taken by the slice predicates); the zero value is None.
+//
+// As a JSON field, an Option encodes None as null, and decoding tells the three cases apart:
+// a value gives Some, null gives None with IsNull true, and a missing field leaves the zero
+// Option, with IsNull false. That distinguishes "clear this field" from "leave it alone" in
+// PATCH requests. As a database column, it scans NULL as None, like sql.Null.
+type Option[T any] struct {
+	value T
+	some  bool
+	null  bool // decoded from an explicit JSON null or SQL NULL
+}
+
+// SomeOf returns an Option holding value.
+func SomeOf[T any](value T) Option[T] {
+	return Option[T]{value: value, some: true}
+}
+
+// NoneOf returns an Option holding no value.
+func NoneOf[T any]() Option[T] {
+	return Option[T]{}
+}
+
+// OptionFromPtr returns an Option holding *p, or None if p is nil.
+func OptionFromPtr[T any](p *T) Option[T] {
+	if p == nil {
+		return NoneOf[T]()
+	}
+	return SomeOf(*p)
+}
+
+// Get returns the value o holds, or false if it holds none.
+//
+// Examples:
+//
+//	if name, ok := req.Name.Get(); ok {
+//		user.Name = name
+//	}
+func (o Option[T]) Get() (T, bool) {
+	return o.value, o.some
+}
+
+// IsSome reports whether o holds a value.
+func (o Option[T]) IsSome() bool {
+	return o.some
+}
+
+// IsNull reports whether o was decoded from a JSON null or scanned from an SQL NULL, rather
+// than left unset.
+func (o Option[T]) IsNull() bool {
+	return !o.some && o.null
+}
+
+// OrElse returns the value o holds, or def if it holds none.
+func (o Option[T]) OrElse(def T) T {
+	if !o.some {
+		return def
+	}
+	return o.value
+}
+
+// Ptr returns a pointer to a copy of the value o holds, or nil if it holds none.
+func (o Option[T]) Ptr() *T {
+	if !o.some {
+		return nil
+	}
+	v := o.value
+	return &v
+}
+
+// String returns "Some(value)" or "None".
+func (o Option[T]) String() string {
+	if !o.some {
+		return "None"
+	}
+	return fmt.Sprintf("Some(%v)", o.value)
+}
+
+// MapOption returns an Option holding fn applied to the value of o, or None without calling
+// fn. It is a function rather than a method because methods cannot have type parameters.
+//
+// Examples:
+//
+//	MapOption(SomeOf("go"), strings.ToUpper) == SomeOf("GO")
+func MapOption[T, U any](o Option[T], fn func(T) U) Option[U] {
+	if !o.some {
+		return Option[U]{null: o.null}
+	}
+	return SomeOf(fn(o.value))
+}
+
+// MarshalJSON encodes the value o holds, or null if it holds none.
+func (o Option[T]) MarshalJSON() ([]byte, error) {
+	if !o.some {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.value)
+}
+
+// UnmarshalJSON decodes o from a value, giving Some, or from null, giving None with IsNull
+// true.
+func (o *Option[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*o = Option[T]{null: true}
+		return nil
+	}
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*o = SomeOf(v)
+	return nil
+}
+
+// Scan implements sql.Scanner, scanning NULL as None with IsNull true and converting other
+// values as sql.Null does.
+func (o *Option[T]) Scan(src any) error {
+	var n sql.Null[T]
+	if err := n.Scan(src); err != nil {
+		return err
+	}
+	if !n.Valid {
+		*o = Option[T]{null: true}
+		return nil
+	}
+	*o = SomeOf(n.V)
+	return nil
+}
+
+// Value implements driver.Valuer, giving NULL for None.
+func (o Option[T]) Value() (driver.Value, error) {
+	return sql.Null[T]{V: o.value, Valid: o.some}.Value()
+}
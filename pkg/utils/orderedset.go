@@ -0,0 +1,120 @@
+package utils
+
+import (
+	"cmp"
+	"slices"
+)
+
+// OrderedSet is a set of distinct values kept in ascending order, backed by a sorted slice:
+// lookups and range queries take logarithmic time, and Add and Delete linear time, which
+// suits sets that are read more than they are changed. The zero value is an empty set ready
+// to use. It is not safe for concurrent writes.
+type OrderedSet[T cmp.Ordered] struct {
+	items []T
+}
+
+// NewOrderedSet returns a set holding items.
+//
+// Examples:
+//
+//	s := NewOrderedSet(5, 1, 3, 1)
+//	s.ToSlice() == []int{1, 3, 5}
+func NewOrderedSet[T cmp.Ordered](items ...T) *OrderedSet[T] {
+	sorted := slices.Clone(items)
+	slices.Sort(sorted)
+	return &OrderedSet[T]{items: slices.Compact(sorted)}
+}
+
+// Add adds item to s, and reports false if s already held it.
+func (s *OrderedSet[T]) Add(item T) bool {
+	i, found := slices.BinarySearch(s.items, item)
+	if found {
+		return false
+	}
+	s.items = slices.Insert(s.items, i, item)
+	return true
+}
+
+// Has reports whether s holds item.
+func (s *OrderedSet[T]) Has(item T) bool {
+	_, found := slices.BinarySearch(s.items, item)
+	return found
+}
+
+// Delete removes item from s, and reports false if s did not hold it.
+func (s *OrderedSet[T]) Delete(item T) bool {
+	i, found := slices.BinarySearch(s.items, item)
+	if !found {
+		return false
+	}
+	s.items = slices.Delete(s.items, i, i+1)
+	return true
+}
+
+// Len returns the number of elements in s.
+func (s *OrderedSet[T]) Len() int {
+	return len(s.items)
+}
+
+// Min returns the smallest element of s, or false if s is empty.
+func (s *OrderedSet[T]) Min() (T, bool) {
+	if len(s.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return s.items[0], true
+}
+
+// Max returns the largest element of s, or false if s is empty.
+func (s *OrderedSet[T]) Max() (T, bool) {
+	if len(s.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return s.items[len(s.items)-1], true
+}
+
+// Between returns the elements of s from lo to hi, both inclusive, in ascending order, in a
+// new slice. It returns nil if lo > hi.
+//
+// Examples:
+//
+//	NewOrderedSet(1, 3, 5, 7).Between(2, 5) == []int{3, 5}
+func (s *OrderedSet[T]) Between(lo, hi T) []T {
+	if cmp.Less(hi, lo) {
+		return nil
+	}
+	start, _ := slices.BinarySearch(s.items, lo)
+	end, found := slices.BinarySearch(s.items, hi)
+	if found {
+		end++
+	}
+	if start >= end {
+		return nil
+	}
+	return slices.Clone(s.items[start:end])
+}
+
+// All returns an iterator over the elements of s in ascending order, for use with
+// range-over-func. Its type is that of iter.Seq[T], as for DayRange. s must not be changed
+// during the iteration.
+//
+// Examples:
+//
+//	for id := range ids.All() {
+//		fmt.Println(id)
+//	}
+func (s *OrderedSet[T]) All() func(yield func(T) bool) {
+	return func(yield func(T) bool) {
+		for _, item := range s.items {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// ToSlice returns the elements of s in ascending order, in a new slice.
+func (s *OrderedSet[T]) ToSlice() []T {
+	return slices.Clone(s.items)
+}
@@ -0,0 +1,124 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// OrdinalFunc formats n as an ordinal number in some language, such as "1st" or "1er".
+type OrdinalFunc func(n int) string
+
+// ordinalFuncs holds the ordinal formatters used by OrdinalIn, keyed by lower-case language code.
+var ordinalFuncs = map[string]OrdinalFunc{
+	"en": Ordinal,
+	// Portuguese, Spanish and Italian use the masculine ordinal indicator.
+	"pt": ordinalWithSuffix("º"),
+	"es": ordinalWithSuffix("º"),
+	"it": ordinalWithSuffix("º"),
+	"de": ordinalWithSuffix("."),
+	"fr": func(n int) string {
+		if n == 1 {
+			return "1er"
+		}
+		return strconv.Itoa(n) + "e"
+	},
+}
+
+// ordinalMu guards ordinalFuncs against concurrent registration.
+var ordinalMu sync.RWMutex
+
+// ordinalWithSuffix returns an OrdinalFunc that appends suffix to every number.
+func ordinalWithSuffix(suffix string) OrdinalFunc {
+	return func(n int) string {
+		return strconv.Itoa(n) + suffix
+	}
+}
+
+// Ordinal returns n followed by its English ordinal suffix: "st", "nd", "rd" or "th".
+// Numbers ending in 11, 12 and 13 take "th". Negative numbers keep their sign.
+//
+// Examples:
+//
+//	Ordinal(1) == "1st"
+//	Ordinal(2) == "2nd"
+//	Ordinal(3) == "3rd"
+//	Ordinal(11) == "11th"
+//	Ordinal(112) == "112th"
+//	Ordinal(23) == "23rd"
+//	Ordinal(0) == "0th"
+func Ordinal(n int) string {
+	last2 := n % 100
+	if last2 < 0 {
+		last2 = -last2
+	}
+	suffix := "th"
+	if last2 < 11 || last2 > 13 {
+		switch last2 % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+	}
+	return strconv.Itoa(n) + suffix
+}
+
+// RegisterOrdinal sets the formatter that OrdinalIn uses for lang, adding a language or replacing
+// a built-in one. Language codes are matched case-insensitively, with "_" and "-" treated alike.
+// It is safe to call concurrently with OrdinalIn.
+// It returns an error if lang is empty or fn is nil.
+//
+// Examples:
+//
+//	RegisterOrdinal("nl", func(n int) string { return strconv.Itoa(n) + "e" }) == nil
+//	RegisterOrdinal("", Ordinal) returns an error
+func RegisterOrdinal(lang string, fn OrdinalFunc) error {
+	if lang == "" {
+		return errors.New("language code cannot be empty")
+	}
+	if fn == nil {
+		return errors.New("ordinal function cannot be nil")
+	}
+
+	ordinalMu.Lock()
+	defer ordinalMu.Unlock()
+	ordinalFuncs[strings.ReplaceAll(strings.ToLower(lang), "_", "-")] = fn
+	return nil
+}
+
+// OrdinalIn formats n as an ordinal number in the language lang, a code such as "en", "fr"
+// or "pt-BR" ("pt_BR" works too). If no formatter is registered for a regional code,
+// the base language is used.
+// Formatters are built in for English ("1st"), Portuguese, Spanish and Italian ("1º"),
+// French ("1er", "2e") and German ("1."); use RegisterOrdinal to add more.
+// It returns an error if no formatter is registered for lang.
+//
+// Examples:
+//
+//	OrdinalIn(2, "en") == ("2nd", nil)
+//	OrdinalIn(1, "fr") == ("1er", nil)
+//	OrdinalIn(3, "pt-BR") == ("3º", nil)
+//	OrdinalIn(4, "de") == ("4.", nil)
+//	OrdinalIn(1, "xx") returns an error
+func OrdinalIn(n int, lang string) (string, error) {
+	code := strings.ReplaceAll(strings.ToLower(lang), "_", "-")
+
+	ordinalMu.RLock()
+	fn, ok := ordinalFuncs[code]
+	if !ok {
+		if base, _, found := strings.Cut(code, "-"); found {
+			fn, ok = ordinalFuncs[base]
+		}
+	}
+	ordinalMu.RUnlock()
+
+	if !ok {
+		return "", fmt.Errorf("no ordinal format registered for language %q", lang)
+	}
+	return fn(n), nil
+}
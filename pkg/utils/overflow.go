@@ -0,0 +1,184 @@
+package utils
+
+import (
+	"errors"
+	"math"
+)
+
+// ErrOverflow is returned when an integer operation or conversion would overflow its type.
+var ErrOverflow = errors.New("integer overflow")
+
+// isSigned reports whether the integer type T is signed.
+func isSigned[T Integer]() bool {
+	var zero T
+	return zero-1 < zero
+}
+
+// SafeAdd returns a + b, or ErrOverflow if the result does not fit in T.
+// It works with any integer type, such as int, int64, and uint64.
+//
+// Examples:
+//
+//	SafeAdd(1, 2) == (3, nil)
+//	SafeAdd(math.MaxInt64, int64(1)) returns (0, ErrOverflow)
+//	SafeAdd(uint64(math.MaxUint64), 1) returns (0, ErrOverflow)
+func SafeAdd[T Integer](a, b T) (T, error) {
+	c := a + b
+	if isSigned[T]() {
+		if (b > 0 && c < a) || (b < 0 && c > a) {
+			return 0, ErrOverflow
+		}
+	} else if c < a {
+		return 0, ErrOverflow
+	}
+	return c, nil
+}
+
+// SafeSub returns a - b, or ErrOverflow if the result does not fit in T.
+// For unsigned types, a result below zero is reported as an overflow.
+//
+// Examples:
+//
+//	SafeSub(5, 3) == (2, nil)
+//	SafeSub(math.MinInt64, int64(1)) returns (0, ErrOverflow)
+//	SafeSub(uint64(1), 2) returns (0, ErrOverflow)
+func SafeSub[T Integer](a, b T) (T, error) {
+	c := a - b
+	if isSigned[T]() {
+		if (b > 0 && c > a) || (b < 0 && c < a) {
+			return 0, ErrOverflow
+		}
+	} else if b > a {
+		return 0, ErrOverflow
+	}
+	return c, nil
+}
+
+// SafeMul returns a * b, or ErrOverflow if the result does not fit in T.
+//
+// Examples:
+//
+//	SafeMul(6, 7) == (42, nil)
+//	SafeMul(math.MaxInt64, int64(2)) returns (0, ErrOverflow)
+//	SafeMul(math.MinInt64, int64(-1)) returns (0, ErrOverflow)
+func SafeMul[T Integer](a, b T) (T, error) {
+	if a == 0 || b == 0 {
+		return 0, nil
+	}
+	c := a * b
+	if c/b != a {
+		return 0, ErrOverflow
+	}
+	// MinInt * -1 wraps back to MinInt and survives the division check above.
+	if isSigned[T]() && (a < 0) == (b < 0) && c < 0 {
+		return 0, ErrOverflow
+	}
+	return c, nil
+}
+
+// SafeConvert converts an integer of type From to type To, returning ErrOverflow
+// if the value cannot be represented exactly in the target type.
+//
+// Examples:
+//
+//	SafeConvert[int32](int64(42)) == (42, nil)
+//	SafeConvert[int8](300) returns (0, ErrOverflow)
+//	SafeConvert[uint](-1) returns (0, ErrOverflow)
+func SafeConvert[To, From Integer](v From) (To, error) {
+	c := To(v)
+	if From(c) != v || (v < 0) != (c < 0) {
+		return 0, ErrOverflow
+	}
+	return c, nil
+}
+
+// SafeIntToInt32 converts an int to an int32, returning ErrOverflow if it is out of range.
+//
+// Examples:
+//
+//	SafeIntToInt32(100) == (100, nil)
+//	SafeIntToInt32(math.MaxInt32 + 1) returns (0, ErrOverflow)
+func SafeIntToInt32(v int) (int32, error) {
+	if v < math.MinInt32 || v > math.MaxInt32 {
+		return 0, ErrOverflow
+	}
+	return int32(v), nil
+}
+
+// SafeIntToInt16 converts an int to an int16, returning ErrOverflow if it is out of range.
+//
+// Examples:
+//
+//	SafeIntToInt16(100) == (100, nil)
+//	SafeIntToInt16(40000) returns (0, ErrOverflow)
+func SafeIntToInt16(v int) (int16, error) {
+	if v < math.MinInt16 || v > math.MaxInt16 {
+		return 0, ErrOverflow
+	}
+	return int16(v), nil
+}
+
+// SafeIntToInt8 converts an int to an int8, returning ErrOverflow if it is out of range.
+//
+// Examples:
+//
+//	SafeIntToInt8(100) == (100, nil)
+//	SafeIntToInt8(200) returns (0, ErrOverflow)
+func SafeIntToInt8(v int) (int8, error) {
+	if v < math.MinInt8 || v > math.MaxInt8 {
+		return 0, ErrOverflow
+	}
+	return int8(v), nil
+}
+
+// SafeIntToUint converts an int to a uint, returning ErrOverflow if it is negative.
+//
+// Examples:
+//
+//	SafeIntToUint(5) == (5, nil)
+//	SafeIntToUint(-1) returns (0, ErrOverflow)
+func SafeIntToUint(v int) (uint, error) {
+	if v < 0 {
+		return 0, ErrOverflow
+	}
+	return uint(v), nil
+}
+
+// SafeInt64ToInt32 converts an int64 to an int32, returning ErrOverflow if it is out of range.
+//
+// Examples:
+//
+//	SafeInt64ToInt32(100) == (100, nil)
+//	SafeInt64ToInt32(math.MinInt32 - 1) returns (0, ErrOverflow)
+func SafeInt64ToInt32(v int64) (int32, error) {
+	if v < math.MinInt32 || v > math.MaxInt32 {
+		return 0, ErrOverflow
+	}
+	return int32(v), nil
+}
+
+// SafeInt64ToUint64 converts an int64 to a uint64, returning ErrOverflow if it is negative.
+//
+// Examples:
+//
+//	SafeInt64ToUint64(5) == (5, nil)
+//	SafeInt64ToUint64(-5) returns (0, ErrOverflow)
+func SafeInt64ToUint64(v int64) (uint64, error) {
+	if v < 0 {
+		return 0, ErrOverflow
+	}
+	return uint64(v), nil
+}
+
+// SafeUint64ToInt64 converts a uint64 to an int64, returning ErrOverflow if it exceeds math.MaxInt64.
+//
+// Examples:
+//
+//	SafeUint64ToInt64(5) == (5, nil)
+//	SafeUint64ToInt64(math.MaxUint64) returns (0, ErrOverflow)
+func SafeUint64ToInt64(v uint64) (int64, error) {
+	if v > math.MaxInt64 {
+		return 0, ErrOverflow
+	}
+	return int64(v), nil
+}
@@ -0,0 +1,99 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+)
+
+// parallelOptions holds the settings applied by ParallelOption values.
+type parallelOptions struct {
+	failFast bool
+}
+
+// ParallelOption configures the behavior of ParallelForEach.
+type ParallelOption func(*parallelOptions)
+
+// WithFailFast makes ParallelForEach stop at the first error: the context passed to the
+// calls still running is cancelled, no further items are started, and only that error is
+// returned.
+func WithFailFast() ParallelOption {
+	return func(o *parallelOptions) {
+		o.failFast = true
+	}
+}
+
+// ParallelForEach calls fn for each item in items with at most workers calls running at
+// once, or runtime.GOMAXPROCS(0) if workers is less than 1, and waits for them to finish.
+// By default every item is processed and the returned error joins the error of each failed
+// call, prefixed with the item's index, in index order; pass WithFailFast to stop at the
+// first error instead. If ctx is done, no further items are started and ctx's error is
+// included. A panic in fn is recovered and reported as that item's error, a *PanicError.
+// It returns nil if every call succeeded.
+//
+// Examples:
+//
+//	err := ParallelForEach(ctx, paths, 8, func(ctx context.Context, path string) error {
+//		return upload(ctx, path)
+//	})
+//	err := ParallelForEach(ctx, ids, 4, deleteRecord, WithFailFast())
+func ParallelForEach[T any](ctx context.Context, items []T, workers int, fn func(ctx context.Context, item T) error, opts ...ParallelOption) error {
+	var o parallelOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	workers = min(workers, len(items))
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	errs := make([]error, len(items))
+	var firstErr error
+	var firstOnce sync.Once
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for runCtx.Err() == nil {
+				i := int(next.Add(1) - 1)
+				if i >= len(items) {
+					return
+				}
+				if err := callParallel(runCtx, items[i], fn); err != nil {
+					errs[i] = fmt.Errorf("item %d: %w", i, err)
+					if o.failFast {
+						firstOnce.Do(func() { firstErr = errs[i] })
+						cancel()
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	if int(next.Load()) < len(items) {
+		errs = append(errs, ctx.Err())
+	}
+	return errors.Join(errs...)
+}
+
+// callParallel calls fn on item, turning a panic into an error.
+func callParallel[T any](ctx context.Context, item T, fn func(context.Context, T) error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &PanicError{Value: r, Stack: debug.Stack()}
+		}
+	}()
+	return fn(ctx, item)
+}
@@ -0,0 +1,65 @@
+package utils
+
+// Partial1 returns fn with its first argument bound to a, taking only the second.
+//
+// Examples:
+//
+//	inRange := Partial1(func(max, n int) bool { return n <= max }, 10)
+//	Filter([]int{5, 15}, inRange) == []int{5}
+func Partial1[A, B, R any](fn func(A, B) R, a A) func(B) R {
+	return func(b B) R {
+		return fn(a, b)
+	}
+}
+
+// Partial2 returns fn with its first two arguments bound to a and b, taking only the third.
+//
+// Examples:
+//
+//	dashes := Partial2(strings.ReplaceAll, "a b c", " ")
+//	dashes("-") == "a-b-c"
+func Partial2[A, B, C, R any](fn func(A, B, C) R, a A, b B) func(C) R {
+	return func(c C) R {
+		return fn(a, b, c)
+	}
+}
+
+// PartialLast returns fn with its last argument bound to b, taking only the first, which
+// suits functions whose settings come after the value they work on.
+//
+// Examples:
+//
+//	short := PartialLast(Truncate, 3)
+//	Map([]string{"alpha", "be"}, short) == []string{"alp", "be"}
+func PartialLast[A, B, R any](fn func(A, B) R, b B) func(A) R {
+	return func(a A) R {
+		return fn(a, b)
+	}
+}
+
+// Curry2 returns fn as a chain of one-argument functions, so that Curry2(fn)(a)(b) is
+// fn(a, b).
+//
+// Examples:
+//
+//	add := Curry2(func(a, b int) int { return a + b })
+//	Map([]int{1, 2}, add(10)) == []int{11, 12}
+func Curry2[A, B, R any](fn func(A, B) R) func(A) func(B) R {
+	return func(a A) func(B) R {
+		return func(b B) R {
+			return fn(a, b)
+		}
+	}
+}
+
+// Curry3 returns fn as a chain of one-argument functions, so that Curry3(fn)(a)(b)(c) is
+// fn(a, b, c).
+func Curry3[A, B, C, R any](fn func(A, B, C) R) func(A) func(B) func(C) R {
+	return func(a A) func(B) func(C) R {
+		return func(b B) func(C) R {
+			return func(c C) R {
+				return fn(a, b, c)
+			}
+		}
+	}
+}
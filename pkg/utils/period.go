@@ -0,0 +1,160 @@
+package utils
+
+import "time"
+
+// midnight returns the first instant of the given date in loc. That is usually 00:00, but where
+// a daylight saving change skips midnight (as in Brazil until 2019), time.Date would return a
+// time on the previous day, so the instant the clocks jump forward is returned instead.
+func midnight(y int, m time.Month, d int, loc *time.Location) time.Time {
+	t := time.Date(y, m, d, 0, 0, 0, 0, loc)
+	// Compare with noon, since d may be out of range for time.Date to normalize.
+	if t.Day() != time.Date(y, m, d, 12, 0, 0, 0, loc).Day() {
+		_, next := t.ZoneBounds()
+		return next
+	}
+	return t
+}
+
+// StartOfDay returns midnight at the start of t's day, in t's location.
+// If a daylight saving change skips midnight, the first instant of the day is returned.
+//
+// Examples:
+//
+//	StartOfDay(time.Date(2024, 5, 3, 15, 4, 5, 0, time.UTC)) == time.Date(2024, 5, 3, 0, 0, 0, 0, time.UTC)
+func StartOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return midnight(y, m, d, t.Location())
+}
+
+// EndOfDay returns the last nanosecond of t's day, in t's location.
+//
+// Examples:
+//
+//	EndOfDay(time.Date(2024, 5, 3, 15, 4, 5, 0, time.UTC)) == time.Date(2024, 5, 3, 23, 59, 59, 999999999, time.UTC)
+func EndOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return midnight(y, m, d+1, t.Location()).Add(-time.Nanosecond)
+}
+
+// StartOfWeek returns midnight at the start of the week containing t, where weeks begin on
+// weekStart (time.Monday for ISO 8601 weeks, time.Sunday in the US), in t's location.
+//
+// Examples:
+//
+//	friday := time.Date(2024, 5, 3, 15, 0, 0, 0, time.UTC)
+//	StartOfWeek(friday, time.Monday) == time.Date(2024, 4, 29, 0, 0, 0, 0, time.UTC)
+//	StartOfWeek(friday, time.Sunday) == time.Date(2024, 4, 28, 0, 0, 0, 0, time.UTC)
+//	StartOfWeek(friday, time.Friday) == time.Date(2024, 5, 3, 0, 0, 0, 0, time.UTC)
+func StartOfWeek(t time.Time, weekStart time.Weekday) time.Time {
+	offset := (int(t.Weekday()) - int(weekStart) + 7) % 7
+	y, m, d := t.Date()
+	return midnight(y, m, d-offset, t.Location())
+}
+
+// StartOfMonth returns midnight on the first day of t's month, in t's location.
+//
+// Examples:
+//
+//	StartOfMonth(time.Date(2024, 2, 17, 8, 0, 0, 0, time.UTC)) == time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+func StartOfMonth(t time.Time) time.Time {
+	y, m, _ := t.Date()
+	return midnight(y, m, 1, t.Location())
+}
+
+// EndOfMonth returns the last nanosecond of t's month, in t's location.
+//
+// Examples:
+//
+//	EndOfMonth(time.Date(2024, 2, 17, 8, 0, 0, 0, time.UTC)) == time.Date(2024, 2, 29, 23, 59, 59, 999999999, time.UTC)
+func EndOfMonth(t time.Time) time.Time {
+	y, m, _ := t.Date()
+	return midnight(y, m+1, 1, t.Location()).Add(-time.Nanosecond)
+}
+
+// StartOfQuarter returns midnight on the first day of t's calendar quarter
+// (January, April, July or October), in t's location.
+//
+// Examples:
+//
+//	StartOfQuarter(time.Date(2024, 5, 3, 0, 0, 0, 0, time.UTC)) == time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+//	StartOfQuarter(time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)) == time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+func StartOfQuarter(t time.Time) time.Time {
+	y, m, _ := t.Date()
+	first := time.Month((int(m)-1)/3*3 + 1)
+	return midnight(y, first, 1, t.Location())
+}
+
+// StartOfYear returns midnight on January 1 of t's year, in t's location.
+//
+// Examples:
+//
+//	StartOfYear(time.Date(2024, 5, 3, 15, 0, 0, 0, time.UTC)) == time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+func StartOfYear(t time.Time) time.Time {
+	return midnight(t.Year(), time.January, 1, t.Location())
+}
+
+// TruncateToInterval returns t rounded down to a multiple of d on the wall clock of t's location,
+// so 15-minute buckets in "Asia/Kolkata" (UTC+05:30) start at :00, :15, :30 and :45 local time,
+// unlike t.Truncate, which works on absolute time. Multiples are counted from the zero time,
+// so intervals that evenly divide a day align with midnight, and 7*24*time.Hour starts weeks on Monday.
+// Across daylight saving changes, a bucket start skipped by the change moves to the first instant
+// after it, and a repeated one keeps t's offset when it can, so the result is never after t.
+// If d <= 0, t is returned unchanged, without its monotonic clock reading.
+//
+// Examples:
+//
+//	TruncateToInterval(time.Date(2024, 5, 3, 10, 47, 12, 0, time.UTC), 15*time.Minute) == time.Date(2024, 5, 3, 10, 45, 0, 0, time.UTC)
+//	TruncateToInterval(time.Date(2024, 5, 3, 10, 47, 12, 0, time.UTC), 24*time.Hour) == time.Date(2024, 5, 3, 0, 0, 0, 0, time.UTC)
+func TruncateToInterval(t time.Time, d time.Duration) time.Time {
+	if d <= 0 {
+		return t.Truncate(d)
+	}
+	return fromWallClock(wallClock(t).Truncate(d), t)
+}
+
+// RoundToInterval returns t rounded to the nearest multiple of d on the wall clock of t's
+// location, with halfway values rounded up, in the same way as TruncateToInterval. Unlike
+// TruncateToInterval, the result may be after t.
+// If d <= 0, t is returned unchanged, without its monotonic clock reading.
+//
+// Examples:
+//
+//	RoundToInterval(time.Date(2024, 5, 3, 10, 47, 12, 0, time.UTC), 15*time.Minute) == time.Date(2024, 5, 3, 10, 45, 0, 0, time.UTC)
+//	RoundToInterval(time.Date(2024, 5, 3, 10, 52, 30, 0, time.UTC), 15*time.Minute) == time.Date(2024, 5, 3, 11, 0, 0, 0, time.UTC)
+func RoundToInterval(t time.Time, d time.Duration) time.Time {
+	if d <= 0 {
+		return t.Round(d)
+	}
+	return fromWallClock(wallClock(t).Round(d), t)
+}
+
+// wallClock returns t's date and time of day in its location, as the same reading in UTC.
+func wallClock(t time.Time) time.Time {
+	y, m, d := t.Date()
+	h, mi, s := t.Clock()
+	return time.Date(y, m, d, h, mi, s, t.Nanosecond(), time.UTC)
+}
+
+// fromWallClock returns the instant with wall's reading in ref's location. If that reading occurs
+// twice, the one with ref's offset is preferred; if it was skipped, the instant after the gap is used.
+func fromWallClock(wall, ref time.Time) time.Time {
+	loc := ref.Location()
+	_, offset := ref.Zone()
+	same := wall.Add(-time.Duration(offset) * time.Second).In(loc)
+	if wallClock(same).Equal(wall) {
+		return same
+	}
+	y, m, d := wall.Date()
+	h, mi, s := wall.Clock()
+	t := time.Date(y, m, d, h, mi, s, wall.Nanosecond(), loc)
+	if got := wallClock(t); !got.Equal(wall) {
+		// The reading was skipped, and time.Date applied the offset from one side of the gap,
+		// landing on the other side; return the instant the clocks jumped instead.
+		start, end := t.ZoneBounds()
+		if got.After(wall) {
+			return start
+		}
+		return end
+	}
+	return t
+}
@@ -0,0 +1,152 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// phoneRegion describes how numbers are written in one country.
+type phoneRegion struct {
+	callingCode string
+	// trunkPrefix is dialled before the national number for domestic calls and dropped in E.164 form.
+	// It is empty for plans, such as Italy's, where the leading digit is part of the number.
+	trunkPrefix string
+	// minLen and maxLen bound the length of the national significant number.
+	minLen, maxLen int
+}
+
+// phoneRegions maps ISO 3166-1 alpha-2 region codes to their numbering plans.
+// The length bounds are deliberately loose; they catch typos, not unassigned ranges.
+var phoneRegions = map[string]phoneRegion{
+	"US": {"1", "1", 10, 10},
+	"CA": {"1", "1", 10, 10},
+	"GB": {"44", "0", 9, 10},
+	"IE": {"353", "0", 7, 9},
+	"DE": {"49", "0", 6, 13},
+	"FR": {"33", "0", 9, 9},
+	"ES": {"34", "", 9, 9},
+	"IT": {"39", "", 6, 11},
+	"PT": {"351", "", 9, 9},
+	"NL": {"31", "0", 9, 9},
+	"BE": {"32", "0", 8, 9},
+	"CH": {"41", "0", 9, 9},
+	"AT": {"43", "0", 4, 13},
+	"SE": {"46", "0", 7, 10},
+	"NO": {"47", "", 8, 8},
+	"DK": {"45", "", 8, 8},
+	"PL": {"48", "", 9, 9},
+	"BR": {"55", "0", 10, 11},
+	"AR": {"54", "0", 10, 11},
+	"MX": {"52", "", 10, 10},
+	"IN": {"91", "0", 10, 10},
+	"CN": {"86", "0", 7, 11},
+	"JP": {"81", "0", 9, 10},
+	"KR": {"82", "0", 8, 10},
+	"AU": {"61", "0", 9, 9},
+	"NZ": {"64", "0", 8, 10},
+	"ZA": {"27", "0", 9, 9},
+}
+
+// phoneExtension matches a trailing extension such as " ext. 123", " x123" or "#123".
+var phoneExtension = regexp.MustCompile(`(?i)\s*(?:ext\.?|extension|x|#)\s*\d{1,7}$`)
+
+// ValidatePhone checks if s is a valid phone number.
+// Numbers in international form ("+44 20 7946 0958" or "0044 20 7946 0958") are accepted
+// for any country; numbers in national form ("(020) 7946 0958") are interpreted using
+// defaultRegion, an ISO 3166-1 alpha-2 code such as "US" or "GB".
+// Spaces, hyphens, dots, slashes and parentheses are ignored, as is a trailing extension.
+// It returns an error if the number cannot be normalized; see FormatE164 for the rules.
+//
+// Examples:
+//
+//	ValidatePhone("(415) 555-2671", "US") == nil
+//	ValidatePhone("+44 20 7946 0958", "") == nil
+//	ValidatePhone("020 7946 0958 ext. 12", "GB") == nil
+//	ValidatePhone("555-2671", "US") returns an error
+//	ValidatePhone("020 7946 0958", "") returns an error
+//	ValidatePhone("call me", "US") returns an error
+func ValidatePhone(s, defaultRegion string) error {
+	_, err := FormatE164(s, defaultRegion)
+	return err
+}
+
+// FormatE164 normalizes a phone number to E.164 form: a "+", the country calling code,
+// and the national significant number, with no separators (for example "+14155552671").
+// Any extension is dropped. International numbers may start with "+" or "00"
+// (or "011" when defaultRegion is in the North American Numbering Plan); other numbers
+// are taken as national numbers of defaultRegion, whose trunk prefix (such as the
+// leading 0 in the UK) is removed.
+// The national number's length is checked for the regions this package knows;
+// numbers for other countries only need to be at most 15 digits long, as E.164 requires.
+// It returns an error if the number contains invalid characters, has the wrong length,
+// or is in national form without a known defaultRegion.
+//
+// Examples:
+//
+//	FormatE164("(415) 555-2671", "US") == ("+14155552671", nil)
+//	FormatE164("1-415-555-2671", "US") == ("+14155552671", nil)
+//	FormatE164("020 7946 0958 x12", "GB") == ("+442079460958", nil)
+//	FormatE164("00 33 1 23 45 67 89", "") == ("+33123456789", nil)
+//	FormatE164("(11) 98765-4321", "BR") == ("+5511987654321", nil)
+//	FormatE164("12345", "US") returns an error
+func FormatE164(s, defaultRegion string) (string, error) {
+	number := strings.TrimSpace(phoneExtension.ReplaceAllString(strings.TrimSpace(s), ""))
+	if number == "" {
+		return "", errors.New("phone number is empty")
+	}
+
+	international := strings.HasPrefix(number, "+")
+	var digits strings.Builder
+	for i, r := range number {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r == '+' && i == 0:
+		case strings.ContainsRune(" -./()", r):
+		default:
+			return "", fmt.Errorf("invalid character %q in phone number", r)
+		}
+	}
+	national := digits.String()
+
+	region, haveRegion := phoneRegions[strings.ToUpper(defaultRegion)]
+	if !international {
+		switch {
+		case strings.HasPrefix(national, "00"):
+			international, national = true, national[2:]
+		case haveRegion && region.callingCode == "1" && strings.HasPrefix(national, "011"):
+			international, national = true, national[3:]
+		}
+	}
+
+	if international {
+		if len(national) < 7 || len(national) > 15 {
+			return "", errors.New("international phone number must have between 7 and 15 digits")
+		}
+		// Calling codes are prefix-free, so at most one of the 1-3 digit prefixes is a known code.
+		for _, r := range phoneRegions {
+			if rest, ok := strings.CutPrefix(national, r.callingCode); ok {
+				if len(rest) < r.minLen || len(rest) > r.maxLen {
+					return "", fmt.Errorf("invalid phone number length for country code +%s", r.callingCode)
+				}
+				break
+			}
+		}
+		return "+" + national, nil
+	}
+
+	if defaultRegion == "" {
+		return "", errors.New("phone number has no country code and no default region was given")
+	}
+	if !haveRegion {
+		return "", fmt.Errorf("unsupported phone region %q", defaultRegion)
+	}
+	// National significant numbers never start with the trunk prefix, so it can be dropped unconditionally.
+	national = strings.TrimPrefix(national, region.trunkPrefix)
+	if len(national) < region.minLen || len(national) > region.maxLen {
+		return "", fmt.Errorf("invalid phone number length for region %s", strings.ToUpper(defaultRegion))
+	}
+	return "+" + region.callingCode + national, nil
+}
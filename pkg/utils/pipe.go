@@ -0,0 +1,86 @@
+package utils
+
+// Pipe2 returns a function that applies f and then g, that is, g(f(a)).
+//
+// Examples:
+//
+//	wordCount := Pipe2(strings.Fields, func(words []string) int { return len(words) })
+//	wordCount("a b  c") == 3
+func Pipe2[A, B, C any](f func(A) B, g func(B) C) func(A) C {
+	return func(a A) C {
+		return g(f(a))
+	}
+}
+
+// Pipe3 returns a function that applies f, g and h in turn, that is, h(g(f(a))).
+func Pipe3[A, B, C, D any](f func(A) B, g func(B) C, h func(C) D) func(A) D {
+	return func(a A) D {
+		return h(g(f(a)))
+	}
+}
+
+// Pipe4 returns a function that applies f, g, h and i in turn, that is, i(h(g(f(a)))).
+func Pipe4[A, B, C, D, E any](f func(A) B, g func(B) C, h func(C) D, i func(D) E) func(A) E {
+	return func(a A) E {
+		return i(h(g(f(a))))
+	}
+}
+
+// PipeErr2 is Pipe2 for stages that can fail: it stops at the first error and returns it.
+//
+// Examples:
+//
+//	loadConfig := PipeErr2(os.ReadFile, parseConfig)
+//	cfg, err := loadConfig("app.json")
+func PipeErr2[A, B, C any](f func(A) (B, error), g func(B) (C, error)) func(A) (C, error) {
+	return func(a A) (C, error) {
+		b, err := f(a)
+		if err != nil {
+			var zero C
+			return zero, err
+		}
+		return g(b)
+	}
+}
+
+// PipeErr3 is Pipe3 for stages that can fail: it stops at the first error and returns it.
+func PipeErr3[A, B, C, D any](f func(A) (B, error), g func(B) (C, error), h func(C) (D, error)) func(A) (D, error) {
+	return PipeErr2(PipeErr2(f, g), h)
+}
+
+// PipeErr4 is Pipe4 for stages that can fail: it stops at the first error and returns it.
+func PipeErr4[A, B, C, D, E any](f func(A) (B, error), g func(B) (C, error), h func(C) (D, error), i func(D) (E, error)) func(A) (E, error) {
+	return PipeErr2(PipeErr3(f, g, h), i)
+}
+
+// Compose returns a function that applies fns in turn, from first to last, to chain any
+// number of transformations of the same type. Note that this is the order of Pipe2, not that
+// of mathematical composition. With no fns it returns its argument unchanged.
+//
+// Examples:
+//
+//	slug := Compose(NormalizeSpaces, Slugify, func(s string) string { return Truncate(s, 40) })
+//	slug("  Hello   World ") == "hello-world"
+func Compose[T any](fns ...func(T) T) func(T) T {
+	return func(v T) T {
+		for _, fn := range fns {
+			v = fn(v)
+		}
+		return v
+	}
+}
+
+// ComposeErr is Compose for stages that can fail: it stops at the first error and returns it,
+// with the zero value of T.
+func ComposeErr[T any](fns ...func(T) (T, error)) func(T) (T, error) {
+	return func(v T) (T, error) {
+		for _, fn := range fns {
+			var err error
+			if v, err = fn(v); err != nil {
+				var zero T
+				return zero, err
+			}
+		}
+		return v, nil
+	}
+}
@@ -0,0 +1,121 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// postalCodePatterns maps upper-case ISO 3166-1 alpha-2 codes to the pattern postal codes must match.
+// Codes are upper-cased and trimmed before matching, so patterns only need upper-case letters.
+var postalCodePatterns = map[string]*regexp.Regexp{
+	"US": regexp.MustCompile(`^\d{5}(-\d{4})?$`),
+	"CA": regexp.MustCompile(`^[ABCEGHJ-NPRSTVXY]\d[ABCEGHJ-NPRSTV-Z] ?\d[ABCEGHJ-NPRSTV-Z]\d$`),
+	"MX": regexp.MustCompile(`^\d{5}$`),
+	"BR": regexp.MustCompile(`^\d{5}-?\d{3}$`),
+	"AR": regexp.MustCompile(`^([A-HJ-NP-Z]\d{4}[A-Z]{3}|\d{4})$`),
+	"GB": regexp.MustCompile(`^(GIR ?0AA|[A-PR-UWYZ]([0-9]{1,2}|[A-HK-Y][0-9]{1,2}|[0-9][A-HJKS-UW]|[A-HK-Y][0-9][ABEHMNPRV-Y]) ?[0-9][ABD-HJLNP-UW-Z]{2})$`),
+	"IE": regexp.MustCompile(`^([AC-FHKNPRTV-Y]\d{2}|D6W) ?[0-9AC-FHKNPRTV-Y]{4}$`),
+	"DE": regexp.MustCompile(`^\d{5}$`),
+	"FR": regexp.MustCompile(`^\d{5}$`),
+	"IT": regexp.MustCompile(`^\d{5}$`),
+	"ES": regexp.MustCompile(`^(0[1-9]|[1-4]\d|5[0-2])\d{3}$`),
+	"PT": regexp.MustCompile(`^\d{4}-\d{3}$`),
+	"NL": regexp.MustCompile(`^[1-9]\d{3} ?[A-Z]{2}$`),
+	"BE": regexp.MustCompile(`^[1-9]\d{3}$`),
+	"CH": regexp.MustCompile(`^[1-9]\d{3}$`),
+	"AT": regexp.MustCompile(`^[1-9]\d{3}$`),
+	"DK": regexp.MustCompile(`^\d{4}$`),
+	"NO": regexp.MustCompile(`^\d{4}$`),
+	"SE": regexp.MustCompile(`^\d{3} ?\d{2}$`),
+	"FI": regexp.MustCompile(`^\d{5}$`),
+	"PL": regexp.MustCompile(`^\d{2}-\d{3}$`),
+	"RU": regexp.MustCompile(`^\d{6}$`),
+	"IN": regexp.MustCompile(`^[1-9]\d{2} ?\d{3}$`),
+	"CN": regexp.MustCompile(`^\d{6}$`),
+	"JP": regexp.MustCompile(`^\d{3}-?\d{4}$`),
+	"KR": regexp.MustCompile(`^\d{5}$`),
+	"AU": regexp.MustCompile(`^\d{4}$`),
+	"NZ": regexp.MustCompile(`^\d{4}$`),
+	"ZA": regexp.MustCompile(`^\d{4}$`),
+}
+
+// postalCodeAliases maps non-ISO country codes in common use to their ISO equivalents.
+var postalCodeAliases = map[string]string{
+	"UK": "GB",
+}
+
+// postalCodeMu guards postalCodePatterns against concurrent registration.
+var postalCodeMu sync.RWMutex
+
+// RegisterPostalCodePattern sets the regular expression that ValidatePostalCode uses for countryISO2,
+// adding a country or replacing the built-in pattern; "UK" sets the pattern for "GB", as in
+// ValidatePostalCode. Postal codes are trimmed and upper-cased
+// before matching, and the pattern should be anchored with ^ and $.
+// It is safe to call concurrently with ValidatePostalCode.
+// It returns an error if countryISO2 is not two letters or pattern does not compile.
+//
+// Examples:
+//
+//	RegisterPostalCodePattern("LU", `^(L-)?\d{4}$`) == nil
+//	RegisterPostalCodePattern("LUX", `^\d{4}$`) returns an error
+//	RegisterPostalCodePattern("LU", `^(\d{4}$`) returns an error
+func RegisterPostalCodePattern(countryISO2, pattern string) error {
+	country := strings.ToUpper(countryISO2)
+	if len(country) != 2 || country[0] < 'A' || country[0] > 'Z' || country[1] < 'A' || country[1] > 'Z' {
+		return fmt.Errorf("invalid country code %q: must be two letters", countryISO2)
+	}
+	if alias, ok := postalCodeAliases[country]; ok {
+		country = alias
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("invalid postal code pattern: %w", err)
+	}
+
+	postalCodeMu.Lock()
+	defer postalCodeMu.Unlock()
+	postalCodePatterns[country] = re
+	return nil
+}
+
+// ValidatePostalCode checks if a string is a valid postal code for the country countryISO2,
+// an ISO 3166-1 alpha-2 code such as "US", "BR" or "GB" ("UK" is accepted as well).
+// Leading and trailing whitespace is ignored and letters may be in either case.
+// Patterns are built in for about thirty countries; use RegisterPostalCodePattern to add more.
+// It returns an error if the code is empty, does not match the country's format,
+// or the country has no registered pattern.
+//
+// Examples:
+//
+//	ValidatePostalCode("12345", "US") == nil
+//	ValidatePostalCode("12345-6789", "US") == nil
+//	ValidatePostalCode("01310-100", "BR") == nil
+//	ValidatePostalCode("SW1A 0AA", "UK") == nil
+//	ValidatePostalCode("k1a 0b1", "CA") == nil
+//	ValidatePostalCode("100-0001", "JP") == nil
+//	ValidatePostalCode("1234", "US") returns an error (invalid US format)
+//	ValidatePostalCode("ABCDE", "XX") returns an error (unsupported country code)
+func ValidatePostalCode(code, countryISO2 string) error {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		return errors.New("postal code cannot be empty")
+	}
+	country := strings.ToUpper(countryISO2)
+	if alias, ok := postalCodeAliases[country]; ok {
+		country = alias
+	}
+
+	postalCodeMu.RLock()
+	re, ok := postalCodePatterns[country]
+	postalCodeMu.RUnlock()
+	if !ok {
+		return fmt.Errorf("unsupported country code %q for postal code validation", countryISO2)
+	}
+	if !re.MatchString(code) {
+		return fmt.Errorf("invalid %s postal code format", country)
+	}
+	return nil
+}
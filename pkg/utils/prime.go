@@ -0,0 +1,125 @@
+package utils
+
+import "math/bits"
+
+// largestPrime64 is the largest prime that fits in a uint64.
+const largestPrime64 = 18446744073709551557
+
+// mulMod returns (a * b) mod m without overflowing.
+func mulMod(a, b, m uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	return bits.Rem64(hi, lo, m)
+}
+
+// powMod returns (base ^ exp) mod m.
+func powMod(base, exp, m uint64) uint64 {
+	result := uint64(1)
+	base %= m
+	for exp > 0 {
+		if exp&1 == 1 {
+			result = mulMod(result, base, m)
+		}
+		base = mulMod(base, base, m)
+		exp >>= 1
+	}
+	return result
+}
+
+// IsPrime reports whether n is a prime number.
+// It uses a deterministic Miller–Rabin test whose witness set is proven
+// correct for every 64-bit integer, so the result is never probabilistic.
+//
+// Examples:
+//
+//	IsPrime(2) == true
+//	IsPrime(97) == true
+//	IsPrime(1) == false
+//	IsPrime(561) == false (Carmichael number)
+//	IsPrime(18446744073709551557) == true
+func IsPrime(n uint64) bool {
+	if n < 2 {
+		return false
+	}
+	// The first twelve primes are both the small-factor filter and the Miller–Rabin witnesses.
+	witnesses := [...]uint64{2, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37}
+	for _, p := range witnesses {
+		if n%p == 0 {
+			return n == p
+		}
+	}
+
+	d := n - 1
+	s := bits.TrailingZeros64(d)
+	d >>= s
+	for _, a := range witnesses {
+		x := powMod(a, d, n)
+		if x == 1 || x == n-1 {
+			continue
+		}
+		composite := true
+		for r := 1; r < s; r++ {
+			x = mulMod(x, x, n)
+			if x == n-1 {
+				composite = false
+				break
+			}
+		}
+		if composite {
+			return false
+		}
+	}
+	return true
+}
+
+// NextPrime returns the smallest prime strictly greater than n.
+// It returns ErrOverflow if no such prime fits in a uint64.
+//
+// Examples:
+//
+//	NextPrime(0) == (2, nil)
+//	NextPrime(13) == (17, nil)
+//	NextPrime(1000) == (1009, nil)
+//	NextPrime(18446744073709551557) returns (0, ErrOverflow)
+func NextPrime(n uint64) (uint64, error) {
+	if n >= largestPrime64 {
+		return 0, ErrOverflow
+	}
+	if n < 2 {
+		return 2, nil
+	}
+	// Start at the next odd number and only test odd candidates.
+	candidate := n + 1 + n%2
+	for !IsPrime(candidate) {
+		candidate += 2
+	}
+	return candidate, nil
+}
+
+// PrimesUpTo returns all prime numbers less than or equal to n in ascending order,
+// using a sieve of Eratosthenes over odd numbers.
+// Memory use is proportional to n, so it is intended for moderate bounds.
+//
+// Examples:
+//
+//	PrimesUpTo(20) == []uint64{2, 3, 5, 7, 11, 13, 17, 19}
+//	PrimesUpTo(2) == []uint64{2}
+//	PrimesUpTo(1) == []uint64{}
+func PrimesUpTo(n uint64) []uint64 {
+	if n < 2 {
+		return []uint64{}
+	}
+	// composite[i] represents the odd number 2*i + 1.
+	composite := make([]bool, n/2+1)
+	primes := []uint64{2}
+	for i := uint64(1); 2*i+1 <= n; i++ {
+		if composite[i] {
+			continue
+		}
+		p := 2*i + 1
+		primes = append(primes, p)
+		for j := p * p; j <= n && j >= p; j += 2 * p {
+			composite[j/2] = true
+		}
+	}
+	return primes
+}
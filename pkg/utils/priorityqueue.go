@@ -0,0 +1,143 @@
+package utils
+
+import "container/heap"
+
+// PriorityQueue is a queue that pops its items in priority order, as decided by a less
+// function: the item that is less than all others comes out first. Items of equal priority
+// come out in the order they were pushed. It is not safe for concurrent use.
+type PriorityQueue[T any] struct {
+	h priorityHeap[T]
+}
+
+// PriorityItem is an item in a PriorityQueue, returned by Push to change its priority later
+// with UpdatePriority or take it out with Remove.
+type PriorityItem[T any] struct {
+	value T
+	seq   uint64 // push order, to break ties
+	index int    // position in the heap, or -1 once the item has left the queue
+}
+
+// Value returns the item's value.
+func (it *PriorityItem[T]) Value() T {
+	return it.value
+}
+
+// priorityHeap implements heap.Interface for PriorityQueue.
+type priorityHeap[T any] struct {
+	less  func(a, b T) bool
+	items []*PriorityItem[T]
+	seq   uint64
+}
+
+// NewPriorityQueue returns an empty queue ordered by less, which reports whether a has a
+// higher priority than b, that is, comes out first.
+//
+// Examples:
+//
+//	jobs := NewPriorityQueue(func(a, b Job) bool { return a.RunAt.Before(b.RunAt) })
+//	jobs.Push(Job{Name: "report", RunAt: tomorrow})
+//	jobs.Push(Job{Name: "backup", RunAt: tonight})
+//	next, _ := jobs.Pop() // backup
+//
+//	maxHeap := NewPriorityQueue(func(a, b int) bool { return a > b })
+func NewPriorityQueue[T any](less func(a, b T) bool) *PriorityQueue[T] {
+	return &PriorityQueue[T]{h: priorityHeap[T]{less: less}}
+}
+
+// Push adds value to q and returns its item, for UpdatePriority and Remove.
+func (q *PriorityQueue[T]) Push(value T) *PriorityItem[T] {
+	it := &PriorityItem[T]{value: value, seq: q.h.seq}
+	q.h.seq++
+	heap.Push(&q.h, it)
+	return it
+}
+
+// Pop removes and returns the value with the highest priority, or returns false if q is empty.
+func (q *PriorityQueue[T]) Pop() (T, bool) {
+	if len(q.h.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return heap.Pop(&q.h).(*PriorityItem[T]).value, true
+}
+
+// Peek returns the value with the highest priority without removing it, or returns false if q
+// is empty.
+func (q *PriorityQueue[T]) Peek() (T, bool) {
+	if len(q.h.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return q.h.items[0].value, true
+}
+
+// Len returns the number of items in q.
+func (q *PriorityQueue[T]) Len() int {
+	return len(q.h.items)
+}
+
+// UpdatePriority replaces the value of item, typically with one of a different priority, and
+// moves it to its new place in q. Among items of equal priority it keeps its original push
+// order. It reports false, changing nothing, if item is no longer in q.
+//
+// Examples:
+//
+//	item := jobs.Push(job)
+//	job.RunAt = time.Now() // run it right away
+//	jobs.UpdatePriority(item, job)
+func (q *PriorityQueue[T]) UpdatePriority(item *PriorityItem[T], value T) bool {
+	if !q.holds(item) {
+		return false
+	}
+	item.value = value
+	heap.Fix(&q.h, item.index)
+	return true
+}
+
+// Remove takes item out of q, and reports false if it was no longer in q.
+func (q *PriorityQueue[T]) Remove(item *PriorityItem[T]) bool {
+	if !q.holds(item) {
+		return false
+	}
+	heap.Remove(&q.h, item.index)
+	return true
+}
+
+// holds reports whether item is in q.
+func (q *PriorityQueue[T]) holds(item *PriorityItem[T]) bool {
+	return item != nil && item.index >= 0 && item.index < len(q.h.items) && q.h.items[item.index] == item
+}
+
+func (h *priorityHeap[T]) Len() int { return len(h.items) }
+
+func (h *priorityHeap[T]) Less(i, j int) bool {
+	a, b := h.items[i], h.items[j]
+	if h.less(a.value, b.value) {
+		return true
+	}
+	if h.less(b.value, a.value) {
+		return false
+	}
+	return a.seq < b.seq
+}
+
+func (h *priorityHeap[T]) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.items[i].index = i
+	h.items[j].index = j
+}
+
+func (h *priorityHeap[T]) Push(x any) {
+	it := x.(*PriorityItem[T])
+	it.index = len(h.items)
+	h.items = append(h.items, it)
+}
+
+func (h *priorityHeap[T]) Pop() any {
+	last := len(h.items) - 1
+	it := h.items[last]
+	h.items[last] = nil // let the item be garbage collected
+	h.items = h.items[:last]
+	it.index = -1
+	return it
+}
@@ -0,0 +1,82 @@
+package utils
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// ParseQueryToMap parses a URL query string such as "a=1&b=2&a=3" into a map from each key
+// to its values in the order they appear. A leading "?" is ignored, so the output of
+// url.URL.RawQuery or a full "?..." suffix can be passed directly.
+// It returns an error if the query contains a malformed escape or a semicolon, which
+// net/url no longer accepts as a separator.
+//
+// Examples:
+//
+//	ParseQueryToMap("?a=1&b=hello+world&a=3") == (map[string][]string{"a": {"1", "3"}, "b": {"hello world"}}, nil)
+//	ParseQueryToMap("flag") == (map[string][]string{"flag": {""}}, nil)
+//	ParseQueryToMap("") == (map[string][]string{}, nil)
+//	ParseQueryToMap("a=%zz") returns an error
+func ParseQueryToMap(qs string) (map[string][]string, error) {
+	values, err := url.ParseQuery(strings.TrimPrefix(qs, "?"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid query string: %w", err)
+	}
+	return values, nil
+}
+
+// BuildQuery encodes m as a URL query string with keys in sorted order, so the same map always
+// produces the same string, as request signing requires. Keys and values are escaped with
+// url.QueryEscape, as url.Values.Encode does. A slice or array value produces one key=value pair
+// per element, in order; other values are formatted with ToString, falling back to Stringify.
+// Nil values are omitted.
+//
+// Examples:
+//
+//	BuildQuery(map[string]any{"b": 2, "a": "x y"}) == "a=x+y&b=2"
+//	BuildQuery(map[string]any{"id": []int{3, 1}}) == "id=3&id=1"
+//	BuildQuery(map[string]any{"q": "a&b=c", "skip": nil}) == "q=a%26b%3Dc"
+//	BuildQuery(nil) == ""
+func BuildQuery(m map[string]any) string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	var b strings.Builder
+	add := func(key string, v any) {
+		if v == nil {
+			return
+		}
+		if b.Len() > 0 {
+			b.WriteByte('&')
+		}
+		b.WriteString(url.QueryEscape(key))
+		b.WriteByte('=')
+		b.WriteString(url.QueryEscape(queryValueString(v)))
+	}
+	for _, k := range keys {
+		v := m[k]
+		rv := reflect.ValueOf(v)
+		if (rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array) && rv.Type().Elem().Kind() != reflect.Uint8 {
+			for i := 0; i < rv.Len(); i++ {
+				add(k, rv.Index(i).Interface())
+			}
+			continue
+		}
+		add(k, v)
+	}
+	return b.String()
+}
+
+// queryValueString formats a single query value for BuildQuery.
+func queryValueString(v any) string {
+	if s, err := ToString(v); err == nil {
+		return s
+	}
+	return Stringify(v)
+}
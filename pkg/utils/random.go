@@ -0,0 +1,80 @@
+package utils
+
+import (
+	crand "crypto/rand"
+	"errors"
+	"math"
+	"math/big"
+	"math/rand/v2"
+)
+
+// RandomInt returns a pseudo-random integer in the inclusive range [min, max].
+// It is not suitable for security-sensitive work; use SecureRandomInt instead.
+// It returns an error if min > max.
+//
+// Examples:
+//
+//	RandomInt(1, 6) returns a value between 1 and 6, inclusive
+//	RandomInt(5, 5) == (5, nil)
+//	RandomInt(10, 1) returns an error
+func RandomInt(min, max int) (int, error) {
+	if min > max {
+		return 0, errors.New("min cannot be greater than max")
+	}
+	// The span is computed in uint64 so that ranges such as [math.MinInt, math.MaxInt] don't overflow.
+	span := uint64(max) - uint64(min)
+	if span == math.MaxUint64 {
+		return int(rand.Uint64()), nil
+	}
+	return min + int(rand.Uint64N(span+1)), nil
+}
+
+// RandomFloat returns a pseudo-random float64 in the half-open range [min, max).
+// If min == max, min is returned.
+// It returns an error if min > max or either bound is NaN or infinite.
+//
+// Examples:
+//
+//	RandomFloat(0, 1) returns a value in [0, 1)
+//	RandomFloat(2.5, 2.5) == (2.5, nil)
+//	RandomFloat(1, 0) returns an error
+func RandomFloat(min, max float64) (float64, error) {
+	if math.IsNaN(min) || math.IsNaN(max) || math.IsInf(min, 0) || math.IsInf(max, 0) {
+		return 0, errors.New("bounds must be finite numbers")
+	}
+	if min > max {
+		return 0, errors.New("min cannot be greater than max")
+	}
+	if min == max {
+		return min, nil
+	}
+	// Interpolating the bounds avoids overflowing max-min; rounding can still land outside [min, max), so retry then.
+	for {
+		u := rand.Float64()
+		f := (1-u)*min + u*max
+		if f >= min && f < max {
+			return f, nil
+		}
+	}
+}
+
+// SecureRandomInt returns a cryptographically secure random integer in the inclusive range [min, max],
+// using crypto/rand. It returns an error if min > max or if the system's random source fails.
+//
+// Examples:
+//
+//	SecureRandomInt(100000, 999999) returns a six-digit one-time code
+//	SecureRandomInt(3, 3) == (3, nil)
+//	SecureRandomInt(9, 0) returns an error
+func SecureRandomInt(min, max int) (int, error) {
+	if min > max {
+		return 0, errors.New("min cannot be greater than max")
+	}
+	span := new(big.Int).Sub(big.NewInt(int64(max)), big.NewInt(int64(min)))
+	span.Add(span, big.NewInt(1))
+	n, err := crand.Int(crand.Reader, span)
+	if err != nil {
+		return 0, err
+	}
+	return int(n.Add(n, big.NewInt(int64(min))).Int64()), nil
+}
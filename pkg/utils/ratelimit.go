@@ -0,0 +1,236 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token-bucket rate limiter. The bucket holds up to burst tokens and refills
+// at rate tokens per second; each event takes one token. It is safe for concurrent use.
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64 // tokens added per second
+	burst  float64
+	tokens float64 // may be negative while Wait calls hold reservations
+	last   time.Time
+}
+
+// NewRateLimiter returns a RateLimiter allowing rate events per second on average, with bursts
+// of up to burst events. The bucket starts full. A rate of math.Inf(1) allows every event, and
+// a rate of zero or less allows only the initial burst. A burst below 1 is treated as 1.
+//
+// Examples:
+//
+//	limiter := NewRateLimiter(10, 20) // 10 requests per second, bursts of 20
+//	limiter := NewRateLimiter(1.0/60, 1) // one event per minute
+func NewRateLimiter(rate float64, burst int) *RateLimiter {
+	if rate < 0 || math.IsNaN(rate) {
+		rate = 0
+	}
+	b := float64(max(burst, 1))
+	return &RateLimiter{rate: rate, burst: b, tokens: b, last: time.Now()}
+}
+
+// Rate returns the number of events per second l allows on average.
+func (l *RateLimiter) Rate() float64 {
+	return l.rate
+}
+
+// Burst returns the maximum number of events l allows at once.
+func (l *RateLimiter) Burst() int {
+	return int(l.burst)
+}
+
+// Tokens returns the number of tokens currently available, which is negative while Wait
+// calls are waiting for tokens they have reserved.
+func (l *RateLimiter) Tokens() float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.refill(time.Now())
+	return l.tokens
+}
+
+// Allow reports whether an event may happen now, taking a token if so.
+// Use it to drop or reject events over the limit, and Wait to delay them.
+//
+// Examples:
+//
+//	if !limiter.Allow() {
+//		http.Error(w, "too many requests", http.StatusTooManyRequests)
+//		return
+//	}
+func (l *RateLimiter) Allow() bool {
+	return l.AllowN(1)
+}
+
+// AllowN reports whether n events may happen now, taking n tokens if so.
+// It always returns false if n is less than 1 or more than the burst.
+func (l *RateLimiter) AllowN(n int) bool {
+	if n < 1 {
+		return false
+	}
+	if math.IsInf(l.rate, 1) {
+		return true
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.refill(time.Now())
+	if l.tokens < float64(n) {
+		return false
+	}
+	l.tokens -= float64(n)
+	return true
+}
+
+// Wait blocks until an event may happen, taking a token, or until ctx is done. Waiters are
+// served in the order they call Wait. It returns an error, without waiting, if ctx would
+// expire before a token is available or the limiter has a zero rate and no tokens, and
+// ctx's error if ctx is done while waiting.
+//
+// Examples:
+//
+//	for _, req := range batch {
+//		if err := limiter.Wait(ctx); err != nil {
+//			return err
+//		}
+//		send(req)
+//	}
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if math.IsInf(l.rate, 1) {
+		return nil
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	l.refill(now)
+	l.tokens--
+	if l.tokens >= 0 {
+		l.mu.Unlock()
+		return nil
+	}
+	if l.rate == 0 {
+		l.tokens++
+		l.mu.Unlock()
+		return errors.New("rate limiter has no tokens left and a zero rate")
+	}
+	delay := time.Duration(-l.tokens / l.rate * float64(time.Second))
+	if deadline, ok := ctx.Deadline(); ok && deadline.Before(now.Add(delay)) {
+		l.tokens++
+		l.mu.Unlock()
+		return errors.New("rate limiter wait would exceed the context deadline")
+	}
+	l.mu.Unlock()
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		// Return the reserved token, so that the waiters behind it are not delayed further.
+		l.mu.Lock()
+		l.refill(time.Now())
+		l.tokens = min(l.tokens+1, l.burst)
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// refill adds the tokens accrued since the last refill. l.mu must be held.
+func (l *RateLimiter) refill(now time.Time) {
+	if elapsed := now.Sub(l.last); elapsed > 0 {
+		l.tokens = min(l.tokens+elapsed.Seconds()*l.rate, l.burst)
+		l.last = now
+	}
+}
+
+// full reports whether l's bucket is full, so that it behaves like a new limiter.
+func (l *RateLimiter) full(now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.refill(now)
+	return l.tokens >= l.burst
+}
+
+// keyedLimiterSweepEvery is how many calls a KeyedLimiter handles between removing
+// the limiters of idle keys.
+const keyedLimiterSweepEvery = 1024
+
+// KeyedLimiter rate-limits events separately for each key, such as a user ID or client IP,
+// with one token bucket per key created on first use. Buckets of idle keys that have refilled
+// completely are removed from time to time, so memory use follows the number of active keys.
+// It is safe for concurrent use.
+type KeyedLimiter[K comparable] struct {
+	mu       sync.Mutex
+	rate     float64
+	burst    int
+	limiters map[K]*RateLimiter
+	calls    int
+}
+
+// NewKeyedLimiter returns a KeyedLimiter allowing each key rate events per second on
+// average, with bursts of up to burst events, as NewRateLimiter does for a single bucket.
+//
+// Examples:
+//
+//	perIP := NewKeyedLimiter[string](5, 10)
+//	if !perIP.Allow(clientIP) {
+//		http.Error(w, "too many requests", http.StatusTooManyRequests)
+//		return
+//	}
+func NewKeyedLimiter[K comparable](rate float64, burst int) *KeyedLimiter[K] {
+	return &KeyedLimiter[K]{rate: rate, burst: burst, limiters: make(map[K]*RateLimiter)}
+}
+
+// Allow reports whether an event for key may happen now, taking a token from key's bucket if so.
+func (k *KeyedLimiter[K]) Allow(key K) bool {
+	return k.limiter(key).Allow()
+}
+
+// AllowN reports whether n events for key may happen now, taking n tokens from key's bucket if so.
+// It always returns false if n is less than 1 or more than the burst.
+func (k *KeyedLimiter[K]) AllowN(key K, n int) bool {
+	if n < 1 {
+		return false
+	}
+	return k.limiter(key).AllowN(n)
+}
+
+// Wait blocks until an event for key may happen, or until ctx is done, as RateLimiter.Wait does.
+func (k *KeyedLimiter[K]) Wait(ctx context.Context, key K) error {
+	return k.limiter(key).Wait(ctx)
+}
+
+// Len returns the number of keys with a bucket, including idle ones not yet removed.
+func (k *KeyedLimiter[K]) Len() int {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return len(k.limiters)
+}
+
+// limiter returns key's bucket, creating it if needed, and periodically removes idle buckets.
+func (k *KeyedLimiter[K]) limiter(key K) *RateLimiter {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.calls++
+	if k.calls%keyedLimiterSweepEvery == 0 {
+		now := time.Now()
+		for key, l := range k.limiters {
+			if l.full(now) {
+				delete(k.limiters, key)
+			}
+		}
+	}
+	l, ok := k.limiters[key]
+	if !ok {
+		l = NewRateLimiter(k.rate, k.burst)
+		k.limiters[key] = l
+	}
+	return l
+}
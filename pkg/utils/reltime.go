@@ -0,0 +1,98 @@
+package utils
+
+import (
+	"math"
+	"strconv"
+	"time"
+)
+
+// relativeDuration describes the magnitude of d in words, such as "3 hours" or "1 minute",
+// using the bucket boundaries of moment.js: under 45 seconds is "a few seconds", then minutes
+// up to 45 minutes, hours up to 22 hours, days up to 26 days, months (of 30 days) up to
+// 320 days, and years (of 365 days) beyond that. Counts are rounded to the nearest unit.
+func relativeDuration(d time.Duration) string {
+	seconds := math.Abs(d.Seconds())
+	plural := func(n float64, unit string) string {
+		count := int(math.Round(n))
+		if count <= 1 {
+			return "1 " + unit
+		}
+		return strconv.Itoa(count) + " " + unit + "s"
+	}
+	switch {
+	case seconds < 45:
+		return "a few seconds"
+	case seconds < 90:
+		return "1 minute"
+	case seconds < 45*60:
+		return plural(seconds/60, "minute")
+	case seconds < 90*60:
+		return "1 hour"
+	case seconds < 22*3600:
+		return plural(seconds/3600, "hour")
+	case seconds < 36*3600:
+		return "1 day"
+	case seconds < 26*86400:
+		return plural(seconds/86400, "day")
+	case seconds < 45*86400:
+		return "1 month"
+	case seconds < 320*86400:
+		return plural(seconds/(30*86400), "month")
+	case seconds < 548*86400:
+		return "1 year"
+	}
+	return plural(seconds/(365*86400), "year")
+}
+
+// referenceTime returns the first of ref, or the current time if ref is empty.
+func referenceTime(ref []time.Time) time.Time {
+	if len(ref) > 0 {
+		return ref[0]
+	}
+	return time.Now()
+}
+
+// TimeAgo describes how long before the reference time t was, such as "3 hours ago" or
+// "2 months ago", for activity feeds and admin UIs. The reference time is the current time,
+// or ref if one is given, which keeps callers testable.
+// Under 45 seconds, and for times after the reference (as happens with clock skew between
+// servers), it returns "just now". Larger differences are bucketed and rounded; see TimeUntil
+// for the boundaries.
+//
+// Examples:
+//
+//	TimeAgo(now.Add(-10*time.Second), now) == "just now"
+//	TimeAgo(now.Add(-time.Minute), now) == "1 minute ago"
+//	TimeAgo(now.Add(-3*time.Hour), now) == "3 hours ago"
+//	TimeAgo(now.Add(-30*time.Hour), now) == "1 day ago"
+//	TimeAgo(now.Add(-400*24*time.Hour), now) == "1 year ago"
+//	TimeAgo(now.Add(time.Hour), now) == "just now"
+func TimeAgo(t time.Time, ref ...time.Time) string {
+	d := referenceTime(ref).Sub(t)
+	if d < 45*time.Second {
+		return "just now"
+	}
+	return relativeDuration(d) + " ago"
+}
+
+// TimeUntil describes how long after the reference time t is, such as "in 3 hours", for
+// countdowns and scheduled items. The reference time is the current time, or ref if one is given.
+// Times at or before the reference return "now", and times less than 45 seconds away return
+// "in a few seconds". Differences are rounded to the nearest unit, moving to the next unit at
+// 90 seconds ("in 2 minutes"), 45 minutes ("in 1 hour"), 22 hours ("in 1 day"), 26 days
+// ("in 1 month", with months of 30 days) and 320 days ("in 1 year", with years of 365 days).
+//
+// Examples:
+//
+//	TimeUntil(now.Add(20*time.Second), now) == "in a few seconds"
+//	TimeUntil(now.Add(5*time.Minute), now) == "in 5 minutes"
+//	TimeUntil(now.Add(50*time.Minute), now) == "in 1 hour"
+//	TimeUntil(now.Add(72*time.Hour), now) == "in 3 days"
+//	TimeUntil(now.Add(-time.Hour), now) == "now"
+func TimeUntil(t time.Time, ref ...time.Time) string {
+	d := t.Sub(referenceTime(ref))
+	if d <= 0 {
+		return "now"
+	}
+	return "in " + relativeDuration(d)
+}
@@ -0,0 +1,111 @@
+package utils
+
+import "fmt"
+
+// Result holds either a value or the error that prevented computing it, for passing both
+// through a single channel or slice element. A Result with a nil error holds a value; the
+// zero value holds the zero value of T.
+type Result[T any] struct {
+	value T
+	err   error
+}
+
+// Ok returns a Result holding value.
+func Ok[T any](value T) Result[T] {
+	return Result[T]{value: value}
+}
+
+// Err returns a Result holding err, which should not be nil.
+func Err[T any](err error) Result[T] {
+	return Result[T]{err: err}
+}
+
+// ResultOf returns a Result from the usual value and error pair: err if it is not nil, and
+// value otherwise.
+//
+// Examples:
+//
+//	results <- ResultOf(fetch(ctx, url))
+func ResultOf[T any](value T, err error) Result[T] {
+	if err != nil {
+		return Err[T](err)
+	}
+	return Ok(value)
+}
+
+// IsOk reports whether r holds a value rather than an error.
+func (r Result[T]) IsOk() bool {
+	return r.err == nil
+}
+
+// Err returns the error r holds, or nil if it holds a value.
+func (r Result[T]) Err() error {
+	return r.err
+}
+
+// Get returns the value and error r holds, for unpacking it the usual way.
+//
+// Examples:
+//
+//	for r := range results {
+//		page, err := r.Get()
+//		if err != nil {
+//			return err
+//		}
+//		pages = append(pages, page)
+//	}
+func (r Result[T]) Get() (T, error) {
+	return r.value, r.err
+}
+
+// Unwrap returns the value r holds. It panics if r holds an error, so it is meant for results
+// known to be values.
+func (r Result[T]) Unwrap() T {
+	if r.err != nil {
+		panic(fmt.Sprintf("utils: Unwrap called on an error Result: %v", r.err))
+	}
+	return r.value
+}
+
+// UnwrapOr returns the value r holds, or def if r holds an error.
+func (r Result[T]) UnwrapOr(def T) T {
+	if r.err != nil {
+		return def
+	}
+	return r.value
+}
+
+// String returns "Ok(value)" or "Err(error)".
+func (r Result[T]) String() string {
+	if r.err != nil {
+		return fmt.Sprintf("Err(%v)", r.err)
+	}
+	return fmt.Sprintf("Ok(%v)", r.value)
+}
+
+// MapResult returns a Result holding fn applied to the value of r, or r's error without
+// calling fn. It is a function rather than a method because methods cannot have type
+// parameters.
+//
+// Examples:
+//
+//	sizes := MapResult(ResultOf(os.ReadFile(name)), func(b []byte) int { return len(b) })
+func MapResult[T, U any](r Result[T], fn func(T) U) Result[U] {
+	if r.err != nil {
+		return Err[U](r.err)
+	}
+	return Ok(fn(r.value))
+}
+
+// AndThen returns the Result of fn applied to the value of r, or r's error without calling
+// fn, to chain steps that can each fail.
+//
+// Examples:
+//
+//	user := AndThen(ResultOf(parseID(s)), func(id int) Result[User] { return ResultOf(db.FindUser(ctx, id)) })
+func AndThen[T, U any](r Result[T], fn func(T) Result[U]) Result[U] {
+	if r.err != nil {
+		return Err[U](r.err)
+	}
+	return fn(r.value)
+}
@@ -0,0 +1,123 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Defaults used by Retry and RetryValue when no option overrides them.
+const (
+	defaultRetryBase = 100 * time.Millisecond
+	defaultRetryMax  = 10 * time.Second
+)
+
+// retryOptions holds the settings applied by RetryOption values.
+type retryOptions struct {
+	base, max time.Duration
+	jitter    float64
+	retryIf   func(error) bool
+	onRetry   func(attempt int, err error, delay time.Duration)
+}
+
+// RetryOption configures the behavior of Retry and RetryValue.
+type RetryOption func(*retryOptions)
+
+// WithBackoff sets the delay before the first retry to base, doubling for each retry after
+// that up to max, as computed by BackoffDuration. The default is 100ms, capped at 10s.
+// A base of zero retries immediately.
+func WithBackoff(base, max time.Duration) RetryOption {
+	return func(o *retryOptions) {
+		o.base = base
+		o.max = max
+	}
+}
+
+// WithJitter sets the fraction of each delay that is randomized, as for BackoffDuration.
+// The default is FullJitter; use NoJitter for fixed delays.
+func WithJitter(jitter float64) RetryOption {
+	return func(o *retryOptions) {
+		o.jitter = jitter
+	}
+}
+
+// WithRetryIf makes Retry retry only the errors for which retryIf returns true, returning any
+// other error immediately. By default every error is retried.
+//
+// Examples:
+//
+//	WithRetryIf(func(err error) bool { return !errors.Is(err, ErrNotFound) })
+func WithRetryIf(retryIf func(err error) bool) RetryOption {
+	return func(o *retryOptions) {
+		o.retryIf = retryIf
+	}
+}
+
+// WithOnRetry sets a function called before each retry with the number of the attempt that
+// failed, counting from 1, its error, and the delay before the next attempt, for logging.
+func WithOnRetry(onRetry func(attempt int, err error, delay time.Duration)) RetryOption {
+	return func(o *retryOptions) {
+		o.onRetry = onRetry
+	}
+}
+
+// Retry calls fn until it returns nil, up to attempts times in total, waiting between attempts
+// with exponential backoff and jitter (100ms doubling up to 10s, with full jitter, unless
+// configured with WithBackoff and WithJitter). An attempts value below 1 is treated as 1.
+// It returns nil once fn succeeds, the error of the last attempt if all of them fail, or at
+// once an error that WithRetryIf rejects. If ctx is done before fn succeeds, Retry stops waiting
+// and returns ctx's error joined with the last error from fn, so errors.Is matches either.
+//
+// Examples:
+//
+//	err := Retry(ctx, 5, func() error {
+//		return client.Ping()
+//	}, WithBackoff(time.Second, 30*time.Second), WithRetryIf(isTemporary))
+func Retry(ctx context.Context, attempts int, fn func() error, opts ...RetryOption) error {
+	_, err := RetryValue(ctx, attempts, func() (struct{}, error) {
+		return struct{}{}, fn()
+	}, opts...)
+	return err
+}
+
+// RetryValue is like Retry for functions that also return a value. It returns the value from
+// the successful attempt, or the zero value of T with the error.
+//
+// Examples:
+//
+//	resp, err := RetryValue(ctx, 3, func() (*http.Response, error) {
+//		return http.Get(url)
+//	})
+func RetryValue[T any](ctx context.Context, attempts int, fn func() (T, error), opts ...RetryOption) (T, error) {
+	o := retryOptions{base: defaultRetryBase, max: defaultRetryMax, jitter: FullJitter}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	attempts = max(attempts, 1)
+
+	var zero T
+	if err := ctx.Err(); err != nil {
+		return zero, err
+	}
+	for attempt := 1; ; attempt++ {
+		v, err := fn()
+		if err == nil {
+			return v, nil
+		}
+		if attempt >= attempts || (o.retryIf != nil && !o.retryIf(err)) {
+			return zero, err
+		}
+
+		delay := BackoffDuration(attempt-1, o.base, o.max, o.jitter)
+		if o.onRetry != nil {
+			o.onRetry(attempt, err, delay)
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return zero, errors.Join(ctx.Err(), err)
+		case <-timer.C:
+		}
+	}
+}
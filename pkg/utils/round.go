@@ -0,0 +1,148 @@
+package utils
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+)
+
+// RoundingMode selects how a value is rounded when it lies between two representable results.
+type RoundingMode int
+
+const (
+	// RoundHalfAwayFromZero rounds to the nearest value, with ties rounded away from zero (like math.Round).
+	RoundHalfAwayFromZero RoundingMode = iota
+	// RoundHalfEven rounds to the nearest value, with ties rounded to the even neighbour (banker's rounding).
+	RoundHalfEven
+	// RoundHalfTowardZero rounds to the nearest value, with ties rounded toward zero.
+	RoundHalfTowardZero
+	// RoundTowardZero truncates any extra digits.
+	RoundTowardZero
+	// RoundAwayFromZero rounds any extra digits away from zero.
+	RoundAwayFromZero
+	// RoundFloor rounds toward negative infinity.
+	RoundFloor
+	// RoundCeil rounds toward positive infinity.
+	RoundCeil
+)
+
+// roundRatToInt rounds the rational number r to an integer using the given mode.
+func roundRatToInt(r *big.Rat, mode RoundingMode) *big.Int {
+	num, den := r.Num(), r.Denom()
+	q, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+	if rem.Sign() == 0 {
+		return q
+	}
+	sign := int64(num.Sign())
+
+	var up bool
+	switch mode {
+	case RoundTowardZero:
+		up = false
+	case RoundAwayFromZero:
+		up = true
+	case RoundFloor:
+		up = sign < 0
+	case RoundCeil:
+		up = sign > 0
+	default:
+		twice := new(big.Int).Abs(rem)
+		twice.Lsh(twice, 1)
+		switch c := twice.Cmp(den); {
+		case c > 0:
+			up = true
+		case c < 0:
+			up = false
+		case mode == RoundHalfEven:
+			up = q.Bit(0) == 1
+		default:
+			up = mode == RoundHalfAwayFromZero
+		}
+	}
+	if up {
+		q.Add(q, big.NewInt(sign))
+	}
+	return q
+}
+
+// RoundToMode rounds f to the given number of decimal places using the specified rounding mode.
+// Rounding is performed on the shortest decimal representation of f, so values such as 1.005
+// round the way they are written rather than the way they are stored in binary.
+// A negative number of decimals rounds to the left of the decimal point.
+// NaN and infinite values are returned unchanged.
+//
+// Examples:
+//
+//	RoundToMode(2.345, 2, RoundHalfAwayFromZero) == 2.35
+//	RoundToMode(2.345, 2, RoundHalfEven) == 2.34
+//	RoundToMode(2.355, 2, RoundHalfEven) == 2.36
+//	RoundToMode(1234.5, -2, RoundHalfAwayFromZero) == 1200
+func RoundToMode(f float64, decimals int, mode RoundingMode) float64 {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return f
+	}
+	r, ok := new(big.Rat).SetString(strconv.FormatFloat(f, 'f', -1, 64))
+	if !ok {
+		return f
+	}
+	scale := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(Abs(decimals))), nil))
+	if decimals >= 0 {
+		r.Mul(r, scale)
+	} else {
+		r.Quo(r, scale)
+	}
+
+	r.SetInt(roundRatToInt(r, mode))
+	if decimals >= 0 {
+		r.Quo(r, scale)
+	} else {
+		r.Mul(r, scale)
+	}
+	result, _ := r.Float64()
+	if result == 0 {
+		return math.Copysign(0, f)
+	}
+	return result
+}
+
+// RoundTo rounds f to the given number of decimal places, with ties rounded away from zero.
+//
+// Examples:
+//
+//	RoundTo(1.005, 2) == 1.01
+//	RoundTo(-2.5, 0) == -3
+//	RoundTo(3.14159, 3) == 3.142
+func RoundTo(f float64, decimals int) float64 {
+	return RoundToMode(f, decimals, RoundHalfAwayFromZero)
+}
+
+// BankersRoundTo rounds f to the given number of decimal places, with ties rounded to the even digit.
+//
+// Examples:
+//
+//	BankersRoundTo(2.5, 0) == 2
+//	BankersRoundTo(3.5, 0) == 4
+//	BankersRoundTo(0.125, 2) == 0.12
+func BankersRoundTo(f float64, decimals int) float64 {
+	return RoundToMode(f, decimals, RoundHalfEven)
+}
+
+// FloorTo rounds f down (toward negative infinity) to the given number of decimal places.
+//
+// Examples:
+//
+//	FloorTo(2.679, 2) == 2.67
+//	FloorTo(-2.671, 2) == -2.68
+func FloorTo(f float64, decimals int) float64 {
+	return RoundToMode(f, decimals, RoundFloor)
+}
+
+// CeilTo rounds f up (toward positive infinity) to the given number of decimal places.
+//
+// Examples:
+//
+//	CeilTo(2.671, 2) == 2.68
+//	CeilTo(-2.679, 2) == -2.67
+func CeilTo(f float64, decimals int) float64 {
+	return RoundToMode(f, decimals, RoundCeil)
+}
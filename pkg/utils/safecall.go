@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// PanicError is the error SafeCall and SafeCallValue return when the function panics. The
+// other functions in this package that recover panics, such as Async and ParallelForEach, report
+// them as a PanicError too.
+type PanicError struct {
+	Value any    // the value passed to panic
+	Stack []byte // the stack of the panicking goroutine, as formatted by runtime/debug.Stack
+}
+
+// Error returns "panic: " followed by the panic value, without the stack.
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Unwrap returns the panic value if it is an error, such as a runtime.Error, for errors.Is
+// and errors.As.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// SafeCall calls fn and returns its error, or a *PanicError if fn panics, so that a bug in
+// plugin or handler code fails that call instead of crashing the program.
+//
+// Examples:
+//
+//	err := SafeCall(func() error { return plugin.Run(ctx) })
+//	var perr *PanicError
+//	if errors.As(err, &perr) {
+//		log.Printf("plugin crashed: %v\n%s", perr.Value, perr.Stack)
+//	}
+func SafeCall(fn func() error) (err error) {
+	_, err = SafeCallValue(func() (struct{}, error) {
+		return struct{}{}, fn()
+	})
+	return err
+}
+
+// SafeCallValue is SafeCall for functions that also return a value. It returns the zero value
+// of T with the *PanicError if fn panics.
+//
+// Examples:
+//
+//	out, err := SafeCallValue(func() ([]byte, error) { return render(tmpl, data) })
+func SafeCallValue[T any](fn func() (T, error)) (value T, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			var zero T
+			value, err = zero, &PanicError{Value: r, Stack: debug.Stack()}
+		}
+	}()
+	return fn()
+}
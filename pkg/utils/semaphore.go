@@ -0,0 +1,172 @@
+package utils
+
+import (
+	"container/list"
+	"context"
+	"sync"
+)
+
+// Semaphore is a weighted semaphore: a pool of size units, of which each holder acquires
+// some number, such as 1 per open file or the size of a buffer in megabytes. Waiters are
+// served in the order they call Acquire, so a large request is not starved by small ones.
+// It is safe for concurrent use.
+type Semaphore struct {
+	mu      sync.Mutex
+	size    int64
+	cur     int64
+	waiters list.List // of semaphoreWaiter
+}
+
+// semaphoreWaiter is an Acquire call waiting for n units; ready is closed once they are granted.
+type semaphoreWaiter struct {
+	n     int64
+	ready chan struct{}
+}
+
+// NewSemaphore returns a Semaphore with size units, all available.
+//
+// Examples:
+//
+//	files := NewSemaphore(64) // at most 64 open files
+//	if err := files.Acquire(ctx, 1); err != nil {
+//		return err
+//	}
+//	defer files.Release(1)
+func NewSemaphore(size int64) *Semaphore {
+	return &Semaphore{size: size}
+}
+
+// Acquire acquires n units, blocking until they are available or ctx is done. On success it
+// returns nil; otherwise it returns ctx's error and acquires nothing. A request for more than
+// the semaphore's size blocks until ctx is done.
+func (s *Semaphore) Acquire(ctx context.Context, n int64) error {
+	s.mu.Lock()
+	if s.size-s.cur >= n && s.waiters.Len() == 0 {
+		s.cur += n
+		s.mu.Unlock()
+		return nil
+	}
+	if n > s.size {
+		s.mu.Unlock()
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	w := semaphoreWaiter{n: n, ready: make(chan struct{})}
+	elem := s.waiters.PushBack(w)
+	s.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return nil
+	case <-ctx.Done():
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		select {
+		case <-w.ready:
+			// Acquired just as ctx was done; give the units back.
+			s.cur -= n
+		default:
+			isFront := s.waiters.Front() == elem
+			s.waiters.Remove(elem)
+			if !isFront {
+				return ctx.Err()
+			}
+		}
+		// Removing the front waiter, or releasing units, may let the next ones in.
+		s.notifyWaiters()
+		return ctx.Err()
+	}
+}
+
+// TryAcquire acquires n units if they are available now and no one is waiting,
+// and reports whether it did.
+func (s *Semaphore) TryAcquire(n int64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.size-s.cur >= n && s.waiters.Len() == 0 {
+		s.cur += n
+		return true
+	}
+	return false
+}
+
+// Release releases n units. It panics if that is more than are held, which
+// indicates a bug in the caller.
+func (s *Semaphore) Release(n int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cur -= n
+	if s.cur < 0 {
+		panic("utils: semaphore released more than held")
+	}
+	s.notifyWaiters()
+}
+
+// notifyWaiters grants units to waiters in order, for as long as the first one fits.
+// s.mu must be held.
+func (s *Semaphore) notifyWaiters() {
+	for {
+		front := s.waiters.Front()
+		if front == nil {
+			return
+		}
+		w := front.Value.(semaphoreWaiter)
+		if s.size-s.cur < w.n {
+			return
+		}
+		s.cur += w.n
+		s.waiters.Remove(front)
+		close(w.ready)
+	}
+}
+
+// LimitGroup runs functions in goroutines, at most a fixed number at a time, and waits for
+// them to finish: a sync.WaitGroup bounded by a Semaphore.
+type LimitGroup struct {
+	sem *Semaphore
+	wg  sync.WaitGroup
+}
+
+// NewLimitGroup returns a LimitGroup running at most limit functions at once.
+// A limit below 1 is treated as 1.
+//
+// Examples:
+//
+//	g := NewLimitGroup(16)
+//	for _, path := range paths {
+//		g.Go(func() { checksum(path) })
+//	}
+//	g.Wait()
+func NewLimitGroup(limit int) *LimitGroup {
+	return &LimitGroup{sem: NewSemaphore(int64(max(limit, 1)))}
+}
+
+// Go runs fn in a new goroutine, first blocking until fewer than the limit are running.
+func (g *LimitGroup) Go(fn func()) {
+	_ = g.sem.Acquire(context.Background(), 1)
+	g.start(fn)
+}
+
+// TryGo runs fn in a new goroutine if fewer than the limit are running, and reports whether it did.
+func (g *LimitGroup) TryGo(fn func()) bool {
+	if !g.sem.TryAcquire(1) {
+		return false
+	}
+	g.start(fn)
+	return true
+}
+
+// start runs fn in a goroutine holding one unit of g's semaphore.
+func (g *LimitGroup) start(fn func()) {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		defer g.sem.Release(1)
+		fn()
+	}()
+}
+
+// Wait blocks until every function started by Go and TryGo has returned.
+func (g *LimitGroup) Wait() {
+	g.wg.Wait()
+}
@@ -0,0 +1,402 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Semver is a version number following Semantic Versioning 2.0.0 (https://semver.org).
+type Semver struct {
+	Major, Minor, Patch uint64
+	// Prerelease holds the dot-separated identifiers after "-", such as "rc.1". It is empty for releases.
+	Prerelease string
+	// Build holds the metadata after "+", such as "build.5". It is ignored when comparing versions.
+	Build string
+}
+
+// ParseSemver parses a semantic version such as "1.4.2", "2.0.0-rc.1" or "1.0.0+build.5".
+// A leading "v" is accepted, as in Git tags. All three numeric components are required,
+// and numeric identifiers may not have leading zeros.
+// It returns an error if s is not a valid semantic version.
+//
+// Examples:
+//
+//	ParseSemver("1.4.2") == (Semver{Major: 1, Minor: 4, Patch: 2}, nil)
+//	ParseSemver("v2.0.0-rc.1+exp.sha.5114f85") returns Prerelease "rc.1" and Build "exp.sha.5114f85"
+//	ParseSemver("1.4") returns an error
+//	ParseSemver("01.4.2") returns an error
+func ParseSemver(s string) (Semver, error) {
+	var v Semver
+	rest := strings.TrimPrefix(s, "v")
+	if i := strings.IndexByte(rest, '+'); i >= 0 {
+		v.Build = rest[i+1:]
+		rest = rest[:i]
+		if !validSemverIdentifiers(v.Build, false) {
+			return Semver{}, fmt.Errorf("invalid build metadata in version %q", s)
+		}
+	}
+	if i := strings.IndexByte(rest, '-'); i >= 0 {
+		v.Prerelease = rest[i+1:]
+		rest = rest[:i]
+		if !validSemverIdentifiers(v.Prerelease, true) {
+			return Semver{}, fmt.Errorf("invalid pre-release in version %q", s)
+		}
+	}
+
+	parts := strings.Split(rest, ".")
+	if len(parts) != 3 {
+		return Semver{}, fmt.Errorf("invalid version %q: must have the form MAJOR.MINOR.PATCH", s)
+	}
+	nums := [3]*uint64{&v.Major, &v.Minor, &v.Patch}
+	for i, part := range parts {
+		n, err := parseSemverNumber(part)
+		if err != nil {
+			return Semver{}, fmt.Errorf("invalid version %q: %w", s, err)
+		}
+		*nums[i] = n
+	}
+	return v, nil
+}
+
+// String returns the version in canonical form, without a leading "v".
+func (v Semver) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+	if v.Build != "" {
+		s += "+" + v.Build
+	}
+	return s
+}
+
+// Compare returns -1, 0 or 1 depending on whether v has lower, equal or higher
+// precedence than other. Pre-releases sort before the corresponding release,
+// and build metadata is ignored, as the specification requires.
+//
+// Examples:
+//
+//	v1.0.0-alpha < v1.0.0-alpha.1 < v1.0.0-beta < v1.0.0-rc.1 < v1.0.0 < v1.0.1
+func (v Semver) Compare(other Semver) int {
+	for _, pair := range [3][2]uint64{{v.Major, other.Major}, {v.Minor, other.Minor}, {v.Patch, other.Patch}} {
+		if pair[0] != pair[1] {
+			if pair[0] < pair[1] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return comparePrerelease(v.Prerelease, other.Prerelease)
+}
+
+// CompareSemver parses a and b and compares them with Semver.Compare.
+// It returns an error if either is not a valid semantic version.
+//
+// Examples:
+//
+//	CompareSemver("1.4.2", "1.10.0") == (-1, nil)
+//	CompareSemver("2.0.0", "2.0.0-rc.1") == (1, nil)
+//	CompareSemver("1.0.0+a", "1.0.0+b") == (0, nil)
+//	CompareSemver("1.0", "1.0.0") returns an error
+func CompareSemver(a, b string) (int, error) {
+	va, err := ParseSemver(a)
+	if err != nil {
+		return 0, err
+	}
+	vb, err := ParseSemver(b)
+	if err != nil {
+		return 0, err
+	}
+	return va.Compare(vb), nil
+}
+
+// SatisfiesConstraint reports whether version satisfies constraint.
+//
+// A constraint is a list of comparators separated by spaces or commas, all of which must hold;
+// alternatives are separated by "||". Each comparator is an operator followed by a version,
+// where the minor and patch numbers may be omitted or written as "x" or "*":
+//   - =, !=, >, >=, <, <=: compare against the version, with missing parts widening the range,
+//     so "<=1.3" allows every 1.3.x and ">1.3" starts at 1.4.0 (!= needs a full version);
+//   - no operator: the same as "=", so "1.3" and "1.3.x" mean any 1.3 release and "*" means anything;
+//   - ~1.2.3: patch updates, >=1.2.3 <1.3.0 (~1 allows any 1.x.x);
+//   - ^1.2.3: updates that do not change the leftmost non-zero number, >=1.2.3 <2.0.0
+//     (^0.2.3 is <0.3.0 and ^0.0.3 is <0.0.4).
+//
+// A pre-release version only satisfies a set of comparators if one of them names a pre-release
+// of the same MAJOR.MINOR.PATCH, so "2.0.0-beta" does not satisfy "<2.0" but satisfies ">=2.0.0-alpha".
+// It returns an error if version or constraint cannot be parsed.
+//
+// Examples:
+//
+//	SatisfiesConstraint("1.4.2", ">=1.3 <2.0") == (true, nil)
+//	SatisfiesConstraint("2.1.0", ">=1.3 <2.0") == (false, nil)
+//	SatisfiesConstraint("1.2.9", "~1.2.3") == (true, nil)
+//	SatisfiesConstraint("0.3.0", "^0.2.3") == (false, nil)
+//	SatisfiesConstraint("3.0.0", "^1.0 || ^3.0") == (true, nil)
+//	SatisfiesConstraint("1.4.2", ">=banana") returns an error
+func SatisfiesConstraint(version, constraint string) (bool, error) {
+	v, err := ParseSemver(version)
+	if err != nil {
+		return false, err
+	}
+	groups := strings.Split(constraint, "||")
+	satisfied := false
+	// Every alternative is parsed, even after a match, so malformed constraints are always reported.
+	for _, group := range groups {
+		comparators, err := parseSemverComparators(group)
+		if err != nil {
+			return false, err
+		}
+		if !satisfied && semverGroupMatches(v, comparators) {
+			satisfied = true
+		}
+	}
+	return satisfied, nil
+}
+
+// semverComparator is a primitive comparison such as ">=1.3.0".
+type semverComparator struct {
+	op string
+	v  Semver
+}
+
+// matches reports whether v satisfies the comparator.
+func (c semverComparator) matches(v Semver) bool {
+	cmp := v.Compare(c.v)
+	switch c.op {
+	case "=":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	default:
+		return cmp <= 0
+	}
+}
+
+// semverGroupMatches reports whether v satisfies all comparators, applying the pre-release rule
+// described in SatisfiesConstraint.
+func semverGroupMatches(v Semver, comparators []semverComparator) bool {
+	prereleaseAllowed := v.Prerelease == ""
+	for _, c := range comparators {
+		if !c.matches(v) {
+			return false
+		}
+		if c.v.Prerelease != "" && c.v.Major == v.Major && c.v.Minor == v.Minor && c.v.Patch == v.Patch {
+			prereleaseAllowed = true
+		}
+	}
+	return prereleaseAllowed
+}
+
+// parseSemverComparators parses one "||" alternative of a constraint into primitive comparators.
+func parseSemverComparators(group string) ([]semverComparator, error) {
+	fields := strings.FieldsFunc(group, func(r rune) bool { return r == ' ' || r == ',' || r == '\t' })
+	if len(fields) == 0 {
+		return nil, errors.New("empty version constraint")
+	}
+
+	var result []semverComparator
+	for _, field := range fields {
+		op := ""
+		for _, candidate := range []string{">=", "<=", "!=", ">", "<", "=", "~", "^"} {
+			if strings.HasPrefix(field, candidate) {
+				op = candidate
+				break
+			}
+		}
+		v, parts, err := parsePartialSemver(strings.TrimPrefix(field, op))
+		if err != nil {
+			return nil, fmt.Errorf("invalid version constraint %q: %w", field, err)
+		}
+		if parts == 0 {
+			// A bare wildcard matches every version.
+			continue
+		}
+		if op == "!=" && parts < 3 {
+			return nil, fmt.Errorf("invalid version constraint %q: != needs a full version", field)
+		}
+		// lower is the smallest version matching the partial version; upper is the first one past it.
+		lower, upper := v, nextPartialSemver(v, parts)
+
+		switch op {
+		case "", "=":
+			if parts == 3 {
+				result = append(result, semverComparator{"=", v})
+			} else {
+				result = append(result, semverComparator{">=", lower}, semverComparator{"<", upper})
+			}
+		case "!=", ">=", "<":
+			result = append(result, semverComparator{op, v})
+		case ">":
+			if parts == 3 {
+				result = append(result, semverComparator{">", v})
+			} else {
+				result = append(result, semverComparator{">=", upper})
+			}
+		case "<=":
+			if parts == 3 {
+				result = append(result, semverComparator{"<=", v})
+			} else {
+				result = append(result, semverComparator{"<", upper})
+			}
+		case "~":
+			result = append(result, semverComparator{">=", lower}, semverComparator{"<", nextPartialSemver(v, Min(parts, 2))})
+		case "^":
+			// Find the leftmost non-zero among the given parts; everything to its right may change.
+			keep := parts
+			switch {
+			case v.Major != 0 || parts == 1:
+				keep = 1
+			case v.Minor != 0 || parts == 2:
+				keep = 2
+			}
+			result = append(result, semverComparator{">=", lower}, semverComparator{"<", nextPartialSemver(v, keep)})
+		}
+	}
+	return result, nil
+}
+
+// parsePartialSemver parses a version whose minor and patch numbers may be missing
+// or wildcards ("x", "X" or "*"), and returns how many leading numbers were given.
+// Missing numbers are zero. A pre-release or build suffix requires all three numbers.
+func parsePartialSemver(s string) (Semver, int, error) {
+	s = strings.TrimPrefix(s, "v")
+	if strings.ContainsAny(s, "-+") {
+		v, err := ParseSemver(s)
+		return v, 3, err
+	}
+	if s == "" {
+		return Semver{}, 0, errors.New("missing version")
+	}
+	var v Semver
+	nums := [3]*uint64{&v.Major, &v.Minor, &v.Patch}
+	parts := strings.Split(s, ".")
+	if len(parts) > 3 {
+		return Semver{}, 0, errors.New("too many version components")
+	}
+	given := 0
+	for i, part := range parts {
+		if part == "x" || part == "X" || part == "*" {
+			continue // keep going, to reject a number after the wildcard
+		}
+		if given < i {
+			return Semver{}, 0, errors.New("a number cannot follow a wildcard")
+		}
+		n, err := parseSemverNumber(part)
+		if err != nil {
+			return Semver{}, 0, err
+		}
+		*nums[i] = n
+		given++
+	}
+	return v, given, nil
+}
+
+// nextPartialSemver returns the first release after every version matching the first parts numbers of v:
+// 1.3 (parts 2) gives 1.4.0, 1 (parts 1) gives 2.0.0, and a full version gives the next patch.
+func nextPartialSemver(v Semver, parts int) Semver {
+	switch parts {
+	case 1:
+		return Semver{Major: v.Major + 1}
+	case 2:
+		return Semver{Major: v.Major, Minor: v.Minor + 1}
+	}
+	return Semver{Major: v.Major, Minor: v.Minor, Patch: v.Patch + 1}
+}
+
+// parseSemverNumber parses a numeric version component, rejecting leading zeros.
+func parseSemverNumber(s string) (uint64, error) {
+	if s == "" {
+		return 0, errors.New("empty version component")
+	}
+	if len(s) > 1 && s[0] == '0' {
+		return 0, fmt.Errorf("version component %q has a leading zero", s)
+	}
+	n, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("version component %q is not a number", s)
+	}
+	return n, nil
+}
+
+// validSemverIdentifiers reports whether s is a non-empty list of dot-separated identifiers made of
+// ASCII letters, digits and hyphens. For pre-releases, numeric identifiers may not have leading zeros.
+func validSemverIdentifiers(s string, prerelease bool) bool {
+	if s == "" {
+		return false
+	}
+	for _, id := range strings.Split(s, ".") {
+		if id == "" {
+			return false
+		}
+		numeric := true
+		for i := 0; i < len(id); i++ {
+			c := id[i]
+			switch {
+			case c >= '0' && c <= '9':
+			case c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '-':
+				numeric = false
+			default:
+				return false
+			}
+		}
+		if prerelease && numeric && len(id) > 1 && id[0] == '0' {
+			return false
+		}
+	}
+	return true
+}
+
+// comparePrerelease compares pre-release strings by semver precedence:
+// a release (empty string) ranks above any pre-release, numeric identifiers compare numerically
+// and rank below alphanumeric ones, and a longer list wins when all shared identifiers are equal.
+func comparePrerelease(a, b string) int {
+	if a == b {
+		return 0
+	}
+	if a == "" {
+		return 1
+	}
+	if b == "" {
+		return -1
+	}
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		x, y := as[i], bs[i]
+		xn, xerr := strconv.ParseUint(x, 10, 64)
+		yn, yerr := strconv.ParseUint(y, 10, 64)
+		switch {
+		case xerr == nil && yerr == nil:
+			if xn != yn {
+				if xn < yn {
+					return -1
+				}
+				return 1
+			}
+		case xerr == nil:
+			return -1
+		case yerr == nil:
+			return 1
+		case x != y:
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	switch {
+	case len(as) < len(bs):
+		return -1
+	case len(as) > len(bs):
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,225 @@
+package utils
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"reflect"
+	"sort"
+)
+
+// Set is an unordered collection of distinct values, backed by a map, so the zero value
+// (nil) is an empty set that can be read but not added to; use NewSet or make.
+// Like maps, sets are not safe for concurrent writes.
+type Set[T comparable] map[T]struct{}
+
+// NewSet returns a set holding items.
+//
+// Examples:
+//
+//	s := NewSet("a", "b", "a")
+//	s.Len() == 2
+func NewSet[T comparable](items ...T) Set[T] {
+	s := make(Set[T], len(items))
+	s.Add(items...)
+	return s
+}
+
+// SetFromSlice returns a set holding the elements of items, dropping duplicates.
+//
+// Examples:
+//
+//	SetFromSlice([]int{3, 1, 3, 2}).Len() == 3
+func SetFromSlice[T comparable](items []T) Set[T] {
+	return NewSet(items...)
+}
+
+// Add adds items to s.
+func (s Set[T]) Add(items ...T) {
+	for _, item := range items {
+		s[item] = struct{}{}
+	}
+}
+
+// Remove removes items from s, ignoring those it does not hold.
+func (s Set[T]) Remove(items ...T) {
+	for _, item := range items {
+		delete(s, item)
+	}
+}
+
+// Has reports whether s holds item.
+func (s Set[T]) Has(item T) bool {
+	_, ok := s[item]
+	return ok
+}
+
+// Len returns the number of elements in s.
+func (s Set[T]) Len() int {
+	return len(s)
+}
+
+// Clone returns a copy of s, which can be changed without affecting s.
+func (s Set[T]) Clone() Set[T] {
+	c := make(Set[T], len(s))
+	for item := range s {
+		c[item] = struct{}{}
+	}
+	return c
+}
+
+// Union returns a new set with the elements that are in s, other, or both.
+//
+// Examples:
+//
+//	NewSet(1, 2).Union(NewSet(2, 3)) holds 1, 2 and 3
+func (s Set[T]) Union(other Set[T]) Set[T] {
+	u := make(Set[T], max(len(s), len(other)))
+	for item := range s {
+		u[item] = struct{}{}
+	}
+	for item := range other {
+		u[item] = struct{}{}
+	}
+	return u
+}
+
+// Intersect returns a new set with the elements that are in both s and other.
+//
+// Examples:
+//
+//	NewSet(1, 2).Intersect(NewSet(2, 3)) holds 2
+func (s Set[T]) Intersect(other Set[T]) Set[T] {
+	small, large := s, other
+	if len(large) < len(small) {
+		small, large = large, small
+	}
+	i := make(Set[T])
+	for item := range small {
+		if large.Has(item) {
+			i[item] = struct{}{}
+		}
+	}
+	return i
+}
+
+// Difference returns a new set with the elements of s that are not in other.
+//
+// Examples:
+//
+//	NewSet(1, 2).Difference(NewSet(2, 3)) holds 1
+func (s Set[T]) Difference(other Set[T]) Set[T] {
+	d := make(Set[T])
+	for item := range s {
+		if !other.Has(item) {
+			d[item] = struct{}{}
+		}
+	}
+	return d
+}
+
+// IsSubsetOf reports whether every element of s is in other.
+func (s Set[T]) IsSubsetOf(other Set[T]) bool {
+	if len(s) > len(other) {
+		return false
+	}
+	for item := range s {
+		if !other.Has(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal reports whether s and other hold the same elements.
+func (s Set[T]) Equal(other Set[T]) bool {
+	return len(s) == len(other) && s.IsSubsetOf(other)
+}
+
+// All returns an iterator over the elements of s, in no particular order, for use with
+// range-over-func. Its type is that of iter.Seq[T], as for DayRange.
+//
+// Examples:
+//
+//	for id := range active.All() {
+//		notify(id)
+//	}
+func (s Set[T]) All() func(yield func(T) bool) {
+	return func(yield func(T) bool) {
+		for item := range s {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// ToSlice returns the elements of s in a new slice, in no particular order.
+func (s Set[T]) ToSlice() []T {
+	items := make([]T, 0, len(s))
+	for item := range s {
+		items = append(items, item)
+	}
+	return items
+}
+
+// MarshalJSON encodes s as a JSON array, so that equal sets always encode the same way.
+// Elements of an ordered kind, such as numbers and strings, are sorted by value; others are
+// sorted by their JSON encoding.
+func (s Set[T]) MarshalJSON() ([]byte, error) {
+	items := s.ToSlice()
+	compare, ordered := orderedCompare[T]()
+	if ordered {
+		sort.Slice(items, func(i, j int) bool { return compare(items[i], items[j]) < 0 })
+	}
+	encoded := make([][]byte, 0, len(items))
+	for _, item := range items {
+		b, err := json.Marshal(item)
+		if err != nil {
+			return nil, err
+		}
+		encoded = append(encoded, b)
+	}
+	if !ordered {
+		sort.Slice(encoded, func(i, j int) bool { return bytes.Compare(encoded[i], encoded[j]) < 0 })
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	buf.Write(bytes.Join(encoded, []byte{','}))
+	buf.WriteByte(']')
+	return buf.Bytes(), nil
+}
+
+// orderedCompare returns a function comparing values of T with cmp.Compare, and true, if T's
+// underlying type is an integer, floating-point or string type; otherwise it returns false.
+func orderedCompare[T any]() (func(a, b T) int, bool) {
+	switch reflect.TypeFor[T]().Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return func(a, b T) int { return cmp.Compare(reflect.ValueOf(a).Int(), reflect.ValueOf(b).Int()) }, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return func(a, b T) int { return cmp.Compare(reflect.ValueOf(a).Uint(), reflect.ValueOf(b).Uint()) }, true
+	case reflect.Float32, reflect.Float64:
+		return func(a, b T) int { return cmp.Compare(reflect.ValueOf(a).Float(), reflect.ValueOf(b).Float()) }, true
+	case reflect.String:
+		return func(a, b T) int { return cmp.Compare(reflect.ValueOf(a).String(), reflect.ValueOf(b).String()) }, true
+	}
+	return nil, false
+}
+
+// UnmarshalJSON decodes s from a JSON array, dropping duplicates, and adds its elements to
+// s, allocating it if needed. A JSON null leaves s unchanged.
+func (s *Set[T]) UnmarshalJSON(data []byte) error {
+	var items []T
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+	if items == nil {
+		return nil
+	}
+	if *s == nil {
+		*s = make(Set[T], len(items))
+	}
+	s.Add(items...)
+	return nil
+}
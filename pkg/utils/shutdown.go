@@ -0,0 +1,210 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Shutdown coordinates a graceful shutdown: components register cleanup hooks, and Wait
+// blocks until SIGINT or SIGTERM is received (or Trigger is called), then runs the hooks and
+// reports those that failed or timed out. It is safe for concurrent use.
+type Shutdown struct {
+	mu             sync.Mutex
+	hooks          []shutdownHook
+	defaultTimeout time.Duration
+	trigger        chan struct{}
+	triggerOnce    sync.Once
+}
+
+// shutdownHook is a cleanup function registered with a Shutdown.
+type shutdownHook struct {
+	name     string
+	fn       func(ctx context.Context) error
+	priority int
+	timeout  time.Duration
+}
+
+// HookOption configures a hook registered with Shutdown.Register.
+type HookOption func(*shutdownHook)
+
+// HookPriority sets the order in which a hook runs: hooks run in ascending priority, and hooks
+// with the same priority run concurrently. The default priority is 0.
+func HookPriority(priority int) HookOption {
+	return func(h *shutdownHook) {
+		h.priority = priority
+	}
+}
+
+// HookTimeout sets how long a hook may run before it is reported as timed out, overriding
+// the Shutdown's default timeout.
+func HookTimeout(timeout time.Duration) HookOption {
+	return func(h *shutdownHook) {
+		h.timeout = timeout
+	}
+}
+
+// HookResult is the outcome of a hook that failed or timed out during a shutdown.
+type HookResult struct {
+	Name     string
+	Err      error // the hook's error, or context.DeadlineExceeded if it timed out
+	TimedOut bool
+	Duration time.Duration // how long the hook ran, or its timeout if it timed out
+}
+
+// ShutdownError reports the hooks that failed or timed out during a shutdown.
+type ShutdownError struct {
+	Failed []HookResult // in the order the hooks were run
+}
+
+func (e *ShutdownError) Error() string {
+	parts := make([]string, len(e.Failed))
+	for i, r := range e.Failed {
+		if r.TimedOut {
+			parts[i] = fmt.Sprintf("hook %q timed out after %s", r.Name, r.Duration)
+		} else {
+			parts[i] = fmt.Sprintf("hook %q failed: %v", r.Name, r.Err)
+		}
+	}
+	return "shutdown: " + strings.Join(parts, "; ")
+}
+
+// Unwrap returns the errors of the failed hooks, for errors.Is and errors.As.
+func (e *ShutdownError) Unwrap() []error {
+	errs := make([]error, len(e.Failed))
+	for i, r := range e.Failed {
+		errs[i] = r.Err
+	}
+	return errs
+}
+
+// TimedOut returns the names of the hooks that timed out.
+func (e *ShutdownError) TimedOut() []string {
+	var names []string
+	for _, r := range e.Failed {
+		if r.TimedOut {
+			names = append(names, r.Name)
+		}
+	}
+	return names
+}
+
+// NewShutdown returns a Shutdown whose hooks time out after defaultTimeout unless registered
+// with HookTimeout. A defaultTimeout of zero or less means 30 seconds.
+//
+// Examples:
+//
+//	sd := NewShutdown(10 * time.Second)
+//	sd.Register("http", server.Shutdown)
+//	sd.Register("db", func(ctx context.Context) error { return db.Close() }, HookPriority(10))
+//	if err := sd.Wait(); err != nil {
+//		log.Print(err) // e.g. shutdown: hook "db" timed out after 10s
+//	}
+func NewShutdown(defaultTimeout time.Duration) *Shutdown {
+	if defaultTimeout <= 0 {
+		defaultTimeout = 30 * time.Second
+	}
+	return &Shutdown{defaultTimeout: defaultTimeout, trigger: make(chan struct{})}
+}
+
+// Register adds a cleanup hook named name, for reporting. fn receives a context that expires
+// at the hook's timeout, and should return by then; a hook that does not is reported as timed
+// out and left running while the shutdown carries on.
+func (s *Shutdown) Register(name string, fn func(ctx context.Context) error, opts ...HookOption) {
+	h := shutdownHook{name: name, fn: fn, timeout: s.defaultTimeout}
+	for _, opt := range opts {
+		opt(&h)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hooks = append(s.hooks, h)
+}
+
+// Trigger starts the shutdown as if a signal had been received, unblocking Wait.
+// Calling it more than once has no further effect.
+func (s *Shutdown) Trigger() {
+	s.triggerOnce.Do(func() { close(s.trigger) })
+}
+
+// Wait blocks until SIGINT or SIGTERM is received or Trigger is called, then runs the hooks
+// with Run and returns its result.
+func (s *Shutdown) Wait() error {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+	select {
+	case <-signals:
+	case <-s.trigger:
+	}
+	return s.Run()
+}
+
+// Run runs the registered hooks in ascending priority order, each group of hooks with the
+// same priority concurrently, and waits for each group to finish or time out before starting
+// the next. It returns a *ShutdownError listing the hooks that failed or timed out, or nil.
+func (s *Shutdown) Run() error {
+	s.mu.Lock()
+	hooks := append([]shutdownHook(nil), s.hooks...)
+	s.mu.Unlock()
+	sort.SliceStable(hooks, func(i, j int) bool { return hooks[i].priority < hooks[j].priority })
+
+	var failed []HookResult
+	for start := 0; start < len(hooks); {
+		end := start + 1
+		for end < len(hooks) && hooks[end].priority == hooks[start].priority {
+			end++
+		}
+		group := hooks[start:end]
+		results := make([]HookResult, len(group))
+		var wg sync.WaitGroup
+		wg.Add(len(group))
+		for i, h := range group {
+			go func() {
+				defer wg.Done()
+				results[i] = runShutdownHook(h)
+			}()
+		}
+		wg.Wait()
+		for _, r := range results {
+			if r.Err != nil {
+				failed = append(failed, r)
+			}
+		}
+		start = end
+	}
+	if len(failed) > 0 {
+		return &ShutdownError{Failed: failed}
+	}
+	return nil
+}
+
+// runShutdownHook runs h with its timeout. A panic in h is reported as its error, a *PanicError.
+func runShutdownHook(h shutdownHook) HookResult {
+	ctx, cancel := context.WithTimeout(context.Background(), h.timeout)
+	defer cancel()
+
+	begin := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- &PanicError{Value: r, Stack: debug.Stack()}
+			}
+		}()
+		done <- h.fn(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return HookResult{Name: h.name, Err: err, Duration: time.Since(begin)}
+	case <-ctx.Done():
+		return HookResult{Name: h.name, Err: ctx.Err(), TimedOut: true, Duration: h.timeout}
+	}
+}
@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ConvertSlice returns a new slice holding each element of in converted to U with a Go
+// conversion, as U(x). Conversions follow the language rules: floats are truncated toward zero
+// and integers that do not fit in U wrap around, so use SafeConvert on each element when the
+// values may be out of range. A nil input yields nil.
+//
+// Examples:
+//
+//	ConvertSlice[int, float64]([]int{1, 2, 3}) == []float64{1, 2, 3}
+//	ConvertSlice[float64, int]([]float64{1.9, -1.9}) == []int{1, -1}
+//	ConvertSlice[int32, int64](nil) == nil
+func ConvertSlice[T, U Number](in []T) []U {
+	if in == nil {
+		return nil
+	}
+	out := make([]U, len(in))
+	for i, x := range in {
+		out[i] = U(x)
+	}
+	return out
+}
+
+// StringsToInts parses each element of in as a base-10 integer, ignoring surrounding whitespace.
+// It returns an error naming the first element that is not a valid int. A nil input yields nil.
+//
+// Examples:
+//
+//	StringsToInts([]string{"1", " 2", "-3"}) == ([]int{1, 2, -3}, nil)
+//	StringsToInts([]string{"1", "two"}) returns an error
+func StringsToInts(in []string) ([]int, error) {
+	if in == nil {
+		return nil, nil
+	}
+	out := make([]int, len(in))
+	for i, s := range in {
+		n, err := strconv.Atoi(strings.TrimSpace(s))
+		if err != nil {
+			return nil, fmt.Errorf("item %d: invalid integer %q", i, s)
+		}
+		out[i] = n
+	}
+	return out, nil
+}
+
+// IntsToStrings formats each element of in in base 10. A nil input yields nil.
+//
+// Examples:
+//
+//	IntsToStrings([]int{1, -2}) == []string{"1", "-2"}
+func IntsToStrings(in []int) []string {
+	if in == nil {
+		return nil
+	}
+	out := make([]string, len(in))
+	for i, n := range in {
+		out[i] = strconv.Itoa(n)
+	}
+	return out
+}
@@ -0,0 +1,120 @@
+package utils
+
+import (
+	"cmp"
+	"slices"
+	"sort"
+)
+
+// SortedSlice is a slice kept in ascending order as elements are inserted, so that it never
+// needs a full sort, and searches take logarithmic time. Unlike OrderedSet, it keeps equal
+// elements, in the order they were inserted. Create it with NewSortedSlice, or with
+// NewSortedSliceBy to order elements by a key. It is not safe for concurrent writes.
+type SortedSlice[T any] struct {
+	items   []T
+	compare func(a, b T) int
+}
+
+// NewSortedSlice returns a sorted slice holding items, in ascending order.
+//
+// Examples:
+//
+//	s := NewSortedSlice(5, 1, 3)
+//	s.InsertSorted(2)
+//	s.Items() == []int{1, 2, 3, 5}
+func NewSortedSlice[T cmp.Ordered](items ...T) *SortedSlice[T] {
+	return newSortedSlice(cmp.Compare[T], items)
+}
+
+// NewSortedSliceBy returns a sorted slice holding items, in ascending order of key. Elements
+// with equal keys count as equal: Search and Between compare keys only.
+//
+// Examples:
+//
+//	board := NewSortedSliceBy(func(p Player) int { return -p.Score }) // highest score first
+//	board.InsertSorted(Player{"ana", 120}, Player{"bo", 340})
+//	top10 := board.Slice(0, 10)
+func NewSortedSliceBy[T any, K cmp.Ordered](key func(T) K, items ...T) *SortedSlice[T] {
+	return newSortedSlice(func(a, b T) int { return cmp.Compare(key(a), key(b)) }, items)
+}
+
+// newSortedSlice returns a sorted slice ordered by compare holding items.
+func newSortedSlice[T any](compare func(a, b T) int, items []T) *SortedSlice[T] {
+	sorted := slices.Clone(items)
+	slices.SortStableFunc(sorted, compare)
+	return &SortedSlice[T]{items: sorted, compare: compare}
+}
+
+// InsertSorted inserts items at their places in order, each after the elements equal to it.
+func (s *SortedSlice[T]) InsertSorted(items ...T) {
+	for _, item := range items {
+		i := sort.Search(len(s.items), func(i int) bool { return s.compare(s.items[i], item) > 0 })
+		s.items = slices.Insert(s.items, i, item)
+	}
+}
+
+// Search returns the index of the first element equal to item, or the index where item would
+// be inserted and false if there is none.
+//
+// Examples:
+//
+//	NewSortedSlice(10, 20, 20, 30).Search(20) == (1, true)
+//	NewSortedSlice(10, 20, 20, 30).Search(25) == (3, false)
+func (s *SortedSlice[T]) Search(item T) (int, bool) {
+	return slices.BinarySearchFunc(s.items, item, s.compare)
+}
+
+// Contains reports whether s holds an element equal to item.
+func (s *SortedSlice[T]) Contains(item T) bool {
+	_, found := s.Search(item)
+	return found
+}
+
+// At returns the element at index i, counting from the smallest. It panics if i is out of
+// range.
+func (s *SortedSlice[T]) At(i int) T {
+	return s.items[i]
+}
+
+// RemoveAt removes and returns the element at index i. It panics if i is out of range.
+func (s *SortedSlice[T]) RemoveAt(i int) T {
+	item := s.items[i]
+	s.items = slices.Delete(s.items, i, i+1)
+	return item
+}
+
+// Len returns the number of elements in s.
+func (s *SortedSlice[T]) Len() int {
+	return len(s.items)
+}
+
+// Slice returns the elements from index i to j-1, in order, in a new slice. The bounds are
+// clamped to the elements of s, so Slice(0, 10) returns at most the first ten.
+func (s *SortedSlice[T]) Slice(i, j int) []T {
+	i = min(max(i, 0), len(s.items))
+	j = min(max(j, i), len(s.items))
+	return slices.Clone(s.items[i:j])
+}
+
+// Between returns the elements from lo to hi, both inclusive, in order, in a new slice.
+// It returns nil if lo is greater than hi.
+//
+// Examples:
+//
+//	NewSortedSlice(1, 3, 3, 5, 7).Between(3, 5) == []int{3, 3, 5}
+func (s *SortedSlice[T]) Between(lo, hi T) []T {
+	if s.compare(lo, hi) > 0 {
+		return nil
+	}
+	start, _ := s.Search(lo)
+	end := sort.Search(len(s.items), func(i int) bool { return s.compare(s.items[i], hi) > 0 })
+	if start >= end {
+		return nil
+	}
+	return slices.Clone(s.items[start:end])
+}
+
+// Items returns the elements of s, in order, in a new slice.
+func (s *SortedSlice[T]) Items() []T {
+	return slices.Clone(s.items)
+}
@@ -0,0 +1,259 @@
+package utils
+
+import (
+	"errors"
+	"math"
+	"slices"
+)
+
+// ErrEmptySlice is returned by the statistics functions when the input slice has no elements.
+var ErrEmptySlice = errors.New("slice cannot be empty")
+
+// sortedFloats returns a sorted float64 copy of values, leaving the input untouched.
+func sortedFloats[T Number](values []T) []float64 {
+	sorted := make([]float64, len(values))
+	for i, v := range values {
+		sorted[i] = float64(v)
+	}
+	slices.Sort(sorted)
+	return sorted
+}
+
+// interpolate returns the value at fractional rank h (0-based) of an already sorted slice,
+// linearly interpolating between the two closest ranks.
+func interpolate(sorted []float64, h float64) float64 {
+	lo := int(math.Floor(h))
+	hi := int(math.Ceil(h))
+	if lo == hi {
+		return sorted[lo]
+	}
+	return sorted[lo] + (h-float64(lo))*(sorted[hi]-sorted[lo])
+}
+
+// Mean returns the arithmetic mean of a numeric slice.
+// It returns ErrEmptySlice if the slice is empty.
+//
+// Examples:
+//
+//	Mean([]int{1, 2, 3, 4}) == (2.5, nil)
+//	Mean([]float64{10}) == (10, nil)
+//	Mean([]int{}) returns (0, ErrEmptySlice)
+func Mean[T Number](values []T) (float64, error) {
+	if len(values) == 0 {
+		return 0, ErrEmptySlice
+	}
+	var sum neumaierSum
+	for _, v := range values {
+		sum.add(float64(v))
+	}
+	return sum.result() / float64(len(values)), nil
+}
+
+// SumFloat64 returns the sum of a float64 slice using Neumaier's variant of Kahan summation.
+// The running compensation term recovers the low-order bits lost by naive addition, so the
+// result stays accurate when adding millions of values or values of very different magnitudes.
+// It returns 0 for an empty slice.
+//
+// Examples:
+//
+//	SumFloat64([]float64{0.1, 0.2, 0.3}) == 0.6 (naive summation gives 0.6000000000000001)
+//	SumFloat64([]float64{1, 1e100, 1, -1e100}) == 2 (naive summation gives 0)
+//	SumFloat64(nil) == 0
+func SumFloat64(values []float64) float64 {
+	var sum neumaierSum
+	for _, v := range values {
+		sum.add(v)
+	}
+	return sum.result()
+}
+
+// neumaierSum accumulates float64 values with Neumaier compensated summation.
+type neumaierSum struct {
+	sum, compensation float64
+}
+
+// add adds v to the running total.
+func (n *neumaierSum) add(v float64) {
+	t := n.sum + v
+	if math.Abs(n.sum) >= math.Abs(v) {
+		n.compensation += (n.sum - t) + v
+	} else {
+		n.compensation += (v - t) + n.sum
+	}
+	n.sum = t
+}
+
+// result returns the compensated total.
+func (n *neumaierSum) result() float64 {
+	// Once the sum overflows, the compensation is NaN and only the raw sum is meaningful.
+	if math.IsInf(n.sum, 0) {
+		return n.sum
+	}
+	return n.sum + n.compensation
+}
+
+// Median returns the middle value of a numeric slice.
+// For an even number of elements it returns the mean of the two middle values.
+// The input slice is not modified.
+// It returns ErrEmptySlice if the slice is empty.
+//
+// Examples:
+//
+//	Median([]int{3, 1, 2}) == (2, nil)
+//	Median([]int{4, 1, 3, 2}) == (2.5, nil)
+//	Median([]float64{}) returns (0, ErrEmptySlice)
+func Median[T Number](values []T) (float64, error) {
+	if len(values) == 0 {
+		return 0, ErrEmptySlice
+	}
+	return interpolate(sortedFloats(values), float64(len(values)-1)/2), nil
+}
+
+// Mode returns the most frequent values of a numeric slice in ascending order.
+// When several values share the highest frequency, all of them are returned.
+// It returns ErrEmptySlice if the slice is empty.
+//
+// Examples:
+//
+//	Mode([]int{1, 2, 2, 3}) == ([]int{2}, nil)
+//	Mode([]int{1, 1, 2, 2, 3}) == ([]int{1, 2}, nil)
+//	Mode([]int{}) returns (nil, ErrEmptySlice)
+func Mode[T Number](values []T) ([]T, error) {
+	if len(values) == 0 {
+		return nil, ErrEmptySlice
+	}
+	counts := make(map[T]int, len(values))
+	highest := 0
+	for _, v := range values {
+		counts[v]++
+		if counts[v] > highest {
+			highest = counts[v]
+		}
+	}
+	modes := make([]T, 0, 1)
+	for v, c := range counts {
+		if c == highest {
+			modes = append(modes, v)
+		}
+	}
+	slices.Sort(modes)
+	return modes, nil
+}
+
+// Variance returns the population variance of a numeric slice.
+// Use SampleVariance when the values are a sample of a larger population.
+// It returns ErrEmptySlice if the slice is empty.
+//
+// Examples:
+//
+//	Variance([]int{2, 4, 4, 4, 5, 5, 7, 9}) == (4, nil)
+//	Variance([]int{}) returns (0, ErrEmptySlice)
+func Variance[T Number](values []T) (float64, error) {
+	mean, err := Mean(values)
+	if err != nil {
+		return 0, err
+	}
+	return sumSquaredDeviations(values, mean) / float64(len(values)), nil
+}
+
+// SampleVariance returns the sample variance (with Bessel's correction) of a numeric slice.
+// It returns an error if the slice has fewer than two elements.
+//
+// Examples:
+//
+//	SampleVariance([]int{1, 2, 3, 4}) == (1.6666666666666667, nil)
+//	SampleVariance([]int{1}) returns an error
+func SampleVariance[T Number](values []T) (float64, error) {
+	if len(values) < 2 {
+		return 0, errors.New("sample variance requires at least two values")
+	}
+	mean, _ := Mean(values)
+	return sumSquaredDeviations(values, mean) / float64(len(values)-1), nil
+}
+
+// sumSquaredDeviations returns the sum of squared differences between each value and mean.
+func sumSquaredDeviations[T Number](values []T, mean float64) float64 {
+	var sum float64
+	for _, v := range values {
+		d := float64(v) - mean
+		sum += d * d
+	}
+	return sum
+}
+
+// StdDev returns the population standard deviation of a numeric slice.
+// It returns ErrEmptySlice if the slice is empty.
+//
+// Examples:
+//
+//	StdDev([]int{2, 4, 4, 4, 5, 5, 7, 9}) == (2, nil)
+//	StdDev([]int{}) returns (0, ErrEmptySlice)
+func StdDev[T Number](values []T) (float64, error) {
+	variance, err := Variance(values)
+	if err != nil {
+		return 0, err
+	}
+	return math.Sqrt(variance), nil
+}
+
+// SampleStdDev returns the sample standard deviation of a numeric slice.
+// It returns an error if the slice has fewer than two elements.
+//
+// Examples:
+//
+//	SampleStdDev([]int{2, 4, 4, 4, 5, 5, 7, 9}) == (2.138089935299395, nil)
+//	SampleStdDev([]int{1}) returns an error
+func SampleStdDev[T Number](values []T) (float64, error) {
+	variance, err := SampleVariance(values)
+	if err != nil {
+		return 0, err
+	}
+	return math.Sqrt(variance), nil
+}
+
+// Percentile returns the p-th percentile (0 <= p <= 100) of a numeric slice,
+// linearly interpolating between the closest ranks.
+// The input slice is not modified.
+// It returns ErrEmptySlice if the slice is empty and an error if p is out of range.
+//
+// Examples:
+//
+//	Percentile([]int{1, 2, 3, 4, 5}, 50) == (3, nil)
+//	Percentile([]int{1, 2, 3, 4}, 75) == (3.25, nil)
+//	Percentile([]int{1, 2, 3}, 100) == (3, nil)
+//	Percentile([]int{1, 2, 3}, 101) returns an error
+func Percentile[T Number](values []T, p float64) (float64, error) {
+	if len(values) == 0 {
+		return 0, ErrEmptySlice
+	}
+	if math.IsNaN(p) || p < 0 || p > 100 {
+		return 0, errors.New("percentile must be between 0 and 100")
+	}
+	sorted := sortedFloats(values)
+	return interpolate(sorted, p/100*float64(len(sorted)-1)), nil
+}
+
+// Quantiles divides a numeric slice into n intervals of equal probability and
+// returns the n-1 cut points, using the same interpolation as Percentile.
+// For example, n = 4 returns the quartiles and n = 100 returns the percentiles.
+// It returns ErrEmptySlice if the slice is empty and an error if n is less than 2.
+//
+// Examples:
+//
+//	Quantiles([]int{1, 2, 3, 4, 5}, 4) == ([]float64{2, 3, 4}, nil)
+//	Quantiles([]int{1, 2, 3, 4, 5}, 2) == ([]float64{3}, nil)
+//	Quantiles([]int{1, 2, 3}, 1) returns an error
+func Quantiles[T Number](values []T, n int) ([]float64, error) {
+	if len(values) == 0 {
+		return nil, ErrEmptySlice
+	}
+	if n < 2 {
+		return nil, errors.New("n must be at least 2")
+	}
+	sorted := sortedFloats(values)
+	cuts := make([]float64, n-1)
+	for i := range cuts {
+		cuts[i] = interpolate(sorted, float64(i+1)/float64(n)*float64(len(sorted)-1))
+	}
+	return cuts, nil
+}
@@ -0,0 +1,147 @@
+package utils
+
+import (
+	"sync"
+	"time"
+)
+
+// Stopwatch measures elapsed wall-clock time across one or more Start/Stop intervals and
+// records lap times. It uses the monotonic clock, so it is unaffected by changes to the system
+// time. The zero value is a stopped stopwatch with no elapsed time, ready to use, and all
+// methods are safe for concurrent use.
+type Stopwatch struct {
+	mu      sync.Mutex
+	running bool
+	started time.Time     // start of the current interval, if running
+	elapsed time.Duration // total of the completed intervals
+	lapMark time.Duration // total elapsed time when the last lap ended
+	laps    []time.Duration
+	onStop  func(time.Duration)
+}
+
+// NewStopwatch returns a running Stopwatch. If onStop is given, it is called with the
+// total elapsed time each time Stop stops the stopwatch, as a hook for logging or metrics.
+//
+// Examples:
+//
+//	sw := NewStopwatch(func(d time.Duration) { log.Printf("import took %s", d) })
+//	importRows()
+//	sw.Stop() // logs "import took 1.2s"
+func NewStopwatch(onStop ...func(elapsed time.Duration)) *Stopwatch {
+	sw := &Stopwatch{}
+	if len(onStop) > 0 {
+		sw.onStop = onStop[0]
+	}
+	sw.Start()
+	return sw
+}
+
+// Start starts the stopwatch, or resumes it after Stop, adding to the elapsed time so far.
+// It does nothing if the stopwatch is already running.
+func (sw *Stopwatch) Start() {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	if !sw.running {
+		sw.running = true
+		sw.started = time.Now()
+	}
+}
+
+// Stop pauses the stopwatch and returns the total elapsed time. If the stopwatch was running
+// and has an onStop callback, the callback is called with that time after the stopwatch is
+// unlocked, so it may call other methods. Stopping a stopped stopwatch only returns the time.
+func (sw *Stopwatch) Stop() time.Duration {
+	sw.mu.Lock()
+	wasRunning := sw.running
+	if sw.running {
+		sw.elapsed += time.Since(sw.started)
+		sw.running = false
+	}
+	elapsed, onStop := sw.elapsed, sw.onStop
+	sw.mu.Unlock()
+
+	if wasRunning && onStop != nil {
+		onStop(elapsed)
+	}
+	return elapsed
+}
+
+// Lap records and returns the elapsed time since the previous lap, or since the stopwatch was
+// first started for the first lap. Time while the stopwatch is stopped does not count.
+//
+// Examples:
+//
+//	sw := NewStopwatch()
+//	fetch()
+//	fetchTime := sw.Lap()
+//	parse()
+//	parseTime := sw.Lap()
+func (sw *Stopwatch) Lap() time.Duration {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	total := sw.elapsedLocked()
+	lap := total - sw.lapMark
+	sw.lapMark = total
+	sw.laps = append(sw.laps, lap)
+	return lap
+}
+
+// Laps returns a copy of the lap times recorded so far, in order.
+func (sw *Stopwatch) Laps() []time.Duration {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	return append([]time.Duration(nil), sw.laps...)
+}
+
+// Elapsed returns the total elapsed time, including the current interval if the stopwatch is running.
+func (sw *Stopwatch) Elapsed() time.Duration {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	return sw.elapsedLocked()
+}
+
+// Running reports whether the stopwatch is running.
+func (sw *Stopwatch) Running() bool {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	return sw.running
+}
+
+// Reset stops the stopwatch and clears its elapsed time and laps. The onStop callback is kept
+// and not called.
+func (sw *Stopwatch) Reset() {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	sw.running = false
+	sw.elapsed = 0
+	sw.lapMark = 0
+	sw.laps = nil
+}
+
+// elapsedLocked returns the total elapsed time. sw.mu must be held.
+func (sw *Stopwatch) elapsedLocked() time.Duration {
+	if sw.running {
+		return sw.elapsed + time.Since(sw.started)
+	}
+	return sw.elapsed
+}
+
+// TimeIt calls fn and returns how long it took. If onDone is given, it is also called with
+// the duration, which makes one-line logging of a block's duration easy. If fn panics, the
+// panic is propagated after onDone is called.
+//
+// Examples:
+//
+//	d := TimeIt(func() { rebuildIndex() })
+//	TimeIt(warmCache, func(d time.Duration) { log.Printf("cache warmed in %s", d) })
+func TimeIt(fn func(), onDone ...func(elapsed time.Duration)) (elapsed time.Duration) {
+	start := time.Now()
+	defer func() {
+		elapsed = time.Since(start)
+		if len(onDone) > 0 && onDone[0] != nil {
+			onDone[0](elapsed)
+		}
+	}()
+	fn()
+	return
+}
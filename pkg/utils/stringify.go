@@ -0,0 +1,175 @@
+package utils
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxStringifyDepth bounds how deeply Stringify descends into nested values,
+// which also stops it from looping forever on self-referencing data.
+const maxStringifyDepth = 16
+
+// Stringify returns a readable representation of any value, for structured log fields and debugging.
+// Strings are returned as-is, numbers and booleans are formatted as ToString does, time.Time values
+// use RFC 3339 with nanoseconds, and errors and fmt.Stringer values use their Error or String method.
+// Slices and arrays are written as "[a, b]" and maps as "{k: v}" with keys in sorted order (numerically
+// for number keys), so the output is stable across runs; strings inside them are quoted.
+// A []byte holding valid UTF-8 is treated as a string, and other bytes are written in hex.
+// Pointers are dereferenced, nil values are written as "<nil>", and other values, such as structs,
+// are formatted with the %+v verb. Stringify never returns an error and never panics on nil receivers.
+//
+// Examples:
+//
+//	Stringify("hi") == "hi"
+//	Stringify(0.1) == "0.1"
+//	Stringify(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)) == "2024-05-01T12:00:00Z"
+//	Stringify(errors.New("boom")) == "boom"
+//	Stringify([]string{"a", "b"}) == `["a", "b"]`
+//	Stringify(map[string]int{"b": 2, "a": 1}) == `{"a": 1, "b": 2}`
+//	Stringify(map[int]bool{10: true, 9: false}) == "{9: false, 10: true}"
+//	Stringify(nil) == "<nil>"
+func Stringify(v any) string {
+	var b strings.Builder
+	writeStringified(&b, reflect.ValueOf(v), false, 0)
+	return b.String()
+}
+
+// writeStringified writes the representation of v to b. Strings are quoted when nested is true.
+func writeStringified(b *strings.Builder, v reflect.Value, nested bool, depth int) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			b.WriteString("<nil>")
+			return
+		}
+		// A pointer may carry a String or Error method its element lacks.
+		if v.Kind() == reflect.Pointer && v.CanInterface() {
+			if s, ok := stringMethod(v.Interface()); ok {
+				b.WriteString(s)
+				return
+			}
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		b.WriteString("<nil>")
+		return
+	}
+	if depth > maxStringifyDepth {
+		b.WriteString("...")
+		return
+	}
+
+	if v.CanInterface() {
+		if t, ok := v.Interface().(time.Time); ok {
+			b.WriteString(t.Format(time.RFC3339Nano))
+			return
+		}
+		if s, ok := stringMethod(v.Interface()); ok {
+			b.WriteString(s)
+			return
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		writeMaybeQuoted(b, v.String(), nested)
+	case reflect.Bool:
+		b.WriteString(strconv.FormatBool(v.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		b.WriteString(strconv.FormatInt(v.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		b.WriteString(strconv.FormatUint(v.Uint(), 10))
+	case reflect.Float32:
+		b.WriteString(formatFloatShortest(v.Float(), 32))
+	case reflect.Float64:
+		b.WriteString(formatFloatShortest(v.Float(), 64))
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			b.WriteString("<nil>")
+			return
+		}
+		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+			if data := v.Bytes(); utf8.Valid(data) {
+				writeMaybeQuoted(b, string(data), nested)
+			} else {
+				fmt.Fprintf(b, "%x", data)
+			}
+			return
+		}
+		b.WriteByte('[')
+		for i := 0; i < v.Len(); i++ {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			writeStringified(b, v.Index(i), true, depth+1)
+		}
+		b.WriteByte(']')
+	case reflect.Map:
+		if v.IsNil() {
+			b.WriteString("<nil>")
+			return
+		}
+		keys := v.MapKeys()
+		slices.SortFunc(keys, compareMapKeys)
+		b.WriteByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			writeStringified(b, key, true, depth+1)
+			b.WriteString(": ")
+			writeStringified(b, v.MapIndex(key), true, depth+1)
+		}
+		b.WriteByte('}')
+	default:
+		if v.CanInterface() {
+			fmt.Fprintf(b, "%+v", v.Interface())
+		} else {
+			fmt.Fprintf(b, "%+v", v)
+		}
+	}
+}
+
+// writeMaybeQuoted writes s to b, quoted with strconv.Quote if quote is true.
+func writeMaybeQuoted(b *strings.Builder, s string, quote bool) {
+	if quote {
+		b.WriteString(strconv.Quote(s))
+		return
+	}
+	b.WriteString(s)
+}
+
+// compareMapKeys orders map keys numerically when both are numbers and by their Stringify form otherwise.
+func compareMapKeys(a, b reflect.Value) int {
+	if fa, ok := numericKey(a); ok {
+		if fb, ok := numericKey(b); ok {
+			return cmp.Compare(fa, fb)
+		}
+	}
+	var sa, sb strings.Builder
+	writeStringified(&sa, a, false, 0)
+	writeStringified(&sb, b, false, 0)
+	return strings.Compare(sa.String(), sb.String())
+}
+
+// numericKey returns k as a float64 if it is a number.
+func numericKey(k reflect.Value) (float64, bool) {
+	for k.Kind() == reflect.Interface && !k.IsNil() {
+		k = k.Elem()
+	}
+	switch k.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(k.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(k.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return k.Float(), true
+	}
+	return 0, false
+}
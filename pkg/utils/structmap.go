@@ -0,0 +1,318 @@
+package utils
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// structField describes how one struct field is named in a map.
+type structField struct {
+	name      string
+	index     []int
+	omitEmpty bool
+}
+
+// structFields lists the exported fields of t named according to tag, such as "json".
+// A tag value of "-" skips the field, and ",omitempty" is recorded. Fields of embedded
+// (non-pointer) structs without a tag name are promoted into the parent, as encoding/json does.
+// If tag is empty, Go field names are used.
+func structFields(t reflect.Type, tag string) []structField {
+	var fields []structField
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		var tagName, opts string
+		if tag != "" {
+			tagValue := sf.Tag.Get(tag)
+			if tagValue == "-" {
+				continue
+			}
+			tagName, opts, _ = strings.Cut(tagValue, ",")
+		}
+
+		if tagName == "" && sf.Anonymous && sf.Type.Kind() == reflect.Struct {
+			// An untagged embedded struct: promote its fields.
+			for _, inner := range structFields(sf.Type, tag) {
+				inner.index = append([]int{i}, inner.index...)
+				fields = append(fields, inner)
+			}
+			continue
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		name := sf.Name
+		if tagName != "" {
+			name = tagName
+		}
+		fields = append(fields, structField{
+			name:      name,
+			index:     []int{i},
+			omitEmpty: strings.Contains(","+opts+",", ",omitempty,"),
+		})
+	}
+	return fields
+}
+
+// StructToMap converts the struct v (or pointer to struct) to a map keyed by the field names
+// given in the tag struct tag, such as "json" or "db", or by Go field names if tag is empty.
+// Fields tagged "-" are skipped, fields tagged omitempty are left out when they hold their zero value,
+// and untagged embedded structs have their fields promoted, as encoding/json does.
+// Nested structs, pointers to structs, and slices or arrays of structs become nested maps and
+// []any values, so the result can be edited and re-encoded freely. Types with their own
+// MarshalJSON or MarshalText method, such as time.Time, are kept as they are, as are maps.
+// It returns an error if v is not a struct or a non-nil pointer to one.
+//
+// Examples:
+//
+//	type Address struct {
+//		City string `json:"city"`
+//	}
+//	type User struct {
+//		Name    string   `json:"name"`
+//		Email   string   `json:"email,omitempty"`
+//		Secret  string   `json:"-"`
+//		Address *Address `json:"address"`
+//	}
+//
+//	StructToMap(User{Name: "Ana", Address: &Address{City: "Recife"}}, "json") ==
+//		(map[string]any{"name": "Ana", "address": map[string]any{"city": "Recife"}}, nil)
+//	StructToMap(42, "json") returns an error
+func StructToMap(v any, tag string) (map[string]any, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil, errors.New("cannot convert a nil pointer to a map")
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cannot convert a value of type %T to a map: must be a struct", v)
+	}
+	return structValueToMap(rv, tag), nil
+}
+
+// structValueToMap converts the struct rv to a map as described in StructToMap.
+func structValueToMap(rv reflect.Value, tag string) map[string]any {
+	fields := structFields(rv.Type(), tag)
+	m := make(map[string]any, len(fields))
+	for _, f := range fields {
+		fv := rv.FieldByIndex(f.index)
+		if f.omitEmpty && isEmptyValue(fv) {
+			continue
+		}
+		m[f.name] = toMapValue(fv, tag)
+	}
+	return m
+}
+
+// toMapValue converts a field value for StructToMap, turning nested structs into maps.
+func toMapValue(v reflect.Value, tag string) any {
+	if isMarshaler(v.Type()) {
+		return v.Interface()
+	}
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return nil
+		}
+		if v.Elem().Kind() == reflect.Struct && !isMarshaler(v.Elem().Type()) {
+			return structValueToMap(v.Elem(), tag)
+		}
+	case reflect.Struct:
+		return structValueToMap(v, tag)
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil
+		}
+		elem := v.Type().Elem()
+		if elem.Kind() == reflect.Struct || elem.Kind() == reflect.Pointer && elem.Elem().Kind() == reflect.Struct {
+			items := make([]any, v.Len())
+			for i := range items {
+				items[i] = toMapValue(v.Index(i), tag)
+			}
+			return items
+		}
+	}
+	return v.Interface()
+}
+
+// isMarshaler reports whether t, or a pointer to it, encodes itself to JSON or text.
+func isMarshaler(t reflect.Type) bool {
+	jsonMarshaler := reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshaler := reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	return t.Implements(jsonMarshaler) || t.Implements(textMarshaler)
+}
+
+// isEmptyValue reports whether v counts as empty for omitempty, following encoding/json.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Interface, reflect.Pointer:
+		return v.IsZero()
+	}
+	return false
+}
+
+// MapToStruct copies the entries of m into the struct pointed to by out, matching keys to
+// fields by their json tag names (or Go field names when untagged), case-insensitively
+// as encoding/json does. Only the fields whose keys appear in m are changed, so a partial map
+// can be applied as a patch to an existing struct. Keys without a matching field are ignored.
+//
+// Values are converted to the field type where that is unambiguous: nested maps fill nested
+// structs (allocating pointers as needed), []any fills slices element by element, numbers are
+// converted between numeric types with ToInt and ToFloat rules (so float64 values decoded from
+// JSON fill int fields when they are integral), strings fill types with an UnmarshalText method
+// such as time.Time, and nil resets a field to its zero value.
+// It returns an error if out is not a non-nil pointer to a struct or a value cannot be converted;
+// fields processed before the error keep their new values.
+//
+// Examples:
+//
+//	var u User
+//	MapToStruct(map[string]any{"name": "Ana", "address": map[string]any{"city": "Recife"}}, &u) == nil
+//	// u.Name == "Ana", u.Address.City == "Recife"
+//	MapToStruct(map[string]any{"name": 42}, &u) returns an error
+//	MapToStruct(map[string]any{}, u) returns an error (not a pointer)
+func MapToStruct(m map[string]any, out any) error {
+	rv := reflect.ValueOf(out)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("cannot decode a map into %T: must be a non-nil pointer to a struct", out)
+	}
+	return mapToStructValue(m, rv.Elem(), "")
+}
+
+// mapToStructValue fills the struct rv from m. The prefix is used in error messages.
+func mapToStructValue(m map[string]any, rv reflect.Value, prefix string) error {
+	fields := structFields(rv.Type(), "json")
+	for key, value := range m {
+		f, ok := findStructField(fields, key)
+		if !ok {
+			continue
+		}
+		if err := assignValue(rv.FieldByIndex(f.index), value, prefix+f.name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// findStructField returns the field named key, preferring an exact match over a case-insensitive one.
+func findStructField(fields []structField, key string) (structField, bool) {
+	for _, f := range fields {
+		if f.name == key {
+			return f, true
+		}
+	}
+	for _, f := range fields {
+		if strings.EqualFold(f.name, key) {
+			return f, true
+		}
+	}
+	return structField{}, false
+}
+
+// assignValue stores value in dst, converting it as described in MapToStruct.
+func assignValue(dst reflect.Value, value any, path string) error {
+	if value == nil {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+	src := reflect.ValueOf(value)
+
+	if src.Type().AssignableTo(dst.Type()) {
+		dst.Set(src)
+		return nil
+	}
+	if dst.Kind() == reflect.Pointer {
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		return assignValue(dst.Elem(), value, path)
+	}
+	if s, ok := value.(string); ok && dst.CanAddr() {
+		if u, ok := dst.Addr().Interface().(encoding.TextUnmarshaler); ok {
+			if err := u.UnmarshalText([]byte(s)); err != nil {
+				return fmt.Errorf("field %s: %w", path, err)
+			}
+			return nil
+		}
+	}
+
+	switch dst.Kind() {
+	case reflect.Struct:
+		if nested, ok := value.(map[string]any); ok {
+			return mapToStructValue(nested, dst, path+".")
+		}
+	case reflect.Slice:
+		if src.Kind() == reflect.Slice || src.Kind() == reflect.Array {
+			slice := reflect.MakeSlice(dst.Type(), src.Len(), src.Len())
+			for i := 0; i < src.Len(); i++ {
+				if err := assignValue(slice.Index(i), src.Index(i).Interface(), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+			dst.Set(slice)
+			return nil
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if isNumberValue(src) {
+			n, err := ToInt(value)
+			if err == nil && dst.OverflowInt(int64(n)) {
+				err = ErrOverflow
+			}
+			if err != nil {
+				return fmt.Errorf("field %s: %w", path, err)
+			}
+			dst.SetInt(int64(n))
+			return nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if isNumberValue(src) {
+			n, err := ToInt(value)
+			if err == nil && (n < 0 || dst.OverflowUint(uint64(n))) {
+				err = ErrOverflow
+			}
+			if err != nil {
+				return fmt.Errorf("field %s: %w", path, err)
+			}
+			dst.SetUint(uint64(n))
+			return nil
+		}
+	case reflect.Float32, reflect.Float64:
+		if isNumberValue(src) {
+			f, err := ToFloat(value)
+			if err != nil {
+				return fmt.Errorf("field %s: %w", path, err)
+			}
+			dst.SetFloat(f)
+			return nil
+		}
+	}
+	if src.Type().ConvertibleTo(dst.Type()) && src.Kind() == dst.Kind() {
+		// Named types sharing a kind, such as a string into a custom string type.
+		dst.Set(src.Convert(dst.Type()))
+		return nil
+	}
+	return fmt.Errorf("field %s: cannot assign %T to %s", path, value, dst.Type())
+}
+
+// isNumberValue reports whether v holds a number, including a json.Number.
+func isNumberValue(v reflect.Value) bool {
+	if v.Type() == reflect.TypeOf(json.Number("")) {
+		return true
+	}
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,243 @@
+package utils
+
+import (
+	"encoding/binary"
+	"hash/maphash"
+	"math"
+	"reflect"
+	"sync"
+)
+
+// syncMapShards is the number of shards in a SyncMap, a power of two.
+const syncMapShards = 32
+
+// SyncMap is a concurrent map from K to V, split into shards that each have their own lock,
+// so that writes to different keys rarely contend. It suits write-heavy workloads, where
+// sync.Map, which is optimized for keys written once and read many times, does poorly.
+// Keys are spread over the shards by hash when they are numbers, strings, booleans or pointers;
+// other keys, such as structs, all go to the same shard.
+// The zero value is an empty map ready to use. A SyncMap must not be copied after first use.
+type SyncMap[K comparable, V any] struct {
+	init   sync.Once
+	seed   maphash.Seed
+	shards [syncMapShards]syncMapShard[K, V]
+}
+
+// syncMapShard is one lock-protected part of a SyncMap.
+type syncMapShard[K comparable, V any] struct {
+	mu sync.RWMutex
+	m  map[K]V
+}
+
+// NewSyncMap returns an empty SyncMap.
+//
+// Examples:
+//
+//	sessions := NewSyncMap[string, *Session]()
+//	sessions.Store(id, session)
+//	s, ok := sessions.Load(id)
+func NewSyncMap[K comparable, V any]() *SyncMap[K, V] {
+	return &SyncMap[K, V]{}
+}
+
+// lazyInit sets up m's hash seed and shard maps on first use.
+func (m *SyncMap[K, V]) lazyInit() {
+	m.init.Do(func() {
+		m.seed = maphash.MakeSeed()
+		for i := range m.shards {
+			m.shards[i].m = make(map[K]V)
+		}
+	})
+}
+
+// shard returns the shard holding key.
+func (m *SyncMap[K, V]) shard(key K) *syncMapShard[K, V] {
+	m.lazyInit()
+	return &m.shards[hashSyncMapKey(m.seed, key)&(syncMapShards-1)]
+}
+
+// hashSyncMapKey hashes key so that equal keys always get the same hash. Numbers, strings,
+// booleans and pointers, including those held in interfaces, are hashed by value. Other keys,
+// such as structs and arrays, cannot be hashed consistently with == without knowing their
+// layout, so they all hash to 0 and share one shard: they work, but do not spread the load.
+func hashSyncMapKey[K comparable](seed maphash.Seed, key K) uint64 {
+	var h maphash.Hash
+	h.SetSeed(seed)
+	// The common key types are handled without reflection.
+	switch k := any(key).(type) {
+	case string:
+		h.WriteString(k)
+		return h.Sum64()
+	case int:
+		return hashSyncMapWord(&h, uint64(k))
+	case int64:
+		return hashSyncMapWord(&h, uint64(k))
+	case uint64:
+		return hashSyncMapWord(&h, k)
+	}
+
+	v := reflect.ValueOf(&key).Elem()
+	for v.Kind() == reflect.Interface && !v.IsNil() {
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.String:
+		h.WriteString(v.String())
+		return h.Sum64()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return hashSyncMapWord(&h, uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return hashSyncMapWord(&h, v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return hashSyncMapWord(&h, syncMapFloatBits(v.Float()))
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		hashSyncMapWord(&h, syncMapFloatBits(real(c)))
+		return hashSyncMapWord(&h, syncMapFloatBits(imag(c)))
+	case reflect.Bool:
+		if v.Bool() {
+			return hashSyncMapWord(&h, 1)
+		}
+		return hashSyncMapWord(&h, 0)
+	case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		return hashSyncMapWord(&h, uint64(v.Pointer()))
+	}
+	return 0
+}
+
+// hashSyncMapWord adds w to h and returns the hash so far.
+func hashSyncMapWord(h *maphash.Hash, w uint64) uint64 {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], w)
+	h.Write(buf[:])
+	return h.Sum64()
+}
+
+// syncMapFloatBits returns the bits of f, with -0 turned into +0 since they are equal and
+// must hash alike.
+func syncMapFloatBits(f float64) uint64 {
+	if f == 0 {
+		f = 0
+	}
+	return math.Float64bits(f)
+}
+
+// Load returns the value stored for key, and whether there is one.
+func (m *SyncMap[K, V]) Load(key K) (V, bool) {
+	s := m.shard(key)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	v, ok := s.m[key]
+	return v, ok
+}
+
+// Store sets the value for key.
+func (m *SyncMap[K, V]) Store(key K, value V) {
+	s := m.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.m[key] = value
+}
+
+// LoadOrStore returns the value stored for key if there is one, and otherwise stores value and
+// returns it. loaded reports whether the value was already there.
+func (m *SyncMap[K, V]) LoadOrStore(key K, value V) (actual V, loaded bool) {
+	s := m.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if v, ok := s.m[key]; ok {
+		return v, true
+	}
+	s.m[key] = value
+	return value, false
+}
+
+// GetOrCompute returns the value stored for key, computing and storing it with fn if there is
+// none. fn is called at most once per missing key, even under concurrent calls, while the
+// key's shard is locked, so it should be quick and must not use m.
+//
+// Examples:
+//
+//	counter := stats.GetOrCompute(route, func() *AtomicCounter { return new(AtomicCounter) })
+//	counter.Inc()
+func (m *SyncMap[K, V]) GetOrCompute(key K, fn func() V) V {
+	s := m.shard(key)
+	s.mu.RLock()
+	v, ok := s.m[key]
+	s.mu.RUnlock()
+	if ok {
+		return v
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if v, ok := s.m[key]; ok {
+		return v
+	}
+	v = fn()
+	s.m[key] = v
+	return v
+}
+
+// Delete removes the value for key, if any.
+func (m *SyncMap[K, V]) Delete(key K) {
+	s := m.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.m, key)
+}
+
+// LoadAndDelete removes the value for key and returns it, and whether there was one.
+func (m *SyncMap[K, V]) LoadAndDelete(key K) (V, bool) {
+	s := m.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.m[key]
+	delete(s.m, key)
+	return v, ok
+}
+
+// Range calls fn for each key and value in m, in no particular order, until fn returns false.
+// Each shard is copied before fn is called on its entries, so fn may use m, but it is not
+// a consistent snapshot of the whole map under concurrent writes.
+//
+// Examples:
+//
+//	sessions.Range(func(id string, s *Session) bool {
+//		if s.Expired() {
+//			sessions.Delete(id)
+//		}
+//		return true
+//	})
+func (m *SyncMap[K, V]) Range(fn func(key K, value V) bool) {
+	m.lazyInit()
+	for i := range m.shards {
+		s := &m.shards[i]
+		s.mu.RLock()
+		keys := make([]K, 0, len(s.m))
+		values := make([]V, 0, len(s.m))
+		for k, v := range s.m {
+			keys = append(keys, k)
+			values = append(values, v)
+		}
+		s.mu.RUnlock()
+		for j, k := range keys {
+			if !fn(k, values[j]) {
+				return
+			}
+		}
+	}
+}
+
+// Len returns the number of entries in m.
+func (m *SyncMap[K, V]) Len() int {
+	m.lazyInit()
+	n := 0
+	for i := range m.shards {
+		s := &m.shards[i]
+		s.mu.RLock()
+		n += len(s.m)
+		s.mu.RUnlock()
+	}
+	return n
+}
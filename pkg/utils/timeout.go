@@ -0,0 +1,67 @@
+package utils
+
+import (
+	"context"
+	"runtime/debug"
+	"time"
+)
+
+// WithTimeout calls fn with a context that expires after d, and returns fn's result, or
+// context.DeadlineExceeded as soon as d elapses if fn has not returned by then.
+// See RunWithDeadline for what happens to fn after a timeout.
+//
+// Examples:
+//
+//	rows, err := WithTimeout(2*time.Second, func(ctx context.Context) ([]Row, error) {
+//		return db.Query(ctx, query)
+//	})
+//	if errors.Is(err, context.DeadlineExceeded) {
+//		// the query took longer than two seconds
+//	}
+func WithTimeout[T any](d time.Duration, fn func(ctx context.Context) (T, error)) (T, error) {
+	return RunWithDeadline(time.Now().Add(d), fn)
+}
+
+// RunWithDeadline calls fn with a context that expires at deadline, and returns fn's result,
+// or context.DeadlineExceeded once the deadline passes if fn has not returned by then.
+//
+// It returns on time even if fn ignores its context: fn runs in its own goroutine, which is
+// left running after a timeout until fn returns, at which point its result is discarded and
+// the goroutine exits. A function that never returns therefore leaks its goroutine, so fn
+// should still honor ctx. A panic in fn is recovered and returned as a *PanicError if it happens
+// before the deadline, and discarded otherwise.
+//
+// Examples:
+//
+//	_, err := RunWithDeadline(endOfBatchWindow, func(ctx context.Context) (struct{}, error) {
+//		return struct{}{}, syncInventory(ctx)
+//	})
+func RunWithDeadline[T any](deadline time.Time, fn func(ctx context.Context) (T, error)) (T, error) {
+	ctx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+
+	type result struct {
+		value T
+		err   error
+	}
+	// Buffered so that the goroutine can finish sending after a timeout and exit.
+	done := make(chan result, 1)
+	go func() {
+		var r result
+		defer func() {
+			if p := recover(); p != nil {
+				r.err = &PanicError{Value: p, Stack: debug.Stack()}
+			}
+			done <- r
+		}()
+		r.value, r.err = fn(ctx)
+	}()
+
+	select {
+	case r := <-done:
+		return r.value, r.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
@@ -0,0 +1,80 @@
+package utils
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// timezoneCache holds the locations loaded by loadTimezone, keyed by name,
+// since time.LoadLocation reads the zone database on every call.
+var timezoneCache sync.Map
+
+// loadTimezone returns the location for the IANA time zone name tz, such as "America/Sao_Paulo"
+// or "UTC". The empty string and "Local" are rejected, since they do not name a zone that
+// means the same thing on every machine.
+func loadTimezone(tz string) (*time.Location, error) {
+	if tz == "" || tz == "Local" {
+		return nil, fmt.Errorf("invalid time zone %q: must be an IANA time zone name", tz)
+	}
+	if loc, ok := timezoneCache.Load(tz); ok {
+		return loc.(*time.Location), nil
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time zone %q: not in the time zone database", tz)
+	}
+	timezoneCache.Store(tz, loc)
+	return loc, nil
+}
+
+// IsValidTimezone reports whether tz is an IANA time zone name known to the system's
+// zone database (or the one embedded with time/tzdata), such as "Europe/Lisbon" or "UTC".
+// The empty string and "Local" are not accepted.
+//
+// Examples:
+//
+//	IsValidTimezone("America/Sao_Paulo") == true
+//	IsValidTimezone("UTC") == true
+//	IsValidTimezone("Mars/Olympus_Mons") == false
+//	IsValidTimezone("") == false
+func IsValidTimezone(tz string) bool {
+	_, err := loadTimezone(tz)
+	return err == nil
+}
+
+// ConvertTimezone returns t as seen in the time zone tz, an IANA name as accepted by
+// IsValidTimezone. The instant is unchanged; only the location used to display it differs.
+// It returns an error if tz is not a valid time zone.
+//
+// Examples:
+//
+//	noonUTC := time.Date(2024, 5, 3, 12, 0, 0, 0, time.UTC)
+//	ConvertTimezone(noonUTC, "America/New_York") returns 2024-05-03 08:00:00 -0400 EDT
+//	ConvertTimezone(noonUTC, "Asia/Kolkata") returns 2024-05-03 17:30:00 +0530 IST
+//	ConvertTimezone(noonUTC, "Nowhere/City") returns an error
+func ConvertTimezone(t time.Time, tz string) (time.Time, error) {
+	loc, err := loadTimezone(tz)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.In(loc), nil
+}
+
+// TimezoneOffset returns the offset from UTC of the time zone tz at the instant at, which
+// accounts for daylight saving time in effect then. Offsets east of UTC are positive.
+// It returns an error if tz is not a valid time zone.
+//
+// Examples:
+//
+//	TimezoneOffset("America/New_York", time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)) == (-5*time.Hour, nil)
+//	TimezoneOffset("America/New_York", time.Date(2024, 7, 15, 0, 0, 0, 0, time.UTC)) == (-4*time.Hour, nil)
+//	TimezoneOffset("Asia/Kolkata", time.Now()) == (5*time.Hour+30*time.Minute, nil)
+func TimezoneOffset(tz string, at time.Time) (time.Duration, error) {
+	loc, err := loadTimezone(tz)
+	if err != nil {
+		return 0, err
+	}
+	_, offset := at.In(loc).Zone()
+	return time.Duration(offset) * time.Second, nil
+}
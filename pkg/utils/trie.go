@@ -0,0 +1,176 @@
+package utils
+
+import "sort"
+
+// Trie is a prefix tree mapping string keys to values of type V, for prefix queries such as
+// autocomplete and longest-prefix lookups. Keys are compared byte by byte. The zero value is
+// an empty trie ready to use. It is not safe for concurrent writes.
+type Trie[V any] struct {
+	root trieNode[V]
+	size int
+}
+
+// trieNode is a node of a Trie, holding a value if a key ends there.
+type trieNode[V any] struct {
+	children map[byte]*trieNode[V]
+	value    V
+	hasValue bool
+}
+
+// NewTrie returns an empty trie.
+//
+// Examples:
+//
+//	routes := NewTrie[http.Handler]()
+//	routes.Insert("/api/", apiHandler)
+//	routes.Insert("/api/users/", usersHandler)
+func NewTrie[V any]() *Trie[V] {
+	return &Trie[V]{}
+}
+
+// Insert stores value for key, replacing any previous value, and reports whether key is new.
+func (t *Trie[V]) Insert(key string, value V) bool {
+	n := &t.root
+	for i := 0; i < len(key); i++ {
+		child, ok := n.children[key[i]]
+		if !ok {
+			if n.children == nil {
+				n.children = make(map[byte]*trieNode[V])
+			}
+			child = &trieNode[V]{}
+			n.children[key[i]] = child
+		}
+		n = child
+	}
+	added := !n.hasValue
+	n.value, n.hasValue = value, true
+	if added {
+		t.size++
+	}
+	return added
+}
+
+// Get returns the value stored for key, or false if there is none.
+func (t *Trie[V]) Get(key string) (V, bool) {
+	n := t.find(key)
+	if n == nil || !n.hasValue {
+		var zero V
+		return zero, false
+	}
+	return n.value, true
+}
+
+// Delete removes key and its value, pruning the nodes left unused, and reports whether key
+// was present.
+func (t *Trie[V]) Delete(key string) bool {
+	path := make([]*trieNode[V], 0, len(key)+1)
+	n := &t.root
+	path = append(path, n)
+	for i := 0; i < len(key); i++ {
+		if n = n.children[key[i]]; n == nil {
+			return false
+		}
+		path = append(path, n)
+	}
+	if !n.hasValue {
+		return false
+	}
+	var zero V
+	n.value, n.hasValue = zero, false
+	t.size--
+
+	// Unlink the nodes that no longer lead to a value, from the end of key up.
+	for i := len(key); i > 0; i-- {
+		if n := path[i]; n.hasValue || len(n.children) > 0 {
+			break
+		}
+		delete(path[i-1].children, key[i-1])
+	}
+	return true
+}
+
+// HasPrefix reports whether any key starts with prefix. Every trie that is not empty has the
+// prefix "".
+func (t *Trie[V]) HasPrefix(prefix string) bool {
+	n := t.find(prefix)
+	return n != nil && (n.hasValue || len(n.children) > 0)
+}
+
+// KeysWithPrefix returns the keys starting with prefix, in lexicographic order, at most limit
+// of them if limit is positive.
+//
+// Examples:
+//
+//	t := NewTrie[int]()
+//	t.Insert("car", 1)
+//	t.Insert("cart", 2)
+//	t.Insert("cat", 3)
+//	t.KeysWithPrefix("car", 0) == []string{"car", "cart"}
+//	t.KeysWithPrefix("ca", 2) == []string{"car", "cart"}
+func (t *Trie[V]) KeysWithPrefix(prefix string, limit int) []string {
+	n := t.find(prefix)
+	if n == nil {
+		return nil
+	}
+	var keys []string
+	n.collect([]byte(prefix), &keys, limit)
+	return keys
+}
+
+// LongestPrefix returns the longest key that is a prefix of s, with its value, or false if
+// no key is.
+//
+// Examples:
+//
+//	prefix, handler, ok := routes.LongestPrefix("/api/users/42") // "/api/users/"
+func (t *Trie[V]) LongestPrefix(s string) (key string, value V, ok bool) {
+	n := &t.root
+	if n.hasValue {
+		value, ok = n.value, true
+	}
+	for i := 0; i < len(s); i++ {
+		if n = n.children[s[i]]; n == nil {
+			break
+		}
+		if n.hasValue {
+			key, value, ok = s[:i+1], n.value, true
+		}
+	}
+	return key, value, ok
+}
+
+// Len returns the number of keys in t.
+func (t *Trie[V]) Len() int {
+	return t.size
+}
+
+// find returns the node reached by following key, or nil if there is none.
+func (t *Trie[V]) find(key string) *trieNode[V] {
+	n := &t.root
+	for i := 0; i < len(key) && n != nil; i++ {
+		n = n.children[key[i]]
+	}
+	return n
+}
+
+// collect appends to keys the keys under n, in lexicographic order, where key is the key
+// leading to n, and reports false once limit keys have been collected.
+func (n *trieNode[V]) collect(key []byte, keys *[]string, limit int) bool {
+	if n.hasValue {
+		*keys = append(*keys, string(key))
+		if limit > 0 && len(*keys) >= limit {
+			return false
+		}
+	}
+	next := make([]byte, 0, len(n.children))
+	for c := range n.children {
+		next = append(next, c)
+	}
+	sort.Slice(next, func(i, j int) bool { return next[i] < next[j] })
+	for _, c := range next {
+		if !n.children[c].collect(append(key, c), keys, limit) {
+			return false
+		}
+	}
+	return true
+}
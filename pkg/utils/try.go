@@ -0,0 +1,54 @@
+package utils
+
+// tryPanic is the panic value used by Try and TryValue, which Catch turns back into an error.
+type tryPanic struct {
+	err error
+}
+
+// Try panics with err, to be recovered by a deferred Catch, if err is not nil. Together they
+// replace the "if err != nil { return err }" after each call in script-like code. Only use
+// Try in a function that defers Catch; elsewhere the panic crashes the program.
+//
+// Examples:
+//
+//	func run() (err error) {
+//		defer Catch(&err)
+//		Try(os.MkdirAll(dir, 0o755))
+//		data := TryValue(os.ReadFile(src))
+//		Try(os.WriteFile(filepath.Join(dir, "out"), data, 0o644))
+//		return nil
+//	}
+func Try(err error) {
+	if err != nil {
+		panic(tryPanic{err})
+	}
+}
+
+// TryValue returns v, or panics with err like Try if err is not nil.
+func TryValue[T any](v T, err error) T {
+	Try(err)
+	return v
+}
+
+// Catch recovers a panic raised by Try or TryValue and stores its error in *err. It must be
+// called directly by a deferred statement, as in "defer Catch(&err)" with err the function's
+// named error result. Other panics are propagated unchanged.
+func Catch(err *error) {
+	if r := recover(); r != nil {
+		p, ok := r.(tryPanic)
+		if !ok {
+			panic(r)
+		}
+		*err = p.err
+	}
+}
+
+// IgnoreError returns v and discards the error, for calls that cannot fail in practice or
+// whose failure does not matter, such as writing to a bytes.Buffer.
+//
+// Examples:
+//
+//	n := IgnoreError(fmt.Fprintf(&buf, "%d items", count))
+func IgnoreError[T any](v T, _ error) T {
+	return v
+}
@@ -0,0 +1,303 @@
+package utils
+
+import (
+	"container/list"
+	"context"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+// ttlCacheOptions holds the settings applied by CacheOption values.
+type ttlCacheOptions[K comparable, V any] struct {
+	maxSize         int
+	cleanupInterval time.Duration
+	onEvict         func(key K, value V)
+}
+
+// CacheOption configures a TTLCache.
+type CacheOption[K comparable, V any] func(*ttlCacheOptions[K, V])
+
+// CacheMaxSize limits the cache to n entries. Adding an entry to a full cache first drops the
+// expired entries, and if there are none, evicts the entry written longest ago.
+// The default, 0, is no limit.
+func CacheMaxSize[K comparable, V any](n int) CacheOption[K, V] {
+	return func(o *ttlCacheOptions[K, V]) {
+		o.maxSize = n
+	}
+}
+
+// CacheCleanupInterval sets how often a background goroutine drops the expired entries, which
+// are otherwise only dropped when they are read or the cache is full. The default is one
+// minute; 0 or less disables the background cleanup.
+func CacheCleanupInterval[K comparable, V any](d time.Duration) CacheOption[K, V] {
+	return func(o *ttlCacheOptions[K, V]) {
+		o.cleanupInterval = d
+	}
+}
+
+// CacheOnEvict sets a function called with each entry that expires, is evicted to respect
+// the size limit, or is deleted, for closing resources or metrics. It is called without the
+// cache's lock held, and not for entries replaced by a new value.
+func CacheOnEvict[K comparable, V any](fn func(key K, value V)) CacheOption[K, V] {
+	return func(o *ttlCacheOptions[K, V]) {
+		o.onEvict = fn
+	}
+}
+
+// TTLCache is a key-value cache whose entries expire after a time to live, set per entry or
+// from the cache's default. Expired entries are dropped lazily when read, and periodically by
+// a background goroutine; see CacheCleanupInterval. It is safe for concurrent use.
+type TTLCache[K comparable, V any] struct {
+	mu         sync.Mutex
+	opts       ttlCacheOptions[K, V]
+	defaultTTL time.Duration
+	entries    map[K]*list.Element // of *ttlCacheEntry[K, V]
+	order      list.List           // entries from the oldest to the newest write
+	loads      map[K]*ttlCacheLoad[V]
+	stop       context.CancelFunc
+}
+
+// ttlCacheEntry is a value stored in a TTLCache.
+type ttlCacheEntry[K comparable, V any] struct {
+	key     K
+	value   V
+	expires time.Time // zero if the entry never expires
+}
+
+// ttlCacheLoad is a GetOrSet loader call in progress, which other callers for the same key
+// wait for.
+type ttlCacheLoad[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+// NewTTLCache returns an empty cache whose entries expire after defaultTTL unless set with
+// SetWithTTL. A defaultTTL of zero or less means entries do not expire by default. Unless the
+// background cleanup is disabled, call Close when done with the cache to stop it.
+//
+// Examples:
+//
+//	responses := NewTTLCache[string, []byte](5*time.Minute, CacheMaxSize[string, []byte](10_000))
+//	defer responses.Close()
+//	responses.Set(url, body)
+func NewTTLCache[K comparable, V any](defaultTTL time.Duration, opts ...CacheOption[K, V]) *TTLCache[K, V] {
+	o := ttlCacheOptions[K, V]{cleanupInterval: time.Minute}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	c := &TTLCache[K, V]{
+		opts:       o,
+		defaultTTL: defaultTTL,
+		entries:    make(map[K]*list.Element),
+		loads:      make(map[K]*ttlCacheLoad[V]),
+		stop:       func() {},
+	}
+	if o.cleanupInterval > 0 {
+		ctx, cancel := context.WithCancel(context.Background())
+		c.stop = cancel
+		go RunEvery(ctx, o.cleanupInterval, func(context.Context) { c.DeleteExpired() })
+	}
+	return c
+}
+
+// Get returns the value stored for key, or false if there is none or it has expired.
+func (c *TTLCache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	now := time.Now()
+	e, ok := c.entries[key]
+	if !ok {
+		c.mu.Unlock()
+		var zero V
+		return zero, false
+	}
+	entry := e.Value.(*ttlCacheEntry[K, V])
+	if entry.expired(now) {
+		c.remove(e)
+		c.mu.Unlock()
+		c.evicted(entry)
+		var zero V
+		return zero, false
+	}
+	v := entry.value
+	c.mu.Unlock()
+	return v, true
+}
+
+// Set stores value for key with the cache's default time to live, replacing any previous
+// value.
+func (c *TTLCache[K, V]) Set(key K, value V) {
+	c.SetWithTTL(key, value, c.defaultTTL)
+}
+
+// SetWithTTL stores value for key, expiring after ttl, replacing any previous value.
+// A ttl of zero or less means the entry does not expire.
+//
+// Examples:
+//
+//	sessions.SetWithTTL(token, session, time.Until(session.ExpiresAt))
+func (c *TTLCache[K, V]) SetWithTTL(key K, value V, ttl time.Duration) {
+	c.mu.Lock()
+	evicted := c.set(key, value, ttl, time.Now())
+	c.mu.Unlock()
+	c.evicted(evicted...)
+}
+
+// GetOrSet returns the value stored for key, or if there is none, calls load and stores its
+// result for ttl, or for the default time to live if ttl is zero; a negative ttl means the
+// result does not expire. Concurrent calls for the same key wait for the load in progress
+// instead of calling load again. If load fails, nothing is stored and its error is returned
+// to every waiting caller; the next call retries.
+// If load panics, the panic is propagated, and the waiting callers get it as a *PanicError.
+//
+// Examples:
+//
+//	user, err := users.GetOrSet(id, 0, func() (*User, error) { return db.FindUser(ctx, id) })
+func (c *TTLCache[K, V]) GetOrSet(key K, ttl time.Duration, load func() (V, error)) (V, error) {
+	if v, ok := c.Get(key); ok {
+		return v, nil
+	}
+	if ttl == 0 {
+		ttl = c.defaultTTL
+	}
+
+	c.mu.Lock()
+	if l, ok := c.loads[key]; ok {
+		c.mu.Unlock()
+		<-l.done
+		return l.value, l.err
+	}
+	// Check again: another caller may have stored the value since Get.
+	if e, ok := c.entries[key]; ok {
+		if entry := e.Value.(*ttlCacheEntry[K, V]); !entry.expired(time.Now()) {
+			c.mu.Unlock()
+			return entry.value, nil
+		}
+	}
+	l := &ttlCacheLoad[V]{done: make(chan struct{})}
+	c.loads[key] = l
+	c.mu.Unlock()
+
+	completed := false
+	defer func() {
+		if !completed {
+			r := recover()
+			l.err = &PanicError{Value: r, Stack: debug.Stack()}
+			c.finishLoad(key, l, ttl)
+			panic(r)
+		}
+	}()
+	l.value, l.err = load()
+	completed = true
+	c.finishLoad(key, l, ttl)
+	return l.value, l.err
+}
+
+// Delete removes the entry for key, if any.
+func (c *TTLCache[K, V]) Delete(key K) {
+	c.mu.Lock()
+	e, ok := c.entries[key]
+	if !ok {
+		c.mu.Unlock()
+		return
+	}
+	c.remove(e)
+	c.mu.Unlock()
+	c.evicted(e.Value.(*ttlCacheEntry[K, V]))
+}
+
+// DeleteExpired removes the expired entries. The background cleanup calls it periodically.
+func (c *TTLCache[K, V]) DeleteExpired() {
+	c.mu.Lock()
+	evicted := c.deleteExpired(time.Now())
+	c.mu.Unlock()
+	c.evicted(evicted...)
+}
+
+// Len returns the number of entries in the cache, including expired ones not yet removed.
+func (c *TTLCache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// Close stops the background cleanup. The cache can still be used afterwards, with expired
+// entries dropped only when read or when the cache is full.
+func (c *TTLCache[K, V]) Close() {
+	c.stop()
+}
+
+// set stores value for key and returns the entries evicted to make room. c.mu must be held.
+func (c *TTLCache[K, V]) set(key K, value V, ttl time.Duration, now time.Time) []*ttlCacheEntry[K, V] {
+	var expires time.Time
+	if ttl > 0 {
+		expires = now.Add(ttl)
+	}
+	if e, ok := c.entries[key]; ok {
+		entry := e.Value.(*ttlCacheEntry[K, V])
+		entry.value, entry.expires = value, expires
+		c.order.MoveToBack(e)
+		return nil
+	}
+
+	var evicted []*ttlCacheEntry[K, V]
+	if c.opts.maxSize > 0 && len(c.entries) >= c.opts.maxSize {
+		evicted = c.deleteExpired(now)
+		for len(c.entries) >= c.opts.maxSize {
+			oldest := c.order.Front()
+			c.remove(oldest)
+			evicted = append(evicted, oldest.Value.(*ttlCacheEntry[K, V]))
+		}
+	}
+	c.entries[key] = c.order.PushBack(&ttlCacheEntry[K, V]{key: key, value: value, expires: expires})
+	return evicted
+}
+
+// deleteExpired removes the entries expired at now and returns them. c.mu must be held.
+func (c *TTLCache[K, V]) deleteExpired(now time.Time) []*ttlCacheEntry[K, V] {
+	var evicted []*ttlCacheEntry[K, V]
+	for e := c.order.Front(); e != nil; {
+		next := e.Next()
+		if entry := e.Value.(*ttlCacheEntry[K, V]); entry.expired(now) {
+			c.remove(e)
+			evicted = append(evicted, entry)
+		}
+		e = next
+	}
+	return evicted
+}
+
+// remove removes the entry held by e. c.mu must be held.
+func (c *TTLCache[K, V]) remove(e *list.Element) {
+	c.order.Remove(e)
+	delete(c.entries, e.Value.(*ttlCacheEntry[K, V]).key)
+}
+
+// finishLoad stores the result of a successful load, and wakes the callers waiting for it.
+func (c *TTLCache[K, V]) finishLoad(key K, l *ttlCacheLoad[V], ttl time.Duration) {
+	c.mu.Lock()
+	delete(c.loads, key)
+	var evicted []*ttlCacheEntry[K, V]
+	if l.err == nil {
+		evicted = c.set(key, l.value, ttl, time.Now())
+	}
+	c.mu.Unlock()
+	close(l.done)
+	c.evicted(evicted...)
+}
+
+// evicted calls the eviction callback with entries. c.mu must not be held.
+func (c *TTLCache[K, V]) evicted(entries ...*ttlCacheEntry[K, V]) {
+	if c.opts.onEvict == nil {
+		return
+	}
+	for _, entry := range entries {
+		c.opts.onEvict(entry.key, entry.value)
+	}
+}
+
+// expired reports whether the entry has expired at now.
+func (e *ttlCacheEntry[K, V]) expired(now time.Time) bool {
+	return !e.expires.IsZero() && !now.Before(e.expires)
+}
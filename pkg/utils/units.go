@@ -0,0 +1,212 @@
+package utils
+
+import "fmt"
+
+// Unit is a unit of measure understood by Convert.
+type Unit string
+
+// Units supported by Convert, grouped by the quantity they measure.
+const (
+	Celsius    Unit = "C"
+	Fahrenheit Unit = "F"
+	Kelvin     Unit = "K"
+
+	Meter     Unit = "m"
+	Kilometer Unit = "km"
+	Foot      Unit = "ft"
+	Mile      Unit = "mi"
+
+	Kilogram Unit = "kg"
+	Pound    Unit = "lb"
+)
+
+// Exact conversion factors from the international yard and pound agreement of 1959.
+const (
+	metersPerFoot     = 0.3048
+	metersPerMile     = 1609.344
+	kilogramsPerPound = 0.45359237
+)
+
+// linearUnits gives each length and weight unit's quantity and its size in that quantity's base unit.
+var linearUnits = map[Unit]struct {
+	quantity string
+	factor   float64
+}{
+	Meter:     {"length", 1},
+	Kilometer: {"length", 1000},
+	Foot:      {"length", metersPerFoot},
+	Mile:      {"length", metersPerMile},
+	Kilogram:  {"weight", 1},
+	Pound:     {"weight", kilogramsPerPound},
+}
+
+// CelsiusToFahrenheit converts a temperature from degrees Celsius to degrees Fahrenheit.
+//
+// Examples:
+//
+//	CelsiusToFahrenheit(100) == 212
+//	CelsiusToFahrenheit(-40) == -40
+func CelsiusToFahrenheit(c float64) float64 {
+	return c*9/5 + 32
+}
+
+// FahrenheitToCelsius converts a temperature from degrees Fahrenheit to degrees Celsius.
+//
+// Examples:
+//
+//	FahrenheitToCelsius(212) == 100
+//	FahrenheitToCelsius(32) == 0
+func FahrenheitToCelsius(f float64) float64 {
+	return (f - 32) * 5 / 9
+}
+
+// CelsiusToKelvin converts a temperature from degrees Celsius to kelvins.
+//
+// Examples:
+//
+//	CelsiusToKelvin(0) == 273.15
+func CelsiusToKelvin(c float64) float64 {
+	return c + 273.15
+}
+
+// KelvinToCelsius converts a temperature from kelvins to degrees Celsius.
+//
+// Examples:
+//
+//	KelvinToCelsius(273.15) == 0
+func KelvinToCelsius(k float64) float64 {
+	return k - 273.15
+}
+
+// FahrenheitToKelvin converts a temperature from degrees Fahrenheit to kelvins.
+//
+// Examples:
+//
+//	FahrenheitToKelvin(32) == 273.15
+func FahrenheitToKelvin(f float64) float64 {
+	return CelsiusToKelvin(FahrenheitToCelsius(f))
+}
+
+// KelvinToFahrenheit converts a temperature from kelvins to degrees Fahrenheit.
+//
+// Examples:
+//
+//	KelvinToFahrenheit(373.15) ≈ 212
+func KelvinToFahrenheit(k float64) float64 {
+	return CelsiusToFahrenheit(KelvinToCelsius(k))
+}
+
+// KilometersToMiles converts a distance from kilometers to international miles.
+//
+// Examples:
+//
+//	KilometersToMiles(1.609344) == 1
+func KilometersToMiles(km float64) float64 {
+	return km * 1000 / metersPerMile
+}
+
+// MilesToKilometers converts a distance from international miles to kilometers.
+//
+// Examples:
+//
+//	MilesToKilometers(26.2) ≈ 42.165
+func MilesToKilometers(mi float64) float64 {
+	return mi * metersPerMile / 1000
+}
+
+// MetersToFeet converts a distance from meters to international feet.
+//
+// Examples:
+//
+//	MetersToFeet(0.3048) == 1
+func MetersToFeet(m float64) float64 {
+	return m / metersPerFoot
+}
+
+// FeetToMeters converts a distance from international feet to meters.
+//
+// Examples:
+//
+//	FeetToMeters(10) == 3.048
+func FeetToMeters(ft float64) float64 {
+	return ft * metersPerFoot
+}
+
+// KilogramsToPounds converts a weight from kilograms to avoirdupois pounds.
+//
+// Examples:
+//
+//	KilogramsToPounds(1) ≈ 2.20462
+func KilogramsToPounds(kg float64) float64 {
+	return kg / kilogramsPerPound
+}
+
+// PoundsToKilograms converts a weight from avoirdupois pounds to kilograms.
+//
+// Examples:
+//
+//	PoundsToKilograms(1) == 0.45359237
+func PoundsToKilograms(lb float64) float64 {
+	return lb * kilogramsPerPound
+}
+
+// Convert converts value from one unit to another of the same quantity: temperature
+// (Celsius, Fahrenheit, Kelvin), length (Meter, Kilometer, Foot, Mile) or weight
+// (Kilogram, Pound). Converting a unit to itself returns value unchanged.
+// It returns an error if either unit is unknown or the units measure different quantities.
+//
+// Examples:
+//
+//	Convert(100, Celsius, Fahrenheit) == (212, nil)
+//	Convert(5, Kilometer, Meter) == (5000, nil)
+//	Convert(3, Mile, Foot) ≈ (15840, nil)
+//	Convert(1, Kilogram, Mile) returns an error
+func Convert(value float64, from, to Unit) (float64, error) {
+	if celsius, ok := toCelsius(value, from); ok {
+		switch to {
+		case Celsius:
+			return celsius, nil
+		case Fahrenheit:
+			return CelsiusToFahrenheit(celsius), nil
+		case Kelvin:
+			return CelsiusToKelvin(celsius), nil
+		}
+		if dst, ok := linearUnits[to]; ok {
+			return 0, fmt.Errorf("cannot convert temperature unit %q to %s unit %q", from, dst.quantity, to)
+		}
+		return 0, fmt.Errorf("unknown unit %q", to)
+	}
+
+	src, ok := linearUnits[from]
+	if !ok {
+		return 0, fmt.Errorf("unknown unit %q", from)
+	}
+	dst, ok := linearUnits[to]
+	if !ok {
+		if _, isTemp := toCelsius(0, to); !isTemp {
+			return 0, fmt.Errorf("unknown unit %q", to)
+		}
+		return 0, fmt.Errorf("cannot convert %s unit %q to temperature unit %q", src.quantity, from, to)
+	}
+	if src.quantity != dst.quantity {
+		return 0, fmt.Errorf("cannot convert %s unit %q to %s unit %q", src.quantity, from, dst.quantity, to)
+	}
+	if from == to {
+		return value, nil
+	}
+	return value * src.factor / dst.factor, nil
+}
+
+// toCelsius converts value in the temperature unit u to degrees Celsius,
+// reporting false if u is not a temperature unit.
+func toCelsius(value float64, u Unit) (float64, bool) {
+	switch u {
+	case Celsius:
+		return value, true
+	case Fahrenheit:
+		return FahrenheitToCelsius(value), true
+	case Kelvin:
+		return KelvinToCelsius(value), true
+	}
+	return 0, false
+}
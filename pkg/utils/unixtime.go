@@ -0,0 +1,128 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FromUnixMillis returns the UTC time ms milliseconds after the Unix epoch.
+//
+// Examples:
+//
+//	FromUnixMillis(1700000000123) == time.Date(2023, 11, 14, 22, 13, 20, 123000000, time.UTC)
+func FromUnixMillis(ms int64) time.Time {
+	return time.UnixMilli(ms).UTC()
+}
+
+// ToUnixMillis returns t as the number of milliseconds since the Unix epoch, truncating
+// any finer precision.
+//
+// Examples:
+//
+//	ToUnixMillis(time.Date(2023, 11, 14, 22, 13, 20, 123456789, time.UTC)) == 1700000000123
+func ToUnixMillis(t time.Time) int64 {
+	return t.UnixMilli()
+}
+
+// FromUnixMicros returns the UTC time us microseconds after the Unix epoch.
+//
+// Examples:
+//
+//	FromUnixMicros(1700000000123456) == time.Date(2023, 11, 14, 22, 13, 20, 123456000, time.UTC)
+func FromUnixMicros(us int64) time.Time {
+	return time.UnixMicro(us).UTC()
+}
+
+// ToUnixMicros returns t as the number of microseconds since the Unix epoch, truncating
+// any finer precision.
+//
+// Examples:
+//
+//	ToUnixMicros(time.Date(2023, 11, 14, 22, 13, 20, 123456789, time.UTC)) == 1700000000123456
+func ToUnixMicros(t time.Time) int64 {
+	return t.UnixMicro()
+}
+
+// Magnitudes at which ParseUnixAny switches to a finer unit. 1e11 seconds is in the year 5138,
+// while 1e11 milliseconds is in March 1973, so real timestamps rarely fall on the wrong side.
+const (
+	unixMillisThreshold = 1e11
+	unixMicrosThreshold = 1e14
+	unixNanosThreshold  = 1e17
+)
+
+// ParseUnixAny parses s as a Unix timestamp in seconds, milliseconds, microseconds or
+// nanoseconds, detecting the unit from its magnitude: values below 1e11 are seconds, below
+// 1e14 milliseconds, below 1e17 microseconds, and larger ones nanoseconds. This normalizes
+// timestamps from APIs that disagree on the unit, as long as those not in seconds are more than
+// about three years from 1970. A value with a decimal point, such as
+// "1700000000.5", is always read as seconds with a fraction. The result is in UTC.
+// It returns an error if s is not an optionally signed integer or decimal.
+//
+// Examples:
+//
+//	ParseUnixAny("1700000000") == (time.Date(2023, 11, 14, 22, 13, 20, 0, time.UTC), nil)
+//	ParseUnixAny("1700000000123") == (time.Date(2023, 11, 14, 22, 13, 20, 123000000, time.UTC), nil)
+//	ParseUnixAny("1700000000123456") == (time.Date(2023, 11, 14, 22, 13, 20, 123456000, time.UTC), nil)
+//	ParseUnixAny("1700000000.25") == (time.Date(2023, 11, 14, 22, 13, 20, 250000000, time.UTC), nil)
+//	ParseUnixAny("yesterday") returns an error
+func ParseUnixAny(s string) (time.Time, error) {
+	str := strings.TrimSpace(s)
+	if whole, frac, ok := strings.Cut(str, "."); ok {
+		return parseUnixDecimal(s, whole, frac)
+	}
+	n, err := strconv.ParseInt(str, 10, 64)
+	if err != nil {
+		if errors.Is(err, strconv.ErrRange) {
+			return time.Time{}, fmt.Errorf("invalid Unix timestamp %q: %w", s, ErrOverflow)
+		}
+		return time.Time{}, fmt.Errorf("invalid Unix timestamp %q", s)
+	}
+
+	abs := uint64(n)
+	if n < 0 {
+		abs = -abs
+	}
+	switch {
+	case abs < unixMillisThreshold:
+		return time.Unix(n, 0).UTC(), nil
+	case abs < unixMicrosThreshold:
+		return time.UnixMilli(n).UTC(), nil
+	case abs < unixNanosThreshold:
+		return time.UnixMicro(n).UTC(), nil
+	}
+	return time.Unix(0, n).UTC(), nil
+}
+
+// parseUnixDecimal parses a timestamp in seconds split at its decimal point into whole and frac,
+// keeping up to nanosecond precision. s is the original input, for error messages.
+func parseUnixDecimal(s, whole, frac string) (time.Time, error) {
+	neg := strings.HasPrefix(whole, "-")
+	digits := strings.TrimLeft(whole, "+-")
+	if len(whole)-len(digits) > 1 || (digits == "" && frac == "") || (digits != "" && !isASCIIDigits(digits)) ||
+		(frac != "" && !isASCIIDigits(frac)) {
+		return time.Time{}, fmt.Errorf("invalid Unix timestamp %q", s)
+	}
+
+	var sec int64
+	if digits != "" {
+		var err error
+		if sec, err = strconv.ParseInt(digits, 10, 64); err != nil {
+			return time.Time{}, fmt.Errorf("invalid Unix timestamp %q: %w", s, ErrOverflow)
+		}
+	}
+	if len(frac) > 9 {
+		frac = frac[:9]
+	}
+	var nsec int64
+	if frac != "" {
+		nsec, _ = strconv.ParseInt(frac+strings.Repeat("0", 9-len(frac)), 10, 64)
+	}
+	if neg {
+		sec, nsec = -sec, -nsec
+	}
+	return time.Unix(sec, nsec).UTC(), nil
+}
@@ -0,0 +1,72 @@
+package utils
+
+import "unsafe"
+
+// UnsafeStringToBytes returns a []byte that shares memory with s, without copying.
+// It is meant for hot paths that pass a string to an API taking []byte for reading only.
+//
+// The returned slice must never be modified: Go strings are immutable, and writing to the
+// slice is undefined behavior that may crash the program or corrupt other strings, including
+// string literals and map keys. The slice is valid for as long as s is reachable, and its
+// capacity equals its length, so appending to it always copies. An empty s yields nil.
+// Use StringToBytes when the caller may modify or retain the result.
+//
+// Examples:
+//
+//	b := UnsafeStringToBytes("hello") // b == []byte("hello"), sharing memory with the literal
+//	bytes.IndexByte(UnsafeStringToBytes(line), ':')
+//	UnsafeStringToBytes("") == nil
+func UnsafeStringToBytes(s string) []byte {
+	if s == "" {
+		return nil
+	}
+	return unsafe.Slice(unsafe.StringData(s), len(s))
+}
+
+// UnsafeBytesToString returns a string that shares memory with b, without copying.
+// It is meant for hot paths that build a value in a []byte and then need it as a string,
+// such as a map lookup or strconv call on a parser's buffer.
+//
+// The bytes of b must not be modified for as long as the returned string is in use:
+// doing so changes the "immutable" string, which breaks map keys, interned values and any
+// code that assumes strings never change. Do not use it on buffers that will be reused,
+// such as a bufio.Scanner's Bytes or a pooled buffer, unless the string is discarded first.
+// An empty b yields "".
+// Use BytesToString when the bytes may change later.
+//
+// Examples:
+//
+//	s := UnsafeBytesToString([]byte("hello")) // s == "hello", sharing memory with the slice
+//	strconv.Atoi(UnsafeBytesToString(field))
+//	UnsafeBytesToString(nil) == ""
+func UnsafeBytesToString(b []byte) string {
+	if len(b) == 0 {
+		return ""
+	}
+	return unsafe.String(unsafe.SliceData(b), len(b))
+}
+
+// StringToBytes returns a newly allocated copy of s as a []byte, which the caller may modify freely.
+// It is the safe counterpart of UnsafeStringToBytes, equivalent to []byte(s) except that an empty s yields nil.
+//
+// Examples:
+//
+//	StringToBytes("hello") == []byte("hello")
+//	StringToBytes("") == nil
+func StringToBytes(s string) []byte {
+	if s == "" {
+		return nil
+	}
+	return []byte(s)
+}
+
+// BytesToString returns a copy of b as a string, unaffected by later changes to b.
+// It is the safe counterpart of UnsafeBytesToString, equivalent to string(b).
+//
+// Examples:
+//
+//	BytesToString([]byte("hello")) == "hello"
+//	BytesToString(nil) == ""
+func BytesToString(b []byte) string {
+	return string(b)
+}
@@ -1019,28 +1019,6 @@ func SafeCompare(s1, s2 string) (int, error) {
 	return 0, nil
 }
 
-// ValidateEmail checks if a string is a valid email address.
-// It uses a regular expression for basic email format validation.
-// Note: This is a basic check and doesn't cover all RFC 5322 complexities.
-//
-// Examples:
-//
-//	ValidateEmail("test@example.com") == nil
-//	ValidateEmail("invalid-email") returns an error
-//	ValidateEmail("user+alias@domain.co.uk") == nil
-//	ValidateEmail("@domain.com") returns an error
-//	ValidateEmail("user@domain.") returns an error
-func ValidateEmail(email string) error {
-	// A common regex for basic email validation.
-	// It checks for a username part, followed by '@', then a domain part.
-	// The domain part must contain at least one dot.
-	const emailRegex = `^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`
-	if !regexp.MustCompile(emailRegex).MatchString(email) {
-		return errors.New("invalid email format")
-	}
-	return nil
-}
-
 // SafeNormalizeSpaces replaces multiple whitespace characters in a string with a single space.
 // It also trims leading and trailing whitespace.
 // It returns an error if the input string is nil, though Go strings are not nilable. This signature
@@ -1241,29 +1219,6 @@ func TakeWhile[T any](slice []T, predicate func(T) bool) []T {
 	return result
 }
 
-// ValidatePhone checks if a string is a valid phone number.
-// It uses a simplified regular expression to validate common phone number formats.
-// This validation is basic and might not cover all international formats or complex cases.
-// It returns an error if the string is not a valid phone number.
-//
-// Examples:
-//
-//	ValidatePhone("123-456-7890") == nil
-//	ValidatePhone("(123) 456-7890") == nil
-//	ValidatePhone("1234567890") == nil
-//	ValidatePhone("+1 123 456 7890") == nil
-//	ValidatePhone("123-456") returns an error
-//	ValidatePhone("abc") returns an error
-func ValidatePhone(phone string) error {
-	// This regex allows for optional country code, area code in parentheses, spaces, hyphens, and digits.
-	// It's a simplified pattern for common formats.
-	const phoneRegex = `^\+?(\d{1,3})?[-. ]?\(?\d{3}\)?[-. ]?\d{3}[-. ]?\d{4}$`
-	if !regexp.MustCompile(phoneRegex).MatchString(phone) {
-		return errors.New("invalid phone number format")
-	}
-	return nil
-}
-
 // DropWhile returns a new slice containing elements from the input slice
 // after the predicate function starts returning false.
 // The predicate function should return true for elements to discard and false for elements to keep.
@@ -2892,46 +2847,6 @@ func GroupBy[T any, K comparable](slice []T, keyFunc func(T) K) map[K][]T {
 	return grouped
 }
 
-// ValidateMACAddress checks if a string is a valid MAC address.
-// A MAC address consists of six groups of two hexadecimal digits, separated by colons or hyphens.
-// It returns an error if the string is not a valid MAC address.
-//
-// Examples:
-//
-//	ValidateMACAddress("00:1A:2B:3C:4D:5E") == nil
-//	ValidateMACAddress("00-1A-2B-3C-4D-5E") == nil
-//	ValidateMACAddress("001A2B3C4D5E") == nil
-//	ValidateMACAddress("00:1a:2b:3c:4d:5e") == nil // Case-insensitive
-//	ValidateMACAddress("00:1A:2B:3C:4D:5G") returns an error // Invalid character 'G'
-//	ValidateMACAddress("00:1A:2B:3C:4D") returns an error // Incorrect length
-//	ValidateMACAddress("00:1A:2B:3C:4D:5E:6F") returns an error // Too long
-func ValidateMACAddress(mac string) error {
-	// Normalize to colons for easier processing
-	mac = strings.ReplaceAll(mac, "-", ":")
-
-	// Split by colon
-	parts := strings.Split(mac, ":")
-
-	// Must have 6 parts
-	if len(parts) != 6 {
-		return errors.New("invalid MAC address format: must have 6 parts separated by colons")
-	}
-
-	for _, part := range parts {
-		// Each part must be 2 characters long
-		if len(part) != 2 {
-			return errors.New("invalid MAC address format: each part must be 2 hexadecimal digits")
-		}
-		// Each part must contain only hexadecimal characters
-		for _, r := range part {
-			if !unicode.IsDigit(r) && (r < 'a' || r > 'f') && (r < 'A' || r > 'F') {
-				return errors.New("invalid MAC address format: contains non-hexadecimal characters")
-			}
-		}
-	}
-	return nil
-}
-
 // UnionGeneric returns a new slice containing all unique elements from both input slices.
 // It uses generics to work with slices of any comparable type.
 // The order of elements in the resulting slice is not guaranteed.
@@ -3995,68 +3910,6 @@ func SafeMapKeys[K1 comparable, K2 comparable, V any](m map[K1]V, f func(K1) K2)
 	return result, nil
 }
 
-// ValidateDomain checks if a string is a valid domain name.
-// A valid domain name consists of labels separated by dots. Each label
-// must start and end with an alphanumeric character, and can contain
-// hyphens in between. The top-level domain (TLD) must be at least two
-// characters long and consist of letters.
-// It returns an error if the string is not a valid domain name.
-//
-// Examples:
-//
-//	ValidateDomain("example.com") == nil
-//	ValidateDomain("sub.example.co.uk") == nil
-//	ValidateDomain("example.io") == nil
-//	ValidateDomain("example") returns an error (missing TLD)
-//	ValidateDomain("example.c") returns an error (TLD too short)
-//	ValidateDomain("example-.com") returns an error (label ends with hyphen)
-//	ValidateDomain("-example.com") returns an error (label starts with hyphen)
-//	ValidateDomain("example..com") returns an error (empty label)
-//	ValidateDomain("example.c_m") returns an error (invalid character in TLD)
-//	ValidateDomain("example.123") returns an error (numeric TLD)
-//	ValidateDomain("") returns an error (empty string)
-func ValidateDomain(domain string) error {
-	if domain == "" {
-		return errors.New("domain cannot be empty")
-	}
-
-	// Split the domain into labels.
-	labels := strings.Split(domain, ".")
-
-	// Must have at least two labels (e.g., example.com).
-	if len(labels) < 2 {
-		return errors.New("invalid domain format: must have at least two labels (e.g., example.com)")
-	}
-
-	// Validate the TLD (last label).
-	tld := labels[len(labels)-1]
-	if len(tld) < 2 {
-		return errors.New("invalid domain format: top-level domain (TLD) must be at least two characters long")
-	}
-	for _, r := range tld {
-		if !unicode.IsLetter(r) {
-			return errors.New("invalid domain format: top-level domain (TLD) must contain only letters")
-		}
-	}
-
-	// Validate each label.
-	for _, label := range labels {
-		if label == "" {
-			return errors.New("invalid domain format: labels cannot be empty")
-		}
-		if label[0] == '-' || label[len(label)-1] == '-' {
-			return errors.New("invalid domain format: labels cannot start or end with a hyphen")
-		}
-		for _, r := range label {
-			if !unicode.IsLetter(r) && !unicode.IsNumber(r) && r != '-' {
-				return errors.New("invalid domain format: labels can only contain alphanumeric characters and hyphens")
-			}
-		}
-	}
-
-	return nil
-}
-
 // ToTitleCase converts a string to title case, capitalizing the first letter of each word.
 // Words are delimited by spaces. It handles Unicode characters correctly.
 //
@@ -4195,45 +4048,7 @@ func SafeMapKeys[K1 comparable, K2 comparable, V any](m map[K1]V, f func(K1) K2)
 //	SafeValidateDomain("example.123") returns (error) (numeric TLD)
 //	SafeValidateDomain("") returns (error) (empty string)
 func SafeValidateDomain(domain string) error {
-	if domain == "" {
-		return errors.New("domain cannot be empty")
-	}
-
-	// Split the domain into labels.
-	labels := strings.Split(domain, ".")
-
-	// Must have at least two labels (e.g., example.com).
-	if len(labels) < 2 {
-		return errors.New("invalid domain format: must have at least two labels (e.g., example.com)")
-	}
-
-	// Validate the TLD (last label).
-	tld := labels[len(labels)-1]
-	if len(tld) < 2 {
-		return errors.New("invalid domain format: top-level domain (TLD) must be at least two characters long")
-	}
-	for _, r := range tld {
-		if !unicode.IsLetter(r) {
-			return errors.New("invalid domain format: top-level domain (TLD) must contain only letters")
-		}
-	}
-
-	// Validate each label.
-	for _, label := range labels {
-		if label == "" {
-			return errors.New("invalid domain format: labels cannot be empty")
-		}
-		if label[0] == '-' || label[len(label)-1] == '-' {
-			return errors.New("invalid domain format: labels cannot start or end with a hyphen")
-		}
-		for _, r := range label {
-			if !unicode.IsLetter(r) && !unicode.IsNumber(r) && r != '-' {
-				return errors.New("invalid domain format: labels can only contain alphanumeric characters and hyphens")
-			}
-		}
-	}
-
-	return nil
+	return ValidateDomain(domain)
 }
 
 // ValidateNumeric checks if a string contains only numeric characters (0-9).
@@ -4436,47 +4251,6 @@ func ValidateAlphaNumeric(s string) error {
 	return nil
 }
 
-// ValidatePostalCode checks if a string is a valid postal code for a given country.
-// This function is a placeholder and currently only validates basic patterns.
-// For comprehensive validation, country-specific logic would need to be implemented.
-// It returns an error if the string is not a valid postal code.
-//
-// Examples:
-//
-//	ValidatePostalCode("12345", "US") == nil
-//	ValidatePostalCode("SW1A 0AA", "UK") == nil
-//	ValidatePostalCode("K1A 0B1", "CA") == nil
-//	ValidatePostalCode("1234", "US") returns an error (invalid US format)
-//	ValidatePostalCode("ABCDE", "XX") returns an error (unsupported country code)
-func ValidatePostalCode(code string, countryCode string) error {
-	if code == "" {
-		return errors.New("postal code cannot be empty")
-	}
-
-	// Convert country code to uppercase for case-insensitive comparison
-	countryCode = strings.ToUpper(countryCode)
-
-	switch countryCode {
-	case "US": // United States (5 digits or ZIP+4)
-		if !regexp.MustCompile(`^\d{5}(-\d{4})?$`).MatchString(code) {
-			return errors.New("invalid US postal code format")
-		}
-	case "UK": // United Kingdom (complex format)
-		// Basic UK format validation: outward code (2-4 chars) + inward code (3 chars)
-		// Example: SW1A 0AA, B33 8TH, M1 1AE
-		if !regexp.MustCompile(`^[A-Z]{1,2}[0-9][A-Z0-9]?\s?[0-9][A-Z]{2}$`).MatchString(strings.ToUpper(code)) {
-			return errors.New("invalid UK postal code format")
-		}
-	case "CA": // Canada (A1A 1A1 format)
-		if !regexp.MustCompile(`^[A-Z]\d[A-Z]\s?\d[A-Z]\d$`).MatchString(strings.ToUpper(code)) {
-			return errors.New("invalid Canadian postal code format")
-		}
-	default:
-		return errors.New("unsupported country code for postal code validation")
-	}
-	return nil
-}
-
 // RemoveAccents removes diacritical marks from accented characters in a string.
 // It converts characters like 'é', 'ü', 'ñ' to 'e', 'u', 'n' respectively.
 // This function is designed to handle common Latin-script accents. For more
@@ -5626,46 +5400,6 @@ func ValidateHex(s string) error {
 	return nil
 }
 
-// ValidateMACAddress checks if a string is a valid MAC address.
-// A MAC address consists of six groups of two hexadecimal digits, separated by colons or hyphens.
-// It returns an error if the string is not a valid MAC address.
-//
-// Examples:
-//
-//	ValidateMACAddress("00:1A:2B:3C:4D:5E") == nil
-//	ValidateMACAddress("00-1A-2B-3C-4D-5E") == nil
-//	ValidateMACAddress("001A2B3C4D5E") == nil
-//	ValidateMACAddress("00:1a:2b:3c:4d:5e") == nil // Case-insensitive
-//	ValidateMACAddress("00:1A:2B:3C:4D:5G") returns an error // Invalid character 'G'
-//	ValidateMACAddress("00:1A:2B:3C:4D") returns an error // Incorrect length
-//	ValidateMACAddress("00:1A:2B:3C:4D:5E:6F") returns an error // Too long
-func ValidateMACAddress(mac string) error {
-	// Normalize to colons for easier processing
-	mac = strings.ReplaceAll(mac, "-", ":")
-
-	// Split by colon
-	parts := strings.Split(mac, ":")
-
-	// Must have 6 parts
-	if len(parts) != 6 {
-		return errors.New("invalid MAC address format: must have 6 parts separated by colons")
-	}
-
-	for _, part := range parts {
-		// Each part must be 2 characters long
-		if len(part) != 2 {
-			return errors.New("invalid MAC address format: each part must be 2 hexadecimal digits")
-		}
-		// Each part must contain only hexadecimal characters
-		for _, r := range part {
-			if !unicode.IsDigit(r) && (r < 'a' || r > 'f') && (r < 'A' || r > 'F') {
-				return errors.New("invalid MAC address format: contains non-hexadecimal characters")
-			}
-		}
-	}
-	return nil
-}
-
 // Difference returns a new slice containing elements that are in slice1 but not in slice2.
 // It uses generics to work with slices of any comparable type.
 // The order of elements in the resulting slice is preserved from slice1.
@@ -7602,37 +7336,6 @@ func ToKebabCase(s string) string {
 	return builder.String()
 }
 
-// ValidateUUID checks if a string is a valid UUID (Universally Unique Identifier).
-// A valid UUID has the format "xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx", where 'x' represents a hexadecimal digit.
-// It returns an error if the string does not conform to this format or contains invalid characters.
-//
-// Examples:
-//
-//	ValidateUUID("a1b2c3d4-e5f6-7890-1234-567890abcdef") == nil
-//	ValidateUUID("A1B2C3D4-E5F6-7890-1234-567890ABCDEF") == nil // Case-insensitive
-//	ValidateUUID("a1b2c3d4e5f678901234567890abcdef") returns an error // Missing hyphens
-//	ValidateUUID("g1b2c3d4-e5f6-7890-1234-567890abcdef") returns an error // Invalid character 'g'
-//	ValidateUUID("a1b2c3d4-e5f6-7890-1234-567890abcde") returns an error // Incorrect length
-func ValidateUUID(uuid string) error {
-	if len(uuid) != 36 {
-		return errors.New("invalid UUID length: must be 36 characters (xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx)")
-	}
-
-	for i, r := range uuid {
-		switch i {
-		case 8, 13, 18, 23: // Hyphen positions
-			if r != '-' {
-				return errors.New("invalid UUID format: hyphens missing or misplaced")
-			}
-		default: // Hexadecimal digit positions
-			if !unicode.IsDigit(r) && (r < 'a' || r > 'f') && (r < 'A' || r > 'F') {
-				return errors.New("invalid UUID format: contains non-hexadecimal characters")
-			}
-		}
-	}
-	return nil
-}
-
 // FastMap applies a function to each element of a slice and returns a new slice with the results.
 // This version is optimized by pre-allocating the result slice capacity.
 // The function `f` takes an element of type T and returns an element of type U.
@@ -7762,76 +7465,6 @@ func SafeValidateMACAddress(mac string) (string, error) {
 	return builder.String(), nil
 }
 
-// ValidateHostname checks if a string is a valid hostname.
-// A valid hostname consists of labels separated by dots. Each label
-// must start and end with an alphanumeric character, and can contain
-// hyphens in between. Hostnames can also be IP addresses.
-// It returns an error if the string is not a valid hostname.
-//
-// Examples:
-//
-//	ValidateHostname("example.com") == nil
-//	ValidateHostname("sub.example.co.uk") == nil
-//	ValidateHostname("localhost") == nil
-//	ValidateHostname("192.168.1.1") == nil
-//	ValidateHostname("example-.com") returns an error (label ends with hyphen)
-//	ValidateHostname("-example.com") returns an error (label starts with hyphen)
-//	ValidateHostname("example..com") returns an error (empty label)
-//	ValidateHostname("example.c") returns an error (TLD too short)
-//	ValidateHostname("example.c_m") returns an error (invalid character in TLD)
-//	ValidateHostname("") returns an error
-func ValidateHostname(hostname string) error {
-	if hostname == "" {
-		return errors.New("hostname cannot be empty")
-	}
-
-	// Try to parse as an IP address first
-	if net.ParseIP(hostname) != nil {
-		return nil
-	}
-
-	// If not an IP address, validate as a hostname
-	labels := strings.Split(hostname, ".")
-
-	// Hostname must have at least one label (e.g., "localhost").
-	if len(labels) == 0 {
-		return errors.New("invalid hostname format: must have at least one label")
-	}
-
-	// Validate each label.
-	for _, label := range labels {
-		if label == "" {
-			return errors.New("invalid hostname format: labels cannot be empty")
-		}
-		if len(label) > 63 { // RFC specifies label length limit
-			return errors.New("invalid hostname format: label length exceeds 63 characters")
-		}
-		if label[0] == '-' || label[len(label)-1] == '-' {
-			return errors.New("invalid hostname format: labels cannot start or end with a hyphen")
-		}
-		for _, r := range label {
-			if !unicode.IsLetter(r) && !unicode.IsNumber(r) && r != '-' {
-				return errors.New("invalid hostname format: labels can only contain alphanumeric characters and hyphens")
-			}
-		}
-	}
-
-	// Validate the TLD (last label) if there are multiple labels.
-	if len(labels) > 1 {
-		tld := labels[len(labels)-1]
-		if len(tld) < 2 {
-			return errors.New("invalid hostname format: top-level domain (TLD) must be at least two characters long")
-		}
-		for _, r := range tld {
-			if !unicode.IsLetter(r) {
-				return errors.New("invalid hostname format: top-level domain (TLD) must contain only letters")
-			}
-		}
-	}
-
-	return nil
-}
-
 // ContainsAnyGeneric checks if a slice of any comparable type contains a specific item.
 // This function leverages Go generics to work with slices of any type that supports equality comparison.
 // It provides an efficient way to check for the presence of an element within a slice.
@@ -7853,37 +7486,6 @@ func ContainsAnyGeneric[T comparable](slice []T, item T) bool {
 	return false
 }
 
-// ValidateUUID checks if a string is a valid UUID (Universally Unique Identifier).
-// A valid UUID has the format "xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx", where 'x' represents a hexadecimal digit.
-// It returns an error if the string does not conform to this format or contains invalid characters.
-//
-// Examples:
-//
-//	ValidateUUID("a1b2c3d4-e5f6-7890-1234-567890abcdef") == nil
-//	ValidateUUID("A1B2C3D4-E5F6-7890-1234-567890ABCDEF") == nil // Case-insensitive
-//	ValidateUUID("a1b2c3d4e5f678901234567890abcdef") returns an error // Missing hyphens
-//	ValidateUUID("g1b2c3d4-e5f6-7890-1234-567890abcdef") returns an error // Invalid character 'g'
-//	ValidateUUID("a1b2c3d4-e5f6-7890-1234-567890abcde") returns an error // Incorrect length
-func ValidateUUID(uuid string) error {
-	if len(uuid) != 36 {
-		return errors.New("invalid UUID length: must be 36 characters (xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx)")
-	}
-
-	for i, r := range uuid {
-		switch i {
-		case 8, 13, 18, 23: // Hyphen positions
-			if r != '-' {
-				return errors.New("invalid UUID format: hyphens missing or misplaced")
-			}
-		default: // Hexadecimal digit positions
-			if !unicode.IsDigit(r) && (r < 'a' || r > 'f') && (r < 'A' || r > 'F') {
-				return errors.New("invalid UUID format: contains non-hexadecimal characters")
-			}
-		}
-	}
-	return nil
-}
-
 // SafeRemovePrefix removes the prefix from the string if present.
 // It returns the modified string and a nil error. If the string does not
 // start with the prefix, it returns the original string and a nil error.
@@ -8754,47 +8356,6 @@ func FastFind[T any](slice []T, predicate func(T) bool) (T, bool) {
 	return zero, false
 }
 
-// ValidatePostalCode checks if a string is a valid postal code for a given country.
-// This function is a placeholder and currently only validates basic patterns.
-// For comprehensive validation, country-specific logic would need to be implemented.
-// It returns an error if the string is not a valid postal code.
-//
-// Examples:
-//
-//	ValidatePostalCode("12345", "US") == nil
-//	ValidatePostalCode("SW1A 0AA", "UK") == nil
-//	ValidatePostalCode("K1A 0B1", "CA") == nil
-//	ValidatePostalCode("1234", "US") returns an error (invalid US format)
-//	ValidatePostalCode("ABCDE", "XX") returns an error (unsupported country code)
-func ValidatePostalCode(code string, countryCode string) error {
-	if code == "" {
-		return errors.New("postal code cannot be empty")
-	}
-
-	// Convert country code to uppercase for case-insensitive comparison
-	countryCode = strings.ToUpper(countryCode)
-
-	switch countryCode {
-	case "US": // United States (5 digits or ZIP+4)
-		if !regexp.MustCompile(`^\d{5}(-\d{4})?$`).MatchString(code) {
-			return errors.New("invalid US postal code format")
-		}
-	case "UK": // United Kingdom (complex format)
-		// Basic UK format validation: outward code (2-4 chars) + inward code (3 chars)
-		// Example: SW1A 0AA, B33 8TH, M1 1AE
-		if !regexp.MustCompile(`^[A-Z]{1,2}[0-9][A-Z0-9]?\s?[0-9][A-Z]{2}$`).MatchString(strings.ToUpper(code)) {
-			return errors.New("invalid UK postal code format")
-		}
-	case "CA": // Canada (A1A 1A1 format)
-		if !regexp.MustCompile(`^[A-Z]\d[A-Z]\s?\d[A-Z]\d$`).MatchString(strings.ToUpper(code)) {
-			return errors.New("invalid Canadian postal code format")
-		}
-	default:
-		return errors.New("unsupported country code for postal code validation")
-	}
-	return nil
-}
-
 // ToCamelCase converts a string from snake_case or kebab-case to camelCase.
 // It handles strings that are already in camelCase or PascalCase by simply returning them.
 // It also handles strings that are all uppercase (like acronyms) by returning them as is.
@@ -10764,31 +10325,6 @@ func FindLastIndex[T any](slice []T, predicate func(T) bool) int {
 	return -1
 }
 
-// ValidateEmail checks if a string is a valid email address.
-// It uses a regular expression for basic email format validation.
-// Note: This is a basic check and doesn't cover all RFC 5322 complexities.
-//
-// @param email The string to validate as an email address.
-// @return An error if the email format is invalid, otherwise nil.
-//
-// Examples:
-//
-//	ValidateEmail("test@example.com") == nil
-//	ValidateEmail("invalid-email") returns an error
-//	ValidateEmail("user+alias@domain.co.uk") == nil
-//	ValidateEmail("@domain.com") returns an error
-//	ValidateEmail("user@domain.") returns an error
-func ValidateEmail(email string) error {
-	// A common regex for basic email validation.
-	// It checks for a username part, followed by '@', then a domain part.
-	// The domain part must contain at least one dot.
-	const emailRegex = `^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`
-	if !regexp.MustCompile(emailRegex).MatchString(email) {
-		return errors.New("invalid email format")
-	}
-	return nil
-}
-
 // FastFindIndex returns the index of the first element in a slice that satisfies a given predicate function.
 // This version is optimized by returning immediately once a match is found.
 // The predicate function should return true for the element to find.
@@ -12115,47 +11651,6 @@ func SafePartition[T any](slice []T, predicate func(T) bool) ([][]T, error) {
 		if predicate(item) {
 			trueSlice = append(true
 
-// ValidatePostalCode checks if a string is a valid postal code for a given country.
-// This function is a placeholder and currently only validates basic patterns.
-// For comprehensive validation, country-specific logic would need to be implemented.
-// It returns an error if the string is not a valid postal code.
-//
-// Examples:
-//
-//	ValidatePostalCode("12345", "US") == nil
-//	ValidatePostalCode("SW1A 0AA", "UK") == nil
-//	ValidatePostalCode("K1A 0B1", "CA") == nil
-//	ValidatePostalCode("1234", "US") returns an error (invalid US format)
-//	ValidatePostalCode("ABCDE", "XX") returns an error (unsupported country code)
-func ValidatePostalCode(code string, countryCode string) error {
-	if code == "" {
-		return errors.New("postal code cannot be empty")
-	}
-
-	// Convert country code to uppercase for case-insensitive comparison
-	countryCode = strings.ToUpper(countryCode)
-
-	switch countryCode {
-	case "US": // United States (5 digits or ZIP+4)
-		if !regexp.MustCompile(`^\d{5}(-\d{4})?$`).MatchString(code) {
-			return errors.New("invalid US postal code format")
-		}
-	case "UK": // United Kingdom (complex format)
-		// Basic UK format validation: outward code (2-4 chars) + inward code (3 chars)
-		// Example: SW1A 0AA, B33 8TH, M1 1AE
-		if !regexp.MustCompile(`^[A-Z]{1,2}[0-9][A-Z0-9]?\s?[0-9][A-Z]{2}$`).MatchString(strings.ToUpper(code)) {
-			return errors.New("invalid UK postal code format")
-		}
-	case "CA": // Canada (A1A 1A1 format)
-		if !regexp.MustCompile(`^[A-Z]\d[A-Z]\s?\d[A-Z]\d$`).MatchString(strings.ToUpper(code)) {
-			return errors.New("invalid Canadian postal code format")
-		}
-	default:
-		return errors.New("unsupported country code for postal code validation")
-	}
-	return nil
-}
-
 // ToTitleCase converts a string to title case, capitalizing the first letter of each word.
 // Words are delimited by spaces. It handles Unicode characters correctly.
 //
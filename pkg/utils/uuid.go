@@ -0,0 +1,199 @@
+package utils
+
+import (
+	crand "crypto/rand"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+)
+
+// UUID is a 128-bit universally unique identifier as defined by RFC 9562.
+type UUID [16]byte
+
+// uuidHexOffsets are the positions of each byte's two hex digits in the canonical UUID form.
+var uuidHexOffsets = [16]int{0, 2, 4, 6, 9, 11, 14, 16, 19, 21, 24, 26, 28, 30, 32, 34}
+
+// uuidV7State remembers the last timestamp and counter handed out by NewUUIDv7,
+// so that identifiers generated within the same millisecond still sort in creation order.
+var uuidV7State struct {
+	sync.Mutex
+	lastMillis int64
+	counter    uint16
+}
+
+// ParseUUID parses a UUID in the canonical form "xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx".
+// Hexadecimal digits may be upper or lower case. The form wrapped in braces ("{...}")
+// and the "urn:uuid:" prefix are also accepted.
+// It returns an error if the string is not a well-formed UUID.
+//
+// Examples:
+//
+//	ParseUUID("f81d4fae-7dec-11d0-a765-00a0c91e6bf6") returns the UUID and nil
+//	ParseUUID("{F81D4FAE-7DEC-11D0-A765-00A0C91E6BF6}") returns the UUID and nil
+//	ParseUUID("f81d4fae7dec11d0a76500a0c91e6bf6") returns an error
+func ParseUUID(s string) (UUID, error) {
+	var u UUID
+	switch {
+	case len(s) == 38 && s[0] == '{' && s[37] == '}':
+		s = s[1:37]
+	case len(s) == 45 && s[:9] == "urn:uuid:":
+		s = s[9:]
+	}
+	if len(s) != 36 {
+		return u, errors.New("invalid UUID length: must be 36 characters (xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx)")
+	}
+	if s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return u, errors.New("invalid UUID format: hyphens missing or misplaced")
+	}
+	for i, pos := range uuidHexOffsets {
+		hi, ok1 := fromHexDigit(s[pos])
+		lo, ok2 := fromHexDigit(s[pos+1])
+		if !ok1 || !ok2 {
+			return UUID{}, errors.New("invalid UUID format: contains non-hexadecimal characters")
+		}
+		u[i] = hi<<4 | lo
+	}
+	return u, nil
+}
+
+// IsValidUUID reports whether s is a well-formed UUID according to ParseUUID.
+// Any version and variant are accepted, including the nil UUID.
+//
+// Examples:
+//
+//	IsValidUUID("f81d4fae-7dec-11d0-a765-00a0c91e6bf6") == true
+//	IsValidUUID("00000000-0000-0000-0000-000000000000") == true
+//	IsValidUUID("not-a-uuid") == false
+func IsValidUUID(s string) bool {
+	_, err := ParseUUID(s)
+	return err == nil
+}
+
+// ValidateUUID checks that s is a well-formed UUID according to ParseUUID.
+// It returns ParseUUID's error if it is not.
+//
+// Examples:
+//
+//	ValidateUUID("a1b2c3d4-e5f6-7890-1234-567890abcdef") == nil
+//	ValidateUUID("A1B2C3D4-E5F6-7890-1234-567890ABCDEF") == nil // Case-insensitive
+//	ValidateUUID("a1b2c3d4e5f678901234567890abcdef") returns an error // Missing hyphens
+//	ValidateUUID("g1b2c3d4-e5f6-7890-1234-567890abcdef") returns an error // Invalid character 'g'
+func ValidateUUID(s string) error {
+	_, err := ParseUUID(s)
+	return err
+}
+
+// String returns the canonical lower-case form of u, "xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx".
+func (u UUID) String() string {
+	var buf [36]byte
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+	return string(buf[:])
+}
+
+// Version returns the version number stored in u, such as 4 for random UUIDs
+// or 7 for time-ordered ones.
+func (u UUID) Version() int {
+	return int(u[6] >> 4)
+}
+
+// Time returns the creation time embedded in a version 7 UUID, with millisecond precision.
+// It returns false if u is not a version 7 UUID.
+func (u UUID) Time() (time.Time, bool) {
+	if u.Version() != 7 {
+		return time.Time{}, false
+	}
+	var ms int64
+	for _, b := range u[:6] {
+		ms = ms<<8 | int64(b)
+	}
+	return time.UnixMilli(ms), true
+}
+
+// NewUUIDv4 returns a new random (version 4) UUID in canonical form.
+// The random bits come from crypto/rand.
+// It panics if the system's secure random source fails, which does not happen on supported platforms.
+//
+// Examples:
+//
+//	NewUUIDv4() returns a value such as "3b241101-e2bb-4255-8caf-4136c566a962"
+func NewUUIDv4() string {
+	var u UUID
+	readUUIDRandom(u[:])
+	u[6] = u[6]&0x0f | 0x40
+	u[8] = u[8]&0x3f | 0x80
+	return u.String()
+}
+
+// NewUUIDv7 returns a new time-ordered (version 7) UUID in canonical form.
+// The first 48 bits hold the Unix time in milliseconds, so the identifiers sort
+// lexicographically by creation time, which makes them well suited as database keys.
+// Identifiers created within the same millisecond by one process are kept in order
+// with a 12-bit counter; the remaining bits come from crypto/rand.
+// It panics if the system's secure random source fails, which does not happen on supported platforms.
+//
+// Examples:
+//
+//	NewUUIDv7() returns a value such as "01932c07-2f1b-7a43-9e0c-5f3b4d2a8e71"
+//	NewUUIDv7() < NewUUIDv7() // when compared as strings
+func NewUUIDv7() string {
+	var u UUID
+	readUUIDRandom(u[:])
+
+	uuidV7State.Lock()
+	ms := time.Now().UnixMilli()
+	if ms <= uuidV7State.lastMillis {
+		// The clock has not advanced (or went backwards): keep the last timestamp and bump the counter,
+		// carrying into the timestamp when the counter runs out.
+		ms = uuidV7State.lastMillis
+		uuidV7State.counter++
+		if uuidV7State.counter > 0x0fff {
+			ms++
+			uuidV7State.counter = 0
+		}
+	} else {
+		// Start each new millisecond at a random counter value in the lower half of its range,
+		// leaving room for increments.
+		uuidV7State.counter = uint16(u[6]&0x07)<<8 | uint16(u[7])
+	}
+	uuidV7State.lastMillis = ms
+	counter := uuidV7State.counter
+	uuidV7State.Unlock()
+
+	for i := 5; i >= 0; i-- {
+		u[i] = byte(ms)
+		ms >>= 8
+	}
+	u[6] = 0x70 | byte(counter>>8)
+	u[7] = byte(counter)
+	u[8] = u[8]&0x3f | 0x80
+	return u.String()
+}
+
+// readUUIDRandom fills b from crypto/rand, panicking on failure.
+func readUUIDRandom(b []byte) {
+	if _, err := crand.Read(b); err != nil {
+		panic("utils: crypto/rand failed: " + err.Error())
+	}
+}
+
+// fromHexDigit returns the value of the hexadecimal digit c.
+func fromHexDigit(c byte) (byte, bool) {
+	switch {
+	case c >= '0' && c <= '9':
+		return c - '0', true
+	case c >= 'a' && c <= 'f':
+		return c - 'a' + 10, true
+	case c >= 'A' && c <= 'F':
+		return c - 'A' + 10, true
+	}
+	return 0, false
+}
@@ -0,0 +1,298 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// errInvalidRule marks errors caused by a malformed validate tag rather than by the value.
+var errInvalidRule = errors.New("invalid validate tag")
+
+// FieldError describes a struct field that failed one of its validation rules.
+type FieldError struct {
+	// Field is the path to the field, such as "Name" or "Address.City".
+	Field string
+	// Rule is the rule that failed, as written in the tag, for example "min=3".
+	Rule string
+	// Err is the underlying validation error.
+	Err error
+}
+
+// Error returns the field path followed by the underlying error.
+func (e *FieldError) Error() string {
+	return e.Field + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying validation error.
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationErrors is the list of field errors returned by Validate, in field order.
+type ValidationErrors []*FieldError
+
+// Error joins the messages of all field errors with "; ".
+func (errs ValidationErrors) Error() string {
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Validate checks the fields of the struct v (or pointer to struct) against the rules
+// in their `validate` tags, and returns a ValidationErrors listing every field that fails.
+// It returns nil if all fields pass.
+//
+// Rules are separated by commas:
+//   - required: the field must not be its zero value (for strings, not blank; for slices
+//     and maps, not empty);
+//   - min=N, max=N, len=N: a bound on the rune count of a string, the length of a slice,
+//     array or map, or the value of a number;
+//   - email, url, uuid: the string must pass ValidateEmail, ValidateURL or ParseUUID;
+//   - oneof=a b c: the string must be one of the space-separated values (see ValidateOneOf).
+//
+// Rules other than required are skipped for fields holding their zero value, so optional
+// fields can still carry format rules; a number that must be set and at least 18 needs
+// "required,min=18", since a zero passes "min=18" alone. Nested structs and pointers to
+// structs are validated recursively, with field paths such as "Address.City", and a pointer
+// back to a struct already being validated is not followed again; unexported fields are
+// ignored.
+// A tag that is malformed or that uses a rule not applicable to the field's type makes
+// Validate return a plain error instead of ValidationErrors.
+//
+// Examples:
+//
+//	type Signup struct {
+//		Name  string `validate:"required,min=3,max=50"`
+//		Email string `validate:"required,email"`
+//		Age   int    `validate:"min=18"`
+//	}
+//
+//	Validate(Signup{Name: "Ana Lima", Email: "ana@example.com", Age: 30}) == nil
+//	Validate(Signup{Name: "Al", Email: "nope", Age: 30}) returns ValidationErrors for Name and Email
+//	Validate(42) returns an error
+func Validate(v any) error {
+	active := map[validatePointer]bool{}
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return errors.New("cannot validate a nil pointer")
+		}
+		active[validatePointer{rv.Type(), rv.Pointer()}] = true
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("cannot validate a value of type %T: must be a struct", v)
+	}
+
+	var errs ValidationErrors
+	if err := validateStruct(rv, "", active, &errs); err != nil {
+		return err
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// validatePointer identifies a pointer followed by validateStruct. The type is part of it
+// because a struct and its first field share an address.
+type validatePointer struct {
+	typ  reflect.Type
+	addr uintptr
+}
+
+// validateStruct checks every exported field of rv, appending failures to errs. active holds
+// the pointers followed to reach rv, which are not followed again, to stop on cycles.
+// A non-nil return value means a tag is malformed.
+func validateStruct(rv reflect.Value, prefix string, active map[validatePointer]bool, errs *ValidationErrors) error {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		field := rv.Field(i)
+		path := prefix + sf.Name
+
+		if tag := sf.Tag.Get("validate"); tag != "" && tag != "-" {
+			failed, err := validateField(field, path, tag, errs)
+			if err != nil {
+				return err
+			}
+			if failed {
+				continue
+			}
+		}
+
+		// Descend into nested structs, whether held directly or through pointers.
+		if err := validateNested(field, path, active, errs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateNested validates field with validateStruct if it is a struct or a non-nil pointer
+// to one, unless one of the pointers leading to it is in active.
+func validateNested(field reflect.Value, path string, active map[validatePointer]bool, errs *ValidationErrors) error {
+	if field.Kind() == reflect.Pointer && !field.IsNil() {
+		p := validatePointer{field.Type(), field.Pointer()}
+		if active[p] {
+			return nil
+		}
+		active[p] = true
+		defer delete(active, p)
+		return validateNested(field.Elem(), path, active, errs)
+	}
+	if field.Kind() == reflect.Struct {
+		return validateStruct(field, path+".", active, errs)
+	}
+	return nil
+}
+
+// validateField applies the rules in tag to field and reports whether any of them failed.
+// It reports only the first failing rule, so each field contributes at most one error, but
+// checks every rule against the field's type, even for zero values, so that a malformed tag
+// is reported whatever the data.
+func validateField(field reflect.Value, path, tag string, errs *ValidationErrors) (bool, error) {
+	rules := strings.Split(tag, ",")
+	zero := isEmptyField(field)
+	for field.Kind() == reflect.Pointer {
+		if field.IsNil() {
+			field = reflect.Zero(field.Type().Elem())
+		} else {
+			field = field.Elem()
+		}
+	}
+
+	var failure *FieldError
+	for _, rule := range rules {
+		name, arg, _ := strings.Cut(strings.TrimSpace(rule), "=")
+		if name == "required" {
+			continue
+		}
+		err := applyRule(field, name, arg)
+		if errors.Is(err, errInvalidRule) {
+			return false, fmt.Errorf("field %s: %w", path, err)
+		}
+		if err != nil && failure == nil {
+			failure = &FieldError{Field: path, Rule: strings.TrimSpace(rule), Err: err}
+		}
+	}
+
+	if zero {
+		if hasRule(rules, "required") {
+			failure = &FieldError{Field: path, Rule: "required", Err: errors.New("value is required")}
+		} else {
+			failure = nil
+		}
+	}
+	if failure != nil {
+		*errs = append(*errs, failure)
+		return true, nil
+	}
+	return false, nil
+}
+
+// isEmptyField reports whether field counts as missing for the required rule: its zero
+// value, a blank string, or an empty slice, map or array.
+func isEmptyField(field reflect.Value) bool {
+	switch field.Kind() {
+	case reflect.String:
+		return IsEmpty(field.String())
+	case reflect.Slice, reflect.Map, reflect.Array:
+		return field.Len() == 0 || field.IsZero()
+	}
+	return field.IsZero()
+}
+
+// applyRule checks a single rule against v. Rules that are unknown, malformed,
+// or not applicable to v's type yield an error wrapping errInvalidRule.
+func applyRule(v reflect.Value, name, arg string) error {
+	switch name {
+	case "min", "max", "len":
+		bound, err := strconv.ParseFloat(arg, 64)
+		if err != nil {
+			return fmt.Errorf("%w: rule %q needs a numeric argument", errInvalidRule, name)
+		}
+		return applyBound(v, name, bound)
+	case "email", "url", "uuid", "oneof":
+		if v.Kind() != reflect.String {
+			return fmt.Errorf("%w: rule %q only applies to strings", errInvalidRule, name)
+		}
+		s := v.String()
+		switch name {
+		case "email":
+			return ValidateEmail(s)
+		case "url":
+			return ValidateURL(s)
+		case "uuid":
+			_, err := ParseUUID(s)
+			return err
+		default:
+			allowed := strings.Fields(arg)
+			if len(allowed) == 0 {
+				return fmt.Errorf("%w: rule %q needs at least one value", errInvalidRule, name)
+			}
+			return ValidateOneOf(s, allowed)
+		}
+	}
+	return fmt.Errorf("%w: unknown rule %q", errInvalidRule, name)
+}
+
+// applyBound checks a min, max or len rule against the length or numeric value of v.
+func applyBound(v reflect.Value, name string, bound float64) error {
+	switch v.Kind() {
+	case reflect.String:
+		if bound < 0 || bound != math.Trunc(bound) {
+			return fmt.Errorf("%w: rule %q needs a non-negative integer for strings", errInvalidRule, name)
+		}
+		n := int(bound)
+		switch name {
+		case "min":
+			return ValidateLength(v.String(), n, math.MaxInt)
+		case "max":
+			return ValidateLength(v.String(), 0, n)
+		default:
+			return ValidateLength(v.String(), n, n)
+		}
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return checkBound(float64(v.Len()), name, bound, "length")
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return checkBound(float64(v.Int()), name, bound, "value")
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return checkBound(float64(v.Uint()), name, bound, "value")
+	case reflect.Float32, reflect.Float64:
+		return checkBound(v.Float(), name, bound, "value")
+	}
+	return fmt.Errorf("%w: rule %q does not apply to %s", errInvalidRule, name, v.Kind())
+}
+
+// checkBound compares x, a length or value described by what, against a min, max or len bound.
+func checkBound(x float64, name string, bound float64, what string) error {
+	switch {
+	case name == "min" && x < bound:
+		return fmt.Errorf("%s must be at least %v", what, bound)
+	case name == "max" && x > bound:
+		return fmt.Errorf("%s must be at most %v", what, bound)
+	case name == "len" && x != bound:
+		return fmt.Errorf("%s must be exactly %v", what, bound)
+	}
+	return nil
+}
+
+// hasRule reports whether rules contains the argument-less rule name.
+func hasRule(rules []string, name string) bool {
+	for _, rule := range rules {
+		if strings.TrimSpace(rule) == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,203 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+// Validator is a reusable chain of checks for values of type T.
+// Build one with NewValidator, StringRule or NumberRule, add rules with the chaining
+// methods, and call Validate once per value. Rules run in the order they were added,
+// and every failure is reported, not just the first.
+// A Validator is safe for concurrent use once it is no longer being modified.
+type Validator[T comparable] struct {
+	required bool
+	// skipZero makes Validate accept the zero value without running any rules,
+	// so that optional fields can still carry format rules.
+	skipZero bool
+	rules    []func(T) error
+}
+
+// NewValidator returns an empty Validator for values of type T.
+// Unlike StringRule, its rules also run on the zero value unless Required is set.
+//
+// Examples:
+//
+//	weekday := NewValidator[time.Weekday]().Custom(func(d time.Weekday) error {
+//		if d == time.Saturday || d == time.Sunday {
+//			return errors.New("must be a weekday")
+//		}
+//		return nil
+//	})
+//	weekday.Validate(time.Monday) == nil
+//	weekday.Validate(time.Sunday) returns an error
+func NewValidator[T comparable]() *Validator[T] {
+	return &Validator[T]{}
+}
+
+// Required makes Validate reject the zero value of T. When the value is zero,
+// the other rules are not run and the only error reported is that the value is required.
+func (v *Validator[T]) Required() *Validator[T] {
+	v.required = true
+	return v
+}
+
+// Custom adds a rule implemented by fn, which should return a non-nil error
+// describing the problem when the value is invalid.
+func (v *Validator[T]) Custom(fn func(T) error) *Validator[T] {
+	v.rules = append(v.rules, fn)
+	return v
+}
+
+// Validate runs the rules against value and returns nil if it passes all of them.
+// Otherwise it returns the failures combined with errors.Join, in rule order,
+// so errors.Is and errors.As can still inspect each one.
+func (v *Validator[T]) Validate(value T) error {
+	var zero T
+	if value == zero {
+		if v.required {
+			return errors.New("value is required")
+		}
+		if v.skipZero {
+			return nil
+		}
+	}
+	var errs []error
+	for _, rule := range v.rules {
+		if err := rule(value); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// StringValidator is a Validator for strings with rules built on the package's string validators.
+type StringValidator struct {
+	Validator[string]
+}
+
+// StringRule returns an empty StringValidator.
+// Empty strings pass without running any rules unless Required is set.
+//
+// Examples:
+//
+//	username := StringRule().Required().MinLen(3).MaxLen(20).Matches(regexp.MustCompile(`^[a-z0-9_]+$`))
+//	username.Validate("ana_lima") == nil
+//	username.Validate("A!") returns an error describing both the length and the pattern failures
+//	StringRule().Email().Validate("") == nil // optional field
+func StringRule() *StringValidator {
+	return &StringValidator{Validator[string]{skipZero: true}}
+}
+
+// Required makes Validate reject the empty string.
+func (v *StringValidator) Required() *StringValidator {
+	v.Validator.Required()
+	return v
+}
+
+// Custom adds a rule implemented by fn.
+func (v *StringValidator) Custom(fn func(string) error) *StringValidator {
+	v.Validator.Custom(fn)
+	return v
+}
+
+// MinLen requires the string to have at least n runes.
+func (v *StringValidator) MinLen(n int) *StringValidator {
+	return v.Custom(func(s string) error {
+		if len([]rune(s)) < n {
+			return fmt.Errorf("must be at least %d characters long", n)
+		}
+		return nil
+	})
+}
+
+// MaxLen requires the string to have at most n runes.
+func (v *StringValidator) MaxLen(n int) *StringValidator {
+	return v.Custom(func(s string) error {
+		if len([]rune(s)) > n {
+			return fmt.Errorf("must be at most %d characters long", n)
+		}
+		return nil
+	})
+}
+
+// Matches requires the string to match re. Anchor the pattern with ^ and $ to match the whole string.
+func (v *StringValidator) Matches(re *regexp.Regexp) *StringValidator {
+	return v.Custom(func(s string) error {
+		if !re.MatchString(s) {
+			return fmt.Errorf("must match the pattern %s", re)
+		}
+		return nil
+	})
+}
+
+// OneOf requires the string to be one of allowed, as ValidateOneOf does.
+func (v *StringValidator) OneOf(allowed ...string) *StringValidator {
+	return v.Custom(func(s string) error { return ValidateOneOf(s, allowed) })
+}
+
+// Email requires the string to be an email address accepted by ValidateEmail, with the given options.
+func (v *StringValidator) Email(opts ...EmailOption) *StringValidator {
+	return v.Custom(func(s string) error { return ValidateEmail(s, opts...) })
+}
+
+// URL requires the string to be a URL accepted by ValidateURL.
+func (v *StringValidator) URL() *StringValidator {
+	return v.Custom(ValidateURL)
+}
+
+// NumberValidator is a Validator for numbers with range rules.
+type NumberValidator[T Number] struct {
+	Validator[T]
+}
+
+// NumberRule returns an empty NumberValidator for values of type T.
+// Zero is validated like any other value unless Required is set.
+//
+// Examples:
+//
+//	age := NumberRule[int]().Min(18).Max(130)
+//	age.Validate(30) == nil
+//	age.Validate(12) returns an error
+//	NumberRule[float64]().Between(0, 1).Validate(1.5) returns an error
+func NumberRule[T Number]() *NumberValidator[T] {
+	return &NumberValidator[T]{}
+}
+
+// Required makes Validate reject zero.
+func (v *NumberValidator[T]) Required() *NumberValidator[T] {
+	v.Validator.Required()
+	return v
+}
+
+// Custom adds a rule implemented by fn.
+func (v *NumberValidator[T]) Custom(fn func(T) error) *NumberValidator[T] {
+	v.Validator.Custom(fn)
+	return v
+}
+
+// Min requires the value to be at least min.
+func (v *NumberValidator[T]) Min(min T) *NumberValidator[T] {
+	return v.Custom(func(n T) error {
+		if n < min {
+			return fmt.Errorf("must be at least %v", min)
+		}
+		return nil
+	})
+}
+
+// Max requires the value to be at most max.
+func (v *NumberValidator[T]) Max(max T) *NumberValidator[T] {
+	return v.Custom(func(n T) error {
+		if n > max {
+			return fmt.Errorf("must be at most %v", max)
+		}
+		return nil
+	})
+}
+
+// Between requires the value to be within [min, max], inclusive.
+func (v *NumberValidator[T]) Between(min, max T) *NumberValidator[T] {
+	return v.Min(min).Max(max)
+}
@@ -0,0 +1,221 @@
+package utils
+
+import (
+	"errors"
+	"math"
+)
+
+// Vec2 is a two-dimensional vector.
+type Vec2 struct {
+	X, Y float64
+}
+
+// Add returns v + o.
+func (v Vec2) Add(o Vec2) Vec2 {
+	return Vec2{v.X + o.X, v.Y + o.Y}
+}
+
+// Sub returns v - o.
+func (v Vec2) Sub(o Vec2) Vec2 {
+	return Vec2{v.X - o.X, v.Y - o.Y}
+}
+
+// Scale returns v multiplied by the scalar s.
+func (v Vec2) Scale(s float64) Vec2 {
+	return Vec2{v.X * s, v.Y * s}
+}
+
+// Dot returns the dot product of v and o.
+//
+// Examples:
+//
+//	Vec2{1, 2}.Dot(Vec2{3, 4}) == 11
+func (v Vec2) Dot(o Vec2) float64 {
+	return v.X*o.X + v.Y*o.Y
+}
+
+// Cross returns the z component of the cross product of v and o, which is positive
+// when o is counter-clockwise from v.
+//
+// Examples:
+//
+//	Vec2{1, 0}.Cross(Vec2{0, 1}) == 1
+func (v Vec2) Cross(o Vec2) float64 {
+	return v.X*o.Y - v.Y*o.X
+}
+
+// Length returns the Euclidean length of v.
+//
+// Examples:
+//
+//	Vec2{3, 4}.Length() == 5
+func (v Vec2) Length() float64 {
+	return math.Hypot(v.X, v.Y)
+}
+
+// Distance returns the Euclidean distance between v and o.
+func (v Vec2) Distance(o Vec2) float64 {
+	return v.Sub(o).Length()
+}
+
+// Normalize returns the unit vector in the direction of v.
+// The zero vector is returned unchanged.
+//
+// Examples:
+//
+//	Vec2{3, 4}.Normalize() == Vec2{0.6, 0.8}
+//	Vec2{}.Normalize() == Vec2{}
+func (v Vec2) Normalize() Vec2 {
+	l := v.Length()
+	if l == 0 {
+		return v
+	}
+	return v.Scale(1 / l)
+}
+
+// Angle returns the angle of v in radians, measured counter-clockwise from the positive X axis,
+// in the range [-π, π].
+func (v Vec2) Angle() float64 {
+	return math.Atan2(v.Y, v.X)
+}
+
+// Vec3 is a three-dimensional vector.
+type Vec3 struct {
+	X, Y, Z float64
+}
+
+// Add returns v + o.
+func (v Vec3) Add(o Vec3) Vec3 {
+	return Vec3{v.X + o.X, v.Y + o.Y, v.Z + o.Z}
+}
+
+// Sub returns v - o.
+func (v Vec3) Sub(o Vec3) Vec3 {
+	return Vec3{v.X - o.X, v.Y - o.Y, v.Z - o.Z}
+}
+
+// Scale returns v multiplied by the scalar s.
+func (v Vec3) Scale(s float64) Vec3 {
+	return Vec3{v.X * s, v.Y * s, v.Z * s}
+}
+
+// Dot returns the dot product of v and o.
+//
+// Examples:
+//
+//	Vec3{1, 2, 3}.Dot(Vec3{4, 5, 6}) == 32
+func (v Vec3) Dot(o Vec3) float64 {
+	return v.X*o.X + v.Y*o.Y + v.Z*o.Z
+}
+
+// Cross returns the cross product of v and o.
+//
+// Examples:
+//
+//	Vec3{1, 0, 0}.Cross(Vec3{0, 1, 0}) == Vec3{0, 0, 1}
+func (v Vec3) Cross(o Vec3) Vec3 {
+	return Vec3{
+		v.Y*o.Z - v.Z*o.Y,
+		v.Z*o.X - v.X*o.Z,
+		v.X*o.Y - v.Y*o.X,
+	}
+}
+
+// Length returns the Euclidean length of v.
+//
+// Examples:
+//
+//	Vec3{2, 3, 6}.Length() == 7
+func (v Vec3) Length() float64 {
+	return math.Sqrt(v.Dot(v))
+}
+
+// Distance returns the Euclidean distance between v and o.
+func (v Vec3) Distance(o Vec3) float64 {
+	return v.Sub(o).Length()
+}
+
+// Normalize returns the unit vector in the direction of v.
+// The zero vector is returned unchanged.
+func (v Vec3) Normalize() Vec3 {
+	l := v.Length()
+	if l == 0 {
+		return v
+	}
+	return v.Scale(1 / l)
+}
+
+// Transform2D is a 2D affine transformation matrix
+//
+//	| A  C  E |
+//	| B  D  F |
+//	| 0  0  1 |
+//
+// which maps a point (x, y) to (A*x + C*y + E, B*x + D*y + F).
+// This is the same layout used by SVG and the HTML canvas.
+// The zero value is not the identity; use IdentityTransform.
+type Transform2D struct {
+	A, B, C, D, E, F float64
+}
+
+// IdentityTransform returns the transformation that leaves points unchanged.
+func IdentityTransform() Transform2D {
+	return Transform2D{A: 1, D: 1}
+}
+
+// TranslateTransform returns a transformation that moves points by (tx, ty).
+func TranslateTransform(tx, ty float64) Transform2D {
+	return Transform2D{A: 1, D: 1, E: tx, F: ty}
+}
+
+// ScaleTransform returns a transformation that scales points by sx horizontally and sy vertically.
+func ScaleTransform(sx, sy float64) Transform2D {
+	return Transform2D{A: sx, D: sy}
+}
+
+// RotateTransform returns a transformation that rotates points counter-clockwise by rad radians
+// around the origin.
+func RotateTransform(rad float64) Transform2D {
+	sin, cos := math.Sincos(rad)
+	return Transform2D{A: cos, B: sin, C: -sin, D: cos}
+}
+
+// Then returns the transformation that applies t first and then o.
+//
+// Examples:
+//
+//	ScaleTransform(2, 2).Then(TranslateTransform(1, 0)).Apply(Vec2{1, 1}) == Vec2{3, 2}
+func (t Transform2D) Then(o Transform2D) Transform2D {
+	return Transform2D{
+		A: o.A*t.A + o.C*t.B,
+		B: o.B*t.A + o.D*t.B,
+		C: o.A*t.C + o.C*t.D,
+		D: o.B*t.C + o.D*t.D,
+		E: o.A*t.E + o.C*t.F + o.E,
+		F: o.B*t.E + o.D*t.F + o.F,
+	}
+}
+
+// Apply returns the point p transformed by t.
+//
+// Examples:
+//
+//	TranslateTransform(5, -1).Apply(Vec2{1, 1}) == Vec2{6, 0}
+func (t Transform2D) Apply(p Vec2) Vec2 {
+	return Vec2{t.A*p.X + t.C*p.Y + t.E, t.B*p.X + t.D*p.Y + t.F}
+}
+
+// Inverse returns the transformation that undoes t.
+// It returns an error if t is not invertible (for example, a scale by zero).
+func (t Transform2D) Inverse() (Transform2D, error) {
+	det := t.A*t.D - t.B*t.C
+	if det == 0 || math.IsNaN(det) {
+		return Transform2D{}, errors.New("transform is not invertible")
+	}
+	a, b, c, d := t.D/det, -t.B/det, -t.C/det, t.A/det
+	return Transform2D{
+		A: a, B: b, C: c, D: d,
+		E: -(a*t.E + c*t.F),
+		F: -(b*t.E + d*t.F),
+	}, nil
+}
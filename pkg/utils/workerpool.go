@@ -0,0 +1,191 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+)
+
+// WorkerPool runs a function over jobs of type T with a fixed number of concurrent workers,
+// producing results of type R. A pool holds no goroutines between runs, so it can be reused
+// and needs no shutdown; each Run, RunOrdered or RunSlice call starts its own workers.
+//
+// Cancelling the context passed to a run stops new jobs from being started. Jobs already
+// started run to completion and their results are still delivered, so a run drains
+// gracefully; the function receives the same context and may return early once it is done.
+// A panic in the function is recovered and reported as that job's error, a *PanicError.
+type WorkerPool[T, R any] struct {
+	workers int
+	fn      func(ctx context.Context, job T) (R, error)
+}
+
+// JobResult is the outcome of one job run by a WorkerPool. Index is the job's position in
+// the input, counting from 0.
+type JobResult[T, R any] struct {
+	Index int
+	Job   T
+	Value R
+	Err   error
+}
+
+// indexedJob is a job paired with its position in the input.
+type indexedJob[T any] struct {
+	index int
+	job   T
+}
+
+// NewWorkerPool returns a WorkerPool that runs fn with the given number of workers.
+// If workers is less than 1, runtime.GOMAXPROCS(0) workers are used.
+//
+// Examples:
+//
+//	pool := NewWorkerPool(8, func(ctx context.Context, url string) (int, error) {
+//		resp, err := fetch(ctx, url)
+//		if err != nil {
+//			return 0, err
+//		}
+//		return resp.StatusCode, nil
+//	})
+//	statuses, err := pool.RunSlice(ctx, urls)
+func NewWorkerPool[T, R any](workers int, fn func(ctx context.Context, job T) (R, error)) *WorkerPool[T, R] {
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	return &WorkerPool[T, R]{workers: workers, fn: fn}
+}
+
+// Workers returns the number of workers p runs jobs with.
+func (p *WorkerPool[T, R]) Workers() int {
+	return p.workers
+}
+
+// Run consumes jobs until the channel is closed or ctx is done, and returns a channel that
+// delivers each job's result as it completes. The results channel is closed once every
+// started job has finished. The caller must receive from it until it is closed, or the
+// workers block.
+//
+// Examples:
+//
+//	for r := range pool.Run(ctx, jobs) {
+//		if r.Err != nil {
+//			log.Printf("job %d failed: %v", r.Index, r.Err)
+//		}
+//	}
+func (p *WorkerPool[T, R]) Run(ctx context.Context, jobs <-chan T) <-chan JobResult[T, R] {
+	queue := make(chan indexedJob[T])
+	go func() {
+		defer close(queue)
+		for i := 0; ctx.Err() == nil; i++ {
+			select {
+			case job, ok := <-jobs:
+				if !ok {
+					return
+				}
+				select {
+				case queue <- indexedJob[T]{i, job}:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	results := make(chan JobResult[T, R], p.workers)
+	var wg sync.WaitGroup
+	wg.Add(p.workers)
+	for w := 0; w < p.workers; w++ {
+		go func() {
+			defer wg.Done()
+			for j := range queue {
+				v, err := p.call(ctx, j.job)
+				results <- JobResult[T, R]{Index: j.index, Job: j.job, Value: v, Err: err}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+	return results
+}
+
+// RunOrdered is like Run, but delivers the results in the order the jobs were received.
+// A slow job holds back the results of the jobs after it, which are buffered meanwhile.
+func (p *WorkerPool[T, R]) RunOrdered(ctx context.Context, jobs <-chan T) <-chan JobResult[T, R] {
+	completed := p.Run(ctx, jobs)
+	ordered := make(chan JobResult[T, R], p.workers)
+	go func() {
+		defer close(ordered)
+		pending := make(map[int]JobResult[T, R])
+		next := 0
+		for r := range completed {
+			pending[r.Index] = r
+			for {
+				r, ok := pending[next]
+				if !ok {
+					break
+				}
+				delete(pending, next)
+				ordered <- r
+				next++
+			}
+		}
+	}()
+	return ordered
+}
+
+// RunSlice runs every job in jobs and returns the values in the same order, with the zero
+// value of R for jobs that failed or were not started. The error joins the error of each
+// failed job, prefixed with its index, and ctx's error if ctx was done before all jobs started;
+// it is nil if every job succeeded.
+//
+// Examples:
+//
+//	sizes, err := NewWorkerPool(4, fileSize).RunSlice(ctx, paths)
+func (p *WorkerPool[T, R]) RunSlice(ctx context.Context, jobs []T) ([]R, error) {
+	values := make([]R, len(jobs))
+	errs := make([]error, len(jobs))
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	wg.Add(p.workers)
+	for w := 0; w < p.workers; w++ {
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				i := int(next.Add(1) - 1)
+				if i >= len(jobs) {
+					return
+				}
+				values[i], errs[i] = p.call(ctx, jobs[i])
+			}
+		}()
+	}
+	wg.Wait()
+
+	var joined []error
+	for i, err := range errs {
+		if err != nil {
+			joined = append(joined, fmt.Errorf("job %d: %w", i, err))
+		}
+	}
+	if int(next.Load()) < len(jobs) {
+		joined = append(joined, ctx.Err())
+	}
+	return values, errors.Join(joined...)
+}
+
+// call runs p's function on job, turning a panic into an error.
+func (p *WorkerPool[T, R]) call(ctx context.Context, job T) (v R, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &PanicError{Value: r, Stack: debug.Stack()}
+		}
+	}()
+	return p.fn(ctx, job)
+}